@@ -5,6 +5,7 @@ package conflux
 import (
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/anthanhphan/gosdk/jcodec"
@@ -63,6 +64,19 @@ func MustLoad[T any](path string) *T {
 	return cfg
 }
 
+// Unmarshal decodes data into dst using the given format.
+// The format is a file extension without the dot: "json", "yaml" or "yml".
+// It is the same decoder Load uses, exposed for callers that already hold
+// the raw bytes (e.g., an HTTP request body).
+//
+// Example:
+//
+//	var cfg AppConfig
+//	err := conflux.Unmarshal(body, conflux.ExtensionYAML, &cfg)
+func Unmarshal(data []byte, format string, dst any) error {
+	return unmarshal(data, strings.ToLower(format), dst)
+}
+
 // validExts is the set of supported config file extensions.
 var validExts = map[string]bool{
 	ExtensionJSON: true,
@@ -70,13 +84,27 @@ var validExts = map[string]bool{
 	ExtensionYML:  true,
 }
 
-// unmarshal decodes data into dst based on file extension.
+// unmarshal decodes data into dst based on file extension. Duration strings
+// (e.g., "30s") are accepted for time.Duration fields and byte sizes (e.g.,
+// "4MB") for integer fields.
 func unmarshal(data []byte, ext string, dst any) error {
 	switch ext {
 	case ExtensionJSON:
+		// On malformed input the hooks are skipped so Unmarshal reports the error
+		if hooked, err := applyJSONHooks(data, reflect.TypeOf(dst)); err == nil {
+			data = hooked
+		}
 		return jcodec.Unmarshal(data, dst)
 	case ExtensionYAML, ExtensionYML:
-		return yaml.Unmarshal(data, dst)
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		if node.Kind == 0 {
+			return nil
+		}
+		applyYAMLHooks(&node, reflect.TypeOf(dst))
+		return node.Decode(dst)
 	default:
 		return fmt.Errorf("unsupported file extension: %s", ext)
 	}
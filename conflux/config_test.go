@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 // ============================================================================
@@ -324,6 +325,16 @@ func TestUnmarshal_YML(t *testing.T) {
 	}
 }
 
+func TestUnmarshal_Exported(t *testing.T) {
+	var cfg testConfig
+	if err := Unmarshal([]byte("database_url: pg\nport: 9090"), "YAML", &cfg); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if cfg.DatabaseURL != "pg" || cfg.Port != 9090 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
 func TestUnmarshal_Unsupported(t *testing.T) {
 	var cfg testConfig
 	err := unmarshal([]byte("data"), "xml", &cfg)
@@ -371,3 +382,53 @@ func TestValidExts(t *testing.T) {
 		}
 	}
 }
+
+// ============================================================================
+// Durations and Byte Sizes
+// ============================================================================
+
+type sizedConfig struct {
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	MaxBody int           `json:"max_body" yaml:"max_body"`
+	Buffers []uint32      `json:"buffers" yaml:"buffers"`
+	Limits  struct {
+		Upload int64 `json:"upload" yaml:"upload"`
+	} `json:"limits" yaml:"limits"`
+}
+
+func TestUnmarshal_DurationAndByteSize(t *testing.T) {
+	docs := map[string]string{
+		ExtensionJSON: `{"timeout":"30s","max_body":"4MB","buffers":["512KiB",1024],"limits":{"upload":"1.5 GB"}}`,
+		ExtensionYAML: "timeout: 30s\nmax_body: 4MB\nbuffers: [512KiB, 1024]\nlimits:\n  upload: 1.5 GB\n",
+	}
+	for format, doc := range docs {
+		t.Run(format, func(t *testing.T) {
+			var cfg sizedConfig
+			if err := Unmarshal([]byte(doc), format, &cfg); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if cfg.Timeout != 30*time.Second {
+				t.Errorf("timeout = %v, want 30s", cfg.Timeout)
+			}
+			if cfg.MaxBody != 4*1024*1024 {
+				t.Errorf("max_body = %d, want %d", cfg.MaxBody, 4*1024*1024)
+			}
+			if len(cfg.Buffers) != 2 || cfg.Buffers[0] != 512*1024 || cfg.Buffers[1] != 1024 {
+				t.Errorf("buffers = %v, want [524288 1024]", cfg.Buffers)
+			}
+			if cfg.Limits.Upload != 3*512*1024*1024 {
+				t.Errorf("limits.upload = %d, want %d", cfg.Limits.Upload, 3*512*1024*1024)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_InvalidByteSize(t *testing.T) {
+	var cfg sizedConfig
+	if err := Unmarshal([]byte(`{"max_body":"4 parsecs"}`), ExtensionJSON, &cfg); err == nil {
+		t.Error("expected error for unknown unit in JSON")
+	}
+	if err := Unmarshal([]byte("max_body: 4 parsecs"), ExtensionYAML, &cfg); err == nil {
+		t.Error("expected error for unknown unit in YAML")
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package conflux
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// Decode Hooks (durations and byte sizes)
+// ============================================================================
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// byteUnits maps lowercase byte-size units to their multiplier. Units are
+// binary, matching the 4*1024*1024 style defaults used across the SDK, so
+// "MB" and "MiB" both mean 1024*1024 bytes.
+var byteUnits = map[string]float64{
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// ParseByteSize parses a human-readable byte size such as "512KiB", "4MB" or
+// "1.5 GB" into a byte count. The unit is required and case-insensitive; see
+// the package README for the accepted units.
+func ParseByteSize(s string) (int64, bool) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end <= 0 {
+		return 0, false
+	}
+	mult, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[end:]))]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(n * mult), true
+}
+
+// customDecoders are interfaces whose implementations parse their own strings.
+var customDecoders = []reflect.Type{
+	reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem(),
+	reflect.TypeOf((*json.Unmarshaler)(nil)).Elem(),
+	reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem(),
+}
+
+// isInt reports whether t is a signed or unsigned integer kind other than
+// time.Duration. Types that decode themselves (e.g., a level enum) are
+// excluded.
+func isInt(t reflect.Type) bool {
+	if t == durationType {
+		return false
+	}
+	for _, iface := range customDecoders {
+		if reflect.PointerTo(t).Implements(iface) {
+			return false
+		}
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// convertScalar converts a string value for a field of type t: a duration
+// string for time.Duration fields and a byte size for integer fields. It
+// returns the decimal replacement, or false to leave the value untouched so
+// the decoder reports its usual error.
+func convertScalar(s string, t reflect.Type) (string, bool) {
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatInt(int64(d), 10), true
+	case isInt(t):
+		n, ok := ParseByteSize(s)
+		if !ok {
+			return "", false
+		}
+		return strconv.FormatInt(n, 10), true
+	}
+	return "", false
+}
+
+// fieldByName finds the struct field that a document key maps to, following
+// embedded structs. tag is the struct tag consulted for the key ("json" or
+// "yaml"); untagged fields match their name case-insensitively.
+func fieldByName(t reflect.Type, tag, key string) (reflect.Type, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if name == "" && ft.Kind() == reflect.Struct && (f.Anonymous || strings.Contains(opts, "inline")) {
+			if found, ok := fieldByName(ft, tag, key); ok {
+				return found, true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == key || (name == "" && strings.EqualFold(f.Name, key)) {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+// indirect strips pointer types.
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// applyJSONHooks rewrites duration and byte-size strings in a JSON document
+// into numbers for the matching fields of t.
+func applyJSONHooks(data []byte, t reflect.Type) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if t == nil || len(trimmed) == 0 {
+		return data, nil
+	}
+	t = indirect(t)
+
+	switch {
+	case trimmed[0] == '"':
+		var s string
+		if err := jcodec.Unmarshal(trimmed, &s); err != nil {
+			return nil, err
+		}
+		if n, ok := convertScalar(s, t); ok {
+			return []byte(n), nil
+		}
+	case trimmed[0] == '{' && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map):
+		var obj map[string]jcodec.RawMessage
+		if err := jcodec.Unmarshal(trimmed, &obj); err != nil {
+			return nil, err
+		}
+		for key, value := range obj {
+			ft, ok := t, true
+			if t.Kind() == reflect.Struct {
+				ft, ok = fieldByName(t, "json", key)
+			} else {
+				ft = t.Elem()
+			}
+			if !ok {
+				continue
+			}
+			converted, err := applyJSONHooks(value, ft)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = converted
+		}
+		return jcodec.Marshal(obj)
+	case trimmed[0] == '[' && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		var items []jcodec.RawMessage
+		if err := jcodec.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			converted, err := applyJSONHooks(item, t.Elem())
+			if err != nil {
+				return nil, err
+			}
+			items[i] = converted
+		}
+		return jcodec.Marshal(items)
+	}
+	return data, nil
+}
+
+// applyYAMLHooks rewrites byte-size strings in a YAML node tree into integers
+// for the matching fields of t. yaml.v3 already decodes duration strings into
+// time.Duration, so only byte sizes need converting.
+func applyYAMLHooks(node *yaml.Node, t reflect.Type) {
+	if t == nil {
+		return
+	}
+	t = indirect(t)
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			applyYAMLHooks(child, t)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			applyYAMLHooks(node.Alias, t)
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!str" && isInt(t) {
+			if n, ok := convertScalar(node.Value, t); ok {
+				node.Value, node.Tag, node.Style = n, "!!int", 0
+			}
+		}
+	case yaml.MappingNode:
+		if t.Kind() != reflect.Struct && t.Kind() != reflect.Map {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			ft, ok := t, true
+			switch {
+			case t.Kind() == reflect.Map:
+				ft = t.Elem()
+			case key.Value == "<<":
+				// Merge key: the merged mapping holds fields of t itself
+			default:
+				ft, ok = fieldByName(t, "yaml", key.Value)
+			}
+			if ok {
+				applyYAMLHooks(value, ft)
+			}
+		}
+	case yaml.SequenceNode:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, child := range node.Content {
+				applyYAMLHooks(child, t.Elem())
+			}
+		}
+	}
+}
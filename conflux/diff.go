@@ -0,0 +1,162 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package conflux
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FieldChange describes one value that differs between two configs.
+type FieldChange struct {
+	// Path is the dotted Go field path of the value, e.g., "Database.Pool.MaxOpen".
+	// Map entries use their key ("Limits.free") and slice elements their index
+	// ("Servers[1].Port"). It is empty when old and new are compared as a whole.
+	Path string
+	// Old is the previous value, or nil if it did not exist.
+	Old any
+	// New is the current value, or nil if it no longer exists.
+	New any
+}
+
+// Diff compares two parsed configs of the same type and returns the values
+// that changed, e.g., after a reload, so callers can log them and react only
+// to the settings they care about. Structs are compared field by field
+// (exported fields only; embedded structs are flattened), maps key by key and
+// slices element by element. Pointers and interfaces are followed. Everything
+// else, including structs without exported fields such as time.Time, is
+// compared as a single value.
+//
+// Changes are ordered by field declaration, map key and slice index. Diff
+// returns nil when nothing changed.
+//
+// Example:
+//
+//	for _, c := range conflux.Diff(oldCfg, newCfg) {
+//	    log.Infow("config changed", "field", c.Path, "old", c.Old, "new", c.New)
+//	}
+func Diff(old, new any) []FieldChange {
+	var changes []FieldChange
+	diffValues(&changes, "", reflect.ValueOf(old), reflect.ValueOf(new))
+	return changes
+}
+
+// diffValues appends the changes between a and b, found at path, to changes.
+func diffValues(changes *[]FieldChange, path string, a, b reflect.Value) {
+	a, b = deref(a), deref(b)
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		if a.IsValid() || b.IsValid() {
+			*changes = append(*changes, FieldChange{Path: path, Old: valueOf(a), New: valueOf(b)})
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if !hasExportedFields(a.Type()) {
+			break
+		}
+		for i := range a.NumField() {
+			f := a.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fieldPath := joinPath(path, f.Name)
+			if f.Anonymous && indirect(f.Type).Kind() == reflect.Struct {
+				fieldPath = path
+			}
+			diffValues(changes, fieldPath, a.Field(i), b.Field(i))
+		}
+		return
+	case reflect.Map:
+		for _, key := range mapKeys(a, b) {
+			keyPath := joinPath(path, fmt.Sprint(key.Interface()))
+			diffValues(changes, keyPath, a.MapIndex(key), b.MapIndex(key))
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		for i := range max(a.Len(), b.Len()) {
+			var ai, bi reflect.Value
+			if i < a.Len() {
+				ai = a.Index(i)
+			}
+			if i < b.Len() {
+				bi = b.Index(i)
+			}
+			diffValues(changes, path+"["+strconv.Itoa(i)+"]", ai, bi)
+		}
+		return
+	}
+
+	if !equalValues(a.Interface(), b.Interface()) {
+		*changes = append(*changes, FieldChange{Path: path, Old: a.Interface(), New: b.Interface()})
+	}
+}
+
+// deref follows pointers and interfaces, returning the zero Value for nil.
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// valueOf returns v's value, or nil for the zero Value.
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// equalValues compares two leaf values. time.Time is compared with Equal so
+// the same instant in another location is not a change.
+func equalValues(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		return ta.Equal(b.(time.Time))
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// hasExportedFields reports whether struct type t has any exported field.
+func hasExportedFields(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// mapKeys returns the keys of a and b, without duplicates, sorted by their
+// printed form.
+func mapKeys(a, b reflect.Value) []reflect.Value {
+	seen := make(map[any]bool, a.Len())
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, key := range m.MapKeys() {
+			if !seen[key.Interface()] {
+				seen[key.Interface()] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// joinPath appends name to a dotted path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package conflux
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffDatabase struct {
+	Host    string
+	MaxOpen int
+}
+
+type diffConfig struct {
+	Name     string
+	Database diffDatabase
+	Cache    *diffDatabase
+	Limits   map[string]int
+	Hosts    []string
+	Timeout  time.Duration
+	internal int
+}
+
+func newDiffConfig() diffConfig {
+	return diffConfig{
+		Name:     "api",
+		Database: diffDatabase{Host: "db", MaxOpen: 10},
+		Cache:    &diffDatabase{Host: "cache", MaxOpen: 5},
+		Limits:   map[string]int{"free": 10, "pro": 100},
+		Hosts:    []string{"a", "b"},
+		Timeout:  time.Second,
+	}
+}
+
+func TestDiff_NestedField(t *testing.T) {
+	oldCfg, newCfg := newDiffConfig(), newDiffConfig()
+	newCfg.Database.MaxOpen = 20
+
+	got := Diff(oldCfg, &newCfg)
+	want := []FieldChange{{Path: "Database.MaxOpen", Old: 10, New: 20}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	oldCfg, newCfg := newDiffConfig(), newDiffConfig()
+	newCfg.internal = 1
+	if got := Diff(oldCfg, newCfg); got != nil {
+		t.Errorf("Diff() = %+v, want nil", got)
+	}
+}
+
+func TestDiff_MapsSlicesAndPointers(t *testing.T) {
+	oldCfg, newCfg := newDiffConfig(), newDiffConfig()
+	newCfg.Cache.Host = "cache-2"
+	newCfg.Limits = map[string]int{"free": 20, "team": 50}
+	newCfg.Hosts = []string{"a", "c", "d"}
+	newCfg.Timeout = 2 * time.Second
+
+	got := Diff(oldCfg, newCfg)
+	want := []FieldChange{
+		{Path: "Cache.Host", Old: "cache", New: "cache-2"},
+		{Path: "Limits.free", Old: 10, New: 20},
+		{Path: "Limits.pro", Old: 100, New: nil},
+		{Path: "Limits.team", Old: nil, New: 50},
+		{Path: "Hosts[1]", Old: "b", New: "c"},
+		{Path: "Hosts[2]", Old: nil, New: "d"},
+		{Path: "Timeout", Old: time.Second, New: 2 * time.Second},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v,\nwant %+v", got, want)
+	}
+
+	newCfg.Cache = nil
+	got = Diff(oldCfg, newCfg)
+	if got[0].Path != "Cache" || got[0].New != nil || !reflect.DeepEqual(got[0].Old, *oldCfg.Cache) {
+		t.Errorf("Diff() first change = %+v, want Cache removed", got[0])
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package conflux
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/anthanhphan/gosdk/validator"
+)
+
+// Remote config defaults
+const (
+	// DefaultRemoteTimeout bounds a remote config download when WithTimeout is not set.
+	DefaultRemoteTimeout = 10 * time.Second
+	// MaxRemoteConfigSize is the largest remote config document accepted (10MB).
+	MaxRemoteConfigSize = 10 << 20
+)
+
+// RemoteOption configures ParseConfigFromURL.
+type RemoteOption func(*remoteConfig)
+
+// remoteConfig holds the settings applied by RemoteOption values.
+type remoteConfig struct {
+	timeout   time.Duration
+	headers   http.Header
+	client    *http.Client
+	format    string
+	basicAuth *url.Userinfo
+}
+
+// WithTimeout bounds the whole download, including connecting and reading the
+// body. Non-positive values keep DefaultRemoteTimeout.
+func WithTimeout(timeout time.Duration) RemoteOption {
+	return func(c *remoteConfig) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithBasicAuth sends HTTP basic auth credentials with the request.
+func WithBasicAuth(username, password string) RemoteOption {
+	return func(c *remoteConfig) {
+		c.basicAuth = url.UserPassword(username, password)
+	}
+}
+
+// WithHeader sends an extra request header, e.g., a bearer token or API key.
+func WithHeader(key, value string) RemoteOption {
+	return func(c *remoteConfig) {
+		c.headers.Set(key, value)
+	}
+}
+
+// WithHTTPClient uses client instead of http.DefaultClient, e.g., for custom
+// TLS settings. The WithTimeout deadline still applies.
+func WithHTTPClient(client *http.Client) RemoteOption {
+	return func(c *remoteConfig) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithFormat forces the document format ("json", "yaml" or "yml") instead of
+// inferring it from the URL extension or the Content-Type header.
+func WithFormat(format string) RemoteOption {
+	return func(c *remoteConfig) {
+		c.format = strings.ToLower(format)
+	}
+}
+
+// ParseConfigFromURL downloads a configuration document over HTTP(S), decodes
+// it into target and validates it, just like Load does for local files.
+//
+// The format is taken from WithFormat, then the URL path extension
+// (.json, .yaml, .yml), then the response Content-Type. Non-2xx responses and
+// documents larger than MaxRemoteConfigSize are rejected.
+//
+// Example:
+//
+//	var cfg AppConfig
+//	err := conflux.ParseConfigFromURL("https://config.internal/app/prod.yaml", &cfg,
+//	    conflux.WithTimeout(5*time.Second),
+//	    conflux.WithBasicAuth("svc-app", os.Getenv("CONFIG_PASSWORD")),
+//	)
+func ParseConfigFromURL(rawURL string, target any, opts ...RemoteOption) error {
+	cfg := remoteConfig{
+		timeout: DefaultRemoteTimeout,
+		headers: http.Header{},
+		client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid config url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported config url scheme: %q", u.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create config request: %w", err)
+	}
+	req.Header = cfg.headers
+	if cfg.basicAuth != nil {
+		password, _ := cfg.basicAuth.Password()
+		req.SetBasicAuth(cfg.basicAuth.Username(), password)
+	}
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch config: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to fetch config: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxRemoteConfigSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(data) > MaxRemoteConfigSize {
+		return fmt.Errorf("config exceeds %d bytes", MaxRemoteConfigSize)
+	}
+
+	ext := cfg.format
+	if ext == "" {
+		ext = remoteFormat(u.Path, resp.Header.Get("Content-Type"))
+	}
+	if !validExts[ext] {
+		return fmt.Errorf("cannot determine config format from %q (Content-Type %q)", u.Redacted(), resp.Header.Get("Content-Type"))
+	}
+
+	if err := unmarshal(data, ext, target); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", ext, err)
+	}
+
+	if err := validator.Validate(target); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+	return nil
+}
+
+// remoteFormat infers the config format from the URL path extension, falling
+// back to the response Content-Type. It returns "" when neither is known.
+func remoteFormat(urlPath, contentType string) string {
+	if ext := strings.TrimPrefix(path.Ext(urlPath), "."); validExts[strings.ToLower(ext)] {
+		return strings.ToLower(ext)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "application/json", "text/json":
+		return ExtensionJSON
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return ExtensionYAML
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package conflux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// ParseConfigFromURL
+// ============================================================================
+
+func TestParseConfigFromURL_YAML(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "svc" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("database_url: postgres://db\nport: 8080\ndebug: true\n"))
+	}))
+	defer srv.Close()
+
+	var cfg testConfig
+	err := ParseConfigFromURL(srv.URL+"/app/config.yaml", &cfg,
+		WithBasicAuth("svc", "secret"),
+		WithTimeout(2*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("ParseConfigFromURL() error = %v", err)
+	}
+	if cfg.DatabaseURL != "postgres://db" || cfg.Port != 8080 || !cfg.Debug {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
+func TestParseConfigFromURL_ContentType(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"database_url":"postgres://db","port":9090}`))
+	}))
+	defer srv.Close()
+
+	var cfg testConfig
+	if err := ParseConfigFromURL(srv.URL+"/config", &cfg); err != nil {
+		t.Fatalf("ParseConfigFromURL() error = %v", err)
+	}
+	if cfg.Port != 9090 {
+		t.Errorf("Port = %d, want 9090", cfg.Port)
+	}
+}
+
+func TestParseConfigFromURL_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing.yaml":
+			http.NotFound(w, r)
+		case "/slow.yaml":
+			time.Sleep(200 * time.Millisecond)
+		case "/invalid.yaml":
+			_, _ = w.Write([]byte("port: 8080\n")) // database_url is required
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("port=8080"))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		opts    []RemoteOption
+		wantErr string
+	}{
+		{name: "unsupported scheme", url: "file:///etc/config.yaml", wantErr: "unsupported config url scheme"},
+		{name: "non-2xx status", url: srv.URL + "/missing.yaml", wantErr: "unexpected status"},
+		{name: "timeout", url: srv.URL + "/slow.yaml", opts: []RemoteOption{WithTimeout(20 * time.Millisecond)}, wantErr: "failed to fetch config"},
+		{name: "unknown format", url: srv.URL + "/config", wantErr: "cannot determine config format"},
+		{name: "validation", url: srv.URL + "/invalid.yaml", wantErr: "config validation failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg testConfig
+			err := ParseConfigFromURL(tt.url, &cfg, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseConfigFromURL() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package routine
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/anthanhphan/gosdk/metrics"
+)
+
+// ---------------------------------------------------------------------------
+// Active goroutine tracking
+// ---------------------------------------------------------------------------
+
+// ActiveGoroutinesMetric is the gauge SetMetrics reports the number of live
+// Run, RunWithContext and RunWithTimeout goroutines under.
+const ActiveGoroutinesMetric = "routine_active_goroutines"
+
+var (
+	activeCount   atomic.Int64
+	activeMetrics atomic.Pointer[metrics.Client]
+)
+
+// ActiveCount returns the number of goroutines started by Run, RunWithContext
+// and RunWithTimeout that have not returned yet, including ones that are
+// still running after their timeout. A count that keeps growing points to
+// leaked or runaway goroutines. Group, WorkerPool and pipeline goroutines are
+// not included.
+func ActiveCount() int {
+	return int(activeCount.Load())
+}
+
+// SetMetrics reports the active goroutine count to client as the
+// ActiveGoroutinesMetric gauge. The gauge is set to the current count and then
+// updated on every launch and completion. Call it once at startup; passing nil
+// stops reporting.
+//
+// Example:
+//
+//	routine.SetMetrics(metricsClient)
+func SetMetrics(client metrics.Client) {
+	if client == nil {
+		activeMetrics.Store(nil)
+		return
+	}
+	activeMetrics.Store(&client)
+	client.SetGauge(context.Background(), ActiveGoroutinesMetric, float64(activeCount.Load()))
+}
+
+// trackStart records a goroutine launch. Call it before the go statement so
+// ActiveCount never lags behind a launch the caller already returned from.
+func trackStart() {
+	activeCount.Add(1)
+	if client := activeMetrics.Load(); client != nil {
+		(*client).GaugeInc(context.Background(), ActiveGoroutinesMetric)
+	}
+}
+
+// trackDone records a goroutine completion. Defer it first in the goroutine
+// so it runs after recoverPanic and the count stays accurate when fn panics.
+func trackDone() {
+	activeCount.Add(-1)
+	if client := activeMetrics.Load(); client != nil {
+		(*client).GaugeDec(context.Background(), ActiveGoroutinesMetric)
+	}
+}
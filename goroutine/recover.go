@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/anthanhphan/gosdk/logger"
 )
@@ -23,9 +24,25 @@ func recoverPanic() {
 		return
 	}
 
+	logPanic(getRecoverLogger(), r)
+}
+
+// recoverPanicWith is recoverPanic with a scoped logger: the panic entry
+// carries fields in addition to the recover logger's own fields.
+func recoverPanicWith(fields []logger.Field) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	logPanic(getRecoverLogger().With(fields...), r)
+}
+
+// logPanic logs a recovered panic value with its location.
+func logPanic(l *logger.Logger, r any) {
 	location := capturePanicLocation()
 
-	getRecoverLogger().Errorw("panic recovered in goroutine",
+	l.Errorw("panic recovered in goroutine",
 		"type", "panic",
 		"error", normalizePanicValue(r).Error(),
 		"panic_at", location,
@@ -62,15 +79,17 @@ func capturePanicLocation() string {
 
 var (
 	recoverLoggerOnce sync.Once
-	recoverLoggerInst *logger.Logger
+	// recoverLogger is swapped by tests while abandoned or timed-out
+	// goroutines may still be logging, hence the atomic pointer.
+	recoverLogger atomic.Pointer[logger.Logger]
 )
 
 // getRecoverLogger returns the cached recover logger, lazily initialized on first use.
 func getRecoverLogger() *logger.Logger {
 	recoverLoggerOnce.Do(func() {
-		recoverLoggerInst = logger.NewLoggerWithFields(
+		recoverLogger.CompareAndSwap(nil, logger.NewLoggerWithFields(
 			logger.String("prefix", "routine::recoverPanic"),
-		)
+		))
 	})
-	return recoverLoggerInst
+	return recoverLogger.Load()
 }
@@ -5,6 +5,7 @@ package routine
 import (
 	"context"
 	"fmt"
+	"io"
 	"runtime"
 	"strings"
 	"sync"
@@ -13,6 +14,8 @@ import (
 	"time"
 
 	"github.com/anthanhphan/gosdk/logger"
+	"github.com/anthanhphan/gosdk/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -1657,6 +1660,112 @@ func TestRunWithTimeout_PanicRecovery(t *testing.T) {
 	// Should not crash the process
 }
 
+// lockedBuffer is a goroutine-safe io.Writer for capturing log output.
+type lockedBuffer struct {
+	mu sync.Mutex
+	sb strings.Builder
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.String()
+}
+
+// captureRecoverLogger redirects the recover logger to a buffer for the test.
+func captureRecoverLogger(t *testing.T) (*lockedBuffer, *logger.Logger) {
+	t.Helper()
+	buf := &lockedBuffer{}
+	l := logger.NewLogger(&logger.Config{
+		LogLevel:    logger.LevelDebug,
+		LogEncoding: logger.EncodingJSON,
+	}, []io.Writer{buf})
+
+	getRecoverLogger() // initialize first so the swap is not overwritten
+	prev := recoverLogger.Swap(l)
+	t.Cleanup(func() { recoverLogger.Store(prev) })
+	return buf, l
+}
+
+func TestRunWith_PanicLogIncludesFields(t *testing.T) {
+	buf, l := captureRecoverLogger(t)
+
+	base := ActiveCount()
+	RunWith([]logger.Field{logger.String("request_id", "req-42")}, func() {
+		panic("boom")
+	})
+	// The count drops after recoverPanic has logged
+	waitActiveCount(t, base)
+	l.Sync()
+
+	out := buf.String()
+	assert.Contains(t, out, `"panic recovered in goroutine"`)
+	assert.Contains(t, out, `"request_id":"req-42"`)
+	assert.Contains(t, out, `"error":"boom"`)
+}
+
+func TestRunWithTimeout_WarnsWithCaller(t *testing.T) {
+	buf, l := captureRecoverLogger(t)
+
+	finished := make(chan struct{})
+	cancel := RunWithTimeout(20*time.Millisecond, func(_ context.Context) {
+		// Ignores ctx, like a stuck task would
+		time.Sleep(100 * time.Millisecond)
+		close(finished)
+	})
+	defer cancel()
+
+	<-finished
+	time.Sleep(20 * time.Millisecond)
+	l.Sync()
+
+	out := buf.String()
+	assert.Contains(t, out, `"goroutine timed out"`)
+	assert.Contains(t, out, `"started_at"`)
+	assert.Contains(t, out, `"abandoned":false`)
+	assert.Contains(t, out, `"timed out goroutine finished"`)
+}
+
+func TestRunWithTimeout_AbandonOnTimeout(t *testing.T) {
+	buf, l := captureRecoverLogger(t)
+
+	release := make(chan struct{})
+	defer close(release)
+	cancel := RunWithTimeout(20*time.Millisecond, func(_ context.Context) {
+		<-release
+	}, AbandonOnTimeout())
+	defer cancel()
+
+	time.Sleep(80 * time.Millisecond)
+	l.Sync()
+
+	out := buf.String()
+	assert.Contains(t, out, `"abandoned":true`)
+	assert.NotContains(t, out, "timed out goroutine finished")
+}
+
+func TestRunWithTimeout_EarlyCancelNoWarning(t *testing.T) {
+	buf, l := captureRecoverLogger(t)
+
+	done := make(chan struct{})
+	cancel := RunWithTimeout(time.Second, func(ctx context.Context) {
+		<-ctx.Done()
+		close(done)
+	})
+	cancel()
+	<-done
+	time.Sleep(20 * time.Millisecond)
+	l.Sync()
+
+	assert.NotContains(t, buf.String(), "timed out")
+}
+
 // ---------------------------------------------------------------------------
 // SubmitWithTimeout tests
 // ---------------------------------------------------------------------------
@@ -1824,8 +1933,12 @@ func TestRunWithTimeout_NoLeak(t *testing.T) {
 
 	<-done
 	cancel()
-	time.Sleep(100 * time.Millisecond)
 
+	// The supervisor logs after fn returns; wait for it instead of sleeping
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before+2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
 	after := runtime.NumGoroutine()
 	// Allow ±2 for background goroutines (GC, etc.)
 	assert.InDelta(t, before, after, 2, "goroutine count should return to baseline")
@@ -1884,3 +1997,82 @@ func TestSubmitWithTimeout_MixedTimeouts(t *testing.T) {
 	assert.Equal(t, int32(5), fast.Load(), "all fast jobs should complete")
 	assert.Equal(t, int32(3), slow.Load(), "all slow jobs should timeout and exit")
 }
+
+// waitActiveCount polls until ActiveCount equals want or the deadline passes.
+func waitActiveCount(t *testing.T, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for ActiveCount() != want && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, want, ActiveCount())
+}
+
+func TestActiveCount(t *testing.T) {
+	captureRecoverLogger(t)
+
+	// Let goroutines left over from earlier tests finish first
+	waitActiveCount(t, 0)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 6)
+	Run(func() { started <- struct{}{}; <-release })
+	Run(func(s string) { started <- struct{}{}; <-release }, "arg")
+	Run(func(a, b int) { started <- struct{}{}; <-release }, 1, 2) // reflect path
+	RunWithContext(context.Background(), func(_ context.Context) { started <- struct{}{}; <-release })
+	cancel := RunWithTimeout(time.Minute, func(_ context.Context) { started <- struct{}{}; <-release })
+	defer cancel()
+	Run(func() {
+		started <- struct{}{}
+		<-release
+		panic("boom")
+	})
+	for range 6 {
+		<-started
+	}
+
+	assert.Equal(t, 6, ActiveCount(), "count should include every launched goroutine")
+
+	close(release)
+	waitActiveCount(t, 0)
+}
+
+func TestSetMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := metrics.NewClientWithRegistry("test", registry,
+		metrics.WithoutGoCollector(), metrics.WithoutProcessCollector())
+	waitActiveCount(t, 0)
+	SetMetrics(client)
+	t.Cleanup(func() { SetMetrics(nil) })
+
+	gauge := func() float64 {
+		t.Helper()
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Gather() error = %v", err)
+		}
+		for _, mf := range families {
+			if mf.GetName() == "test_"+ActiveGoroutinesMetric {
+				return mf.GetMetric()[0].GetGauge().GetValue()
+			}
+		}
+		t.Fatalf("gauge %s not found", ActiveGoroutinesMetric)
+		return 0
+	}
+
+	assert.Equal(t, float64(0), gauge())
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 3)
+	for range 3 {
+		Run(func() { started <- struct{}{}; <-release })
+	}
+	for range 3 {
+		<-started
+	}
+	assert.Equal(t, float64(3), gauge())
+
+	close(release)
+	waitActiveCount(t, 0)
+	assert.Equal(t, float64(0), gauge())
+}
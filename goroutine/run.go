@@ -5,6 +5,8 @@ package routine
 import (
 	"context"
 	"time"
+
+	"github.com/anthanhphan/gosdk/logger"
 )
 
 // ---------------------------------------------------------------------------
@@ -31,7 +33,9 @@ func Run(fn any, args ...any) {
 	switch f := fn.(type) {
 	case func():
 		if len(args) == 0 {
+			trackStart()
 			go func() {
+				defer trackDone()
 				defer recoverPanic()
 				f()
 			}()
@@ -40,7 +44,9 @@ func Run(fn any, args ...any) {
 	case func(error):
 		if len(args) == 1 {
 			if a, ok := args[0].(error); ok {
+				trackStart()
 				go func() {
+					defer trackDone()
 					defer recoverPanic()
 					f(a)
 				}()
@@ -50,7 +56,9 @@ func Run(fn any, args ...any) {
 	case func(string):
 		if len(args) == 1 {
 			if a, ok := args[0].(string); ok {
+				trackStart()
 				go func() {
+					defer trackDone()
 					defer recoverPanic()
 					f(a)
 				}()
@@ -60,7 +68,9 @@ func Run(fn any, args ...any) {
 	case func(int):
 		if len(args) == 1 {
 			if a, ok := args[0].(int); ok {
+				trackStart()
 				go func() {
+					defer trackDone()
 					defer recoverPanic()
 					f(a)
 				}()
@@ -70,12 +80,39 @@ func Run(fn any, args ...any) {
 	}
 
 	// Generic path: use reflect for other function signatures
+	trackStart()
 	go func() {
+		defer trackDone()
 		defer recoverPanic()
 		invoke(fn, args)
 	}()
 }
 
+// RunWith starts fn in a new goroutine like Run, attaching fields to the logs
+// the goroutine emits on its behalf, including the panic log. Use it for
+// background work spawned from a request so the entry keeps the caller's
+// request_id.
+//
+// Go has no goroutine-local storage, so logs written by fn itself only carry
+// fields when fn logs through a scoped logger, e.g., logger.NewLoggerWithFields.
+//
+// Example:
+//
+//	fields := []logger.Field{logger.String("request_id", ctx.RequestID())}
+//	routine.RunWith(fields, func() {
+//	    log := logger.NewLoggerWithFields(fields...)
+//	    log.Info("sending welcome email")
+//	    sendWelcomeEmail(user)
+//	})
+func RunWith(fields []logger.Field, fn func()) {
+	trackStart()
+	go func() {
+		defer trackDone()
+		defer recoverPanicWith(fields)
+		fn()
+	}()
+}
+
 // RunWithContext starts a goroutine that executes fn with the given context.
 // If the context is cancelled or times out, the function is expected to
 // observe ctx.Done() and return. The goroutine logs a warning if fn has not
@@ -94,16 +131,40 @@ func Run(fn any, args ...any) {
 //	    // ctx cancellation will abort the HTTP request
 //	})
 func RunWithContext(ctx context.Context, fn func(ctx context.Context)) {
+	trackStart()
 	go func() {
+		defer trackDone()
 		defer recoverPanic()
 		fn(ctx)
 	}()
 }
 
+// TimeoutOption configures RunWithTimeout.
+type TimeoutOption func(*timeoutConfig)
+
+// timeoutConfig holds the settings applied by TimeoutOption values.
+type timeoutConfig struct {
+	abandon bool
+}
+
+// AbandonOnTimeout stops supervising fn once the timeout fires. By default the
+// supervisor keeps waiting after the warning and logs again when the overdue fn
+// finally returns; with this option it logs once and stops tracking fn.
+func AbandonOnTimeout() TimeoutOption {
+	return func(c *timeoutConfig) {
+		c.abandon = true
+	}
+}
+
 // RunWithTimeout starts a goroutine that executes fn with a timeout.
 // A context with the given timeout is created and passed to fn.
 // If fn does not complete within the timeout, the context is cancelled,
-// and a warning is logged. fn should respect ctx.Done() to exit promptly.
+// and a warning is logged with the location RunWithTimeout was called from.
+// fn should respect ctx.Done() to exit promptly.
+//
+// Go cannot forcibly stop a goroutine, so the timeout is observational unless
+// fn honors ctx: a task that ignores ctx keeps running after the warning.
+// The warning at least surfaces stuck background work.
 //
 // Returns a cancel function that can be called to cancel early.
 //
@@ -114,7 +175,13 @@ func RunWithContext(ctx context.Context, fn func(ctx context.Context)) {
 //	    resp, err := http.Get("https://api.example.com/slow")
 //	})
 //	defer cancel() // optional: cancel early if no longer needed
-func RunWithTimeout(timeout time.Duration, fn func(ctx context.Context)) context.CancelFunc {
+func RunWithTimeout(timeout time.Duration, fn func(ctx context.Context), opts ...TimeoutOption) context.CancelFunc {
+	var cfg timeoutConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	caller := getCallerLocation()
+
 	ctx, cancel := context.WithCancelCause(context.Background())
 
 	timer := time.AfterFunc(timeout, func() {
@@ -124,13 +191,16 @@ func RunWithTimeout(timeout time.Duration, fn func(ctx context.Context)) context
 	// Wrap ctx so Err() returns DeadlineExceeded when timed out.
 	tctx := &timeoutCtx{Context: ctx}
 
+	trackStart()
 	go func() {
 		defer recoverPanic()
 		defer cancel(nil)
 		defer timer.Stop()
 
+		start := time.Now()
 		done := make(chan struct{})
 		go func() {
+			defer trackDone()
 			defer close(done)
 			defer recoverPanic()
 			fn(tctx)
@@ -140,11 +210,26 @@ func RunWithTimeout(timeout time.Duration, fn func(ctx context.Context)) context
 		case <-done:
 			// fn completed normally
 		case <-ctx.Done():
+			if context.Cause(ctx) != context.DeadlineExceeded {
+				// Cancelled early by the caller
+				<-done
+				return
+			}
 			getRecoverLogger().Warnw("goroutine timed out",
 				"timeout", timeout.String(),
+				"started_at", caller,
+				"abandoned", cfg.abandon,
 			)
+			if cfg.abandon {
+				return
+			}
 			// Wait for fn to actually return (it should observe ctx.Done)
 			<-done
+			getRecoverLogger().Infow("timed out goroutine finished",
+				"timeout", timeout.String(),
+				"elapsed", time.Since(start).String(),
+				"started_at", caller,
+			)
 		}
 	}()
 
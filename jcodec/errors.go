@@ -0,0 +1,170 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// Unmarshal errors with JSON path context
+// ============================================================================
+
+// UnmarshalError reports where in the document Unmarshal failed, e.g.,
+// `server.headers[2]: cannot unmarshal string into int`.
+// Err is the engine's original error, so errors.As/errors.Is keep working on it.
+//
+// Example:
+//
+//	var ue *jcodec.UnmarshalError
+//	if errors.As(err, &ue) {
+//	    log.Printf("bad value at %s: %v", ue.Path, ue.Err)
+//	}
+type UnmarshalError struct {
+	// Path is the location of the offending value: object keys joined by
+	// dots and array indexes in brackets, e.g., "items[3].price".
+	Path string
+	// Err is the underlying engine error.
+	Err error
+
+	msg string
+}
+
+// Error returns the path followed by a short description of the failure.
+func (e *UnmarshalError) Error() string {
+	return e.Path + ": " + e.msg
+}
+
+// Unwrap returns the underlying engine error.
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// withPath wraps an Unmarshal failure in an UnmarshalError when the failing
+// value can be located. Engines report offsets in different ways, so the
+// document is decoded again with encoding/json into a fresh value of v's type
+// to get a comparable offset. This only runs on the error path. err is
+// returned unchanged when no path can be determined (e.g., a root value).
+func withPath(data []byte, v any, err error) error {
+	rt := reflect.TypeOf(v)
+	if rt == nil || rt.Kind() != reflect.Pointer {
+		return err
+	}
+
+	var (
+		offset int64
+		msg    string
+	)
+	stdErr := json.Unmarshal(data, reflect.New(rt.Elem()).Interface())
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(stdErr, &typeErr):
+		offset = typeErr.Offset
+		msg = fmt.Sprintf("cannot unmarshal %s into %s", typeErr.Value, typeErr.Type)
+	case errors.As(stdErr, &syntaxErr):
+		offset = syntaxErr.Offset
+		msg = syntaxErr.Error()
+	default:
+		return err
+	}
+
+	path := pathAtOffset(data, offset)
+	if path == "" {
+		return err
+	}
+	return &UnmarshalError{Path: path, Err: err, msg: msg}
+}
+
+// pathFrame is one open object or array while walking a document.
+type pathFrame struct {
+	array     bool
+	key       string
+	index     int
+	expectKey bool
+}
+
+// pathAtOffset returns the path of the value that ends at or contains offset.
+func pathAtOffset(data []byte, offset int64) string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var stack []pathFrame
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return ""
+			}
+			// Syntax error: report the value being parsed
+			return formatPath(stack)
+		}
+
+		if n := len(stack); n > 0 && !stack[n-1].array && stack[n-1].expectKey {
+			if key, ok := tok.(string); ok {
+				stack[n-1].key = key
+				stack[n-1].expectKey = false
+				continue
+			}
+		}
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			path := formatPath(stack[:len(stack)-1])
+			stack = stack[:len(stack)-1]
+			if dec.InputOffset() >= offset {
+				return path
+			}
+			valueDone(stack)
+			continue
+		}
+
+		path := formatPath(stack)
+		if dec.InputOffset() >= offset {
+			return path
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			stack = append(stack, pathFrame{array: delim == '[', expectKey: delim == '{'})
+			continue
+		}
+		valueDone(stack)
+	}
+}
+
+// valueDone advances the innermost container past a completed value.
+func valueDone(stack []pathFrame) {
+	if n := len(stack); n > 0 {
+		if stack[n-1].array {
+			stack[n-1].index++
+		} else {
+			stack[n-1].expectKey = true
+		}
+	}
+}
+
+// formatPath renders the path to the current value of the innermost frame.
+func formatPath(stack []pathFrame) string {
+	var b strings.Builder
+	for _, f := range stack {
+		if f.array {
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(f.index))
+			b.WriteByte(']')
+			continue
+		}
+		if f.key == "" && f.expectKey {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(f.key)
+	}
+	return b.String()
+}
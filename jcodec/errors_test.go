@@ -0,0 +1,100 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type pathTestConfig struct {
+	Server struct {
+		Name    string `json:"name"`
+		Headers []int  `json:"headers"`
+		Limits  struct {
+			Max int `json:"max"`
+		} `json:"limits"`
+	} `json:"server"`
+	Items []struct {
+		Price float64 `json:"price"`
+	} `json:"items"`
+}
+
+func TestUnmarshal_ErrorPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name:     "array element type mismatch",
+			data:     `{"server":{"name":"api","headers":[1,2,"three"]}}`,
+			wantPath: "server.headers[2]",
+			wantMsg:  "server.headers[2]: cannot unmarshal string into int",
+		},
+		{
+			name:     "nested object field",
+			data:     `{"server":{"limits":{"max":"many"}}}`,
+			wantPath: "server.limits.max",
+		},
+		{
+			name:     "object instead of scalar",
+			data:     `{"items":[{"price":1.5},{"price":{"amount":2}}]}`,
+			wantPath: "items[1].price",
+		},
+		{
+			name:     "later sibling after nested containers",
+			data:     `{"server":{"headers":[1],"limits":{"max":1}},"items":[{"price":true}]}`,
+			wantPath: "items[0].price",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg pathTestConfig
+			err := Unmarshal([]byte(tt.data), &cfg)
+			if err == nil {
+				t.Fatal("Unmarshal() error = nil, want error")
+			}
+
+			var ue *UnmarshalError
+			if !errors.As(err, &ue) {
+				t.Fatalf("Unmarshal() error = %T %v, want *UnmarshalError", err, err)
+			}
+			if ue.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", ue.Path, tt.wantPath)
+			}
+			if ue.Unwrap() == nil {
+				t.Error("Unwrap() = nil, want the engine error")
+			}
+			if tt.wantMsg != "" && err.Error() != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_ErrorPathRoot(t *testing.T) {
+	var n int
+	err := Unmarshal([]byte(`"text"`), &n)
+	if err == nil {
+		t.Fatal("Unmarshal() error = nil, want error")
+	}
+	var ue *UnmarshalError
+	if errors.As(err, &ue) {
+		t.Errorf("root value error should not be wrapped, got path %q", ue.Path)
+	}
+}
+
+func TestUnmarshal_SyntaxErrorPath(t *testing.T) {
+	var cfg pathTestConfig
+	err := Unmarshal([]byte(`{"items":[{"price":1},{"price":}]}`), &cfg)
+	if err == nil {
+		t.Fatal("Unmarshal() error = nil, want error")
+	}
+	if !strings.HasPrefix(err.Error(), "items[1]") {
+		t.Errorf("Error() = %q, want it to start with items[1]", err.Error())
+	}
+}
@@ -113,12 +113,17 @@ func Marshal(v any) ([]byte, error) {
 }
 
 // Unmarshal converts JSON bytes to a Go value using the optimal engine for the current architecture.
+// When a nested value has the wrong type or is malformed, the error is an
+// *UnmarshalError carrying the JSON path to it (e.g., "server.headers[2]").
 //
 // Example:
 //
 //	err := jcodec.Unmarshal(data, &user)
 func Unmarshal(data []byte, v any) error {
-	return unmarshalFn(data, v)
+	if err := unmarshalFn(data, v); err != nil {
+		return withPath(data, v, err)
+	}
+	return nil
 }
 
 // MarshalIndent converts a Go value to pretty-printed JSON bytes using the optimal engine.
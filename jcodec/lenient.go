@@ -0,0 +1,123 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ============================================================================
+// Lenient Decoding (hand-edited JSON)
+// ============================================================================
+
+// UnmarshalLenient decodes hand-edited JSON, such as config files, into v. On
+// top of strict JSON it accepts exactly these relaxations:
+//
+//   - line comments (// ...) and block comments (/* ... */)
+//   - a trailing comma after the last object member or array element
+//   - single-quoted strings, for keys and values; inside them \' is a quote
+//     and " needs no escaping
+//
+// Everything else (unquoted keys, hex numbers, NaN, ...) is still rejected.
+// The input is normalized to strict JSON and decoded with Unmarshal, so errors
+// carry the same JSON path. Use Unmarshal for API input.
+//
+// Example:
+//
+//	// {
+//	//     'name': 'orders', // service name
+//	//     "ports": [8080, 9090,],
+//	// }
+//	err := jcodec.UnmarshalLenient(data, &cfg)
+func UnmarshalLenient(data []byte, v any) error {
+	normalized, err := normalizeLenient(data)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(normalized, v)
+}
+
+// normalizeLenient rewrites the relaxations UnmarshalLenient accepts into
+// strict JSON. Anything it does not recognize is copied as is for the decoder
+// to report.
+func normalizeLenient(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '"':
+			end, _ := stringEnd(data, i, '"')
+			out = append(out, data[i:end]...)
+			i = end - 1
+		case c == '\'':
+			end, closed := stringEnd(data, i, '\'')
+			body := data[i+1 : end]
+			if closed {
+				body = body[:len(body)-1]
+			}
+			out = appendSingleQuoted(out, body, closed)
+			i = end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, errors.New("jcodec: unterminated block comment")
+			}
+			i += end + 3
+		case c == '}' || c == ']':
+			// Drop a trailing comma, skipping the whitespace before the bracket
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = append(trimmed[:len(trimmed)-1], out[len(trimmed):]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// stringEnd returns the index just past the string literal starting at
+// data[start] and closed by quote, or len(data) and false if it is unterminated.
+func stringEnd(data []byte, start int, quote byte) (int, bool) {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1, true
+		}
+	}
+	return len(data), false
+}
+
+// appendSingleQuoted appends the body of a single-quoted string to out as a
+// double-quoted one. An unterminated string stays unterminated so the decoder
+// reports it.
+func appendSingleQuoted(out, body []byte, closed bool) []byte {
+	out = append(out, '"')
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && i+1 < len(body) && body[i+1] == '\'':
+			out = append(out, '\'')
+			i++
+		case c == '\\' && i+1 < len(body):
+			out = append(out, c, body[i+1])
+			i++
+		case c == '"':
+			out = append(out, '\\', '"')
+		default:
+			out = append(out, c)
+		}
+	}
+	if !closed {
+		return out
+	}
+	return append(out, '"')
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type lenientConfig struct {
+	Name  string   `json:"name"`
+	Ports []int    `json:"ports"`
+	Tags  []string `json:"tags"`
+	Note  string   `json:"note"`
+}
+
+func TestUnmarshalLenient(t *testing.T) {
+	data := []byte(`{
+		// service name
+		'name': 'orders',
+		"ports": [8080, 9090,], /* secondary port added for canary */
+		"tags": ['it\'s', "a // b", 'say "hi"',],
+		"note": "/* not a comment */",
+	}`)
+
+	var cfg lenientConfig
+	if err := UnmarshalLenient(data, &cfg); err != nil {
+		t.Fatalf("UnmarshalLenient() error = %v", err)
+	}
+	if cfg.Name != "orders" {
+		t.Errorf("Name = %q, want orders (single-quoted key and value)", cfg.Name)
+	}
+	if len(cfg.Ports) != 2 || cfg.Ports[1] != 9090 {
+		t.Errorf("Ports = %v, want [8080 9090] (trailing comma)", cfg.Ports)
+	}
+	wantTags := []string{"it's", "a // b", `say "hi"`}
+	if strings.Join(cfg.Tags, "|") != strings.Join(wantTags, "|") {
+		t.Errorf("Tags = %q, want %q", cfg.Tags, wantTags)
+	}
+	if cfg.Note != "/* not a comment */" {
+		t.Errorf("Note = %q, comment markers inside strings must be kept", cfg.Note)
+	}
+
+	if err := Unmarshal([]byte(`{"ports": [1,],}`), &cfg); err == nil {
+		t.Error("strict Unmarshal should still reject trailing commas")
+	}
+}
+
+func TestUnmarshalLenient_Errors(t *testing.T) {
+	var cfg lenientConfig
+	if err := UnmarshalLenient([]byte(`{"name": "a" /* open`), &cfg); err == nil || !strings.Contains(err.Error(), "block comment") {
+		t.Errorf("unterminated comment error = %v", err)
+	}
+	if err := UnmarshalLenient([]byte(`{name: 'a'}`), &cfg); err == nil {
+		t.Error("unquoted keys should still be rejected")
+	}
+	err := UnmarshalLenient([]byte(`{'ports': [1, 'two',],}`), &cfg)
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) || unmarshalErr.Path != "ports[1]" {
+		t.Errorf("error = %v, want an *UnmarshalError at ports[1]", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ============================================================================
+// JSON Merge Patch (RFC 7396)
+// ============================================================================
+
+// Merge applies a JSON Merge Patch (RFC 7396) to dst, which must be a non-nil
+// pointer. Keys present in patch are set, keys set to null are deleted and
+// absent keys are left untouched. Nested objects are merged recursively;
+// arrays and scalars are replaced as a whole.
+//
+// Deleting a key resets the matching Go value to its zero value: pointer,
+// map, slice and interface fields become nil, and the key is removed from
+// map fields. A null on a non-pointer field (e.g., a string) resets it to "".
+//
+// The merged document is decoded into a copy of dst whose JSON fields were
+// reset first, so fields that do not appear in its JSON encoding (unexported
+// or tagged `json:"-"`) keep their values, including in nested structs.
+// Values that decode themselves (json.Unmarshaler, encoding.TextUnmarshaler)
+// and the elements of maps and slices are decoded from scratch. dst is left
+// unchanged when an error is returned.
+//
+// Example:
+//
+//	user, _ := repo.Get(id)
+//	// {"status": "suspended", "nickname": null}
+//	if err := jcodec.Merge(&user, ctx.Body()); err != nil {
+//	    return ctx.BadRequestMsg("invalid patch")
+//	}
+func Merge(dst any, patch []byte) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("jcodec: Merge destination must be a non-nil pointer")
+	}
+	if !Valid(patch) {
+		return errors.New("jcodec: Merge patch is not valid JSON")
+	}
+
+	current, err := Marshal(dst)
+	if err != nil {
+		return fmt.Errorf("jcodec: Merge failed to encode destination: %w", err)
+	}
+	merged, err := mergePatch(current, patch)
+	if err != nil {
+		return err
+	}
+
+	out := reflect.New(rv.Elem().Type())
+	out.Elem().Set(rv.Elem())
+	resetJSONFields(out.Elem())
+	if err := Unmarshal(merged, out.Interface()); err != nil {
+		return err
+	}
+	rv.Elem().Set(out.Elem())
+	return nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// resetJSONFields zeroes the parts of v that the merged document is decoded
+// into, keeping fields that JSON ignores. Structs reached through a pointer
+// are copied first, so the decoder does not write through dst's pointers.
+func resetJSONFields(v reflect.Value) {
+	switch {
+	case decodesItself(v.Type()):
+		if v.CanSet() {
+			v.SetZero()
+		}
+	case v.Kind() == reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if field.Tag.Get("json") == "-" || (!field.IsExported() && !field.Anonymous) {
+				continue
+			}
+			resetJSONFields(v.Field(i))
+		}
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct && !decodesItself(v.Type().Elem()):
+		if !v.CanSet() {
+			return // unexported embedded pointer: it cannot be replaced
+		}
+		elem := reflect.New(v.Type().Elem())
+		elem.Elem().Set(v.Elem())
+		resetJSONFields(elem.Elem())
+		v.Set(elem)
+	default:
+		if v.CanSet() {
+			v.SetZero()
+		}
+	}
+}
+
+// decodesItself reports whether values of type t unmarshal themselves.
+func decodesItself(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396 section 2 on
+// encoded documents. Values are kept as raw JSON so numbers are never
+// rounded through float64.
+func mergePatch(target, patch []byte) ([]byte, error) {
+	if !isObject(patch) {
+		return patch, nil
+	}
+
+	doc := map[string]RawMessage{}
+	if isObject(target) {
+		if err := Unmarshal(target, &doc); err != nil {
+			return nil, err
+		}
+	}
+	var changes map[string]RawMessage
+	if err := Unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+
+	for key, value := range changes {
+		if isNull(value) {
+			delete(doc, key)
+			continue
+		}
+		merged, err := mergePatch(doc[key], value)
+		if err != nil {
+			return nil, err
+		}
+		doc[key] = merged
+	}
+	return Marshal(doc)
+}
+
+// isObject reports whether the encoded value is a JSON object.
+func isObject(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '{'
+}
+
+// isNull reports whether the encoded value is the JSON null literal.
+func isNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"testing"
+	"time"
+)
+
+type mergeUser struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Status   string            `json:"status"`
+	Nickname *string           `json:"nickname"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func TestMerge(t *testing.T) {
+	nick := "ally"
+	user := mergeUser{
+		ID:       9007199254740993, // not representable as float64
+		Name:     "Alice",
+		Status:   "active",
+		Nickname: &nick,
+		Labels:   map[string]string{"team": "core", "tier": "gold"},
+	}
+
+	patch := []byte(`{"status":"suspended","nickname":null,"labels":{"tier":null,"region":"eu"}}`)
+	if err := Merge(&user, patch); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if user.Status != "suspended" {
+		t.Errorf("Status = %q, want %q", user.Status, "suspended")
+	}
+	if user.Nickname != nil {
+		t.Errorf("Nickname = %q, want nil", *user.Nickname)
+	}
+	if user.Name != "Alice" || user.ID != 9007199254740993 {
+		t.Errorf("absent fields changed: %+v", user)
+	}
+	want := map[string]string{"team": "core", "region": "eu"}
+	if len(user.Labels) != len(want) {
+		t.Fatalf("Labels = %v, want %v", user.Labels, want)
+	}
+	for k, v := range want {
+		if user.Labels[k] != v {
+			t.Errorf("Labels[%q] = %q, want %q", k, user.Labels[k], v)
+		}
+	}
+}
+
+type mergeAccount struct {
+	Owner     mergeUser  `json:"owner"`
+	Manager   *mergeUser `json:"manager"`
+	Password  string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	version   int
+}
+
+func TestMerge_KeepsHiddenFields(t *testing.T) {
+	manager := &mergeUser{ID: 2, Name: "Bob"}
+	account := mergeAccount{
+		Owner:     mergeUser{ID: 1, Name: "Alice", Status: "active"},
+		Manager:   manager,
+		Password:  "hash",
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		version:   7,
+	}
+
+	patch := []byte(`{"owner":{"status":"suspended"},"manager":{"name":"Carol"},"created_at":null}`)
+	if err := Merge(&account, patch); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if account.Password != "hash" || account.version != 7 {
+		t.Errorf("hidden fields reset: Password = %q, version = %d", account.Password, account.version)
+	}
+	if account.Owner.Status != "suspended" || account.Owner.Name != "Alice" {
+		t.Errorf("Owner = %+v, want Alice suspended", account.Owner)
+	}
+	if account.Manager == nil || account.Manager.Name != "Carol" || account.Manager.ID != 2 {
+		t.Errorf("Manager = %+v, want Carol with ID 2", account.Manager)
+	}
+	if manager.Name != "Bob" {
+		t.Errorf("Merge() wrote through the original Manager pointer: %+v", manager)
+	}
+	if !account.CreatedAt.IsZero() {
+		t.Errorf("CreatedAt = %v, want zero after null", account.CreatedAt)
+	}
+}
+
+func TestMerge_Errors(t *testing.T) {
+	user := mergeUser{Name: "Alice"}
+
+	if err := Merge(user, []byte(`{}`)); err == nil {
+		t.Error("Merge() with non-pointer should fail")
+	}
+	if err := Merge(&user, []byte(`{"name":`)); err == nil {
+		t.Error("Merge() with invalid patch should fail")
+	}
+	if err := Merge(&user, []byte(`{"id":"not-a-number"}`)); err == nil {
+		t.Error("Merge() with mistyped value should fail")
+	}
+	if user.Name != "Alice" || user.ID != 0 {
+		t.Errorf("failed Merge() modified destination: %+v", user)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package jcodec
+
+import (
+	"io"
+)
+
+// ============================================================================
+// NDJSON (newline-delimited JSON) writer
+// ============================================================================
+
+// NDJSONWriter writes values as newline-delimited JSON, one value per line.
+// Each value is encoded and written to the underlying writer in a single
+// Write call; NDJSONWriter keeps no buffer of its own, so wrap w in a
+// bufio.Writer to batch small records. It is not safe for concurrent use.
+//
+// Example:
+//
+//	nw := jcodec.NewNDJSONWriter(w)
+//	for rows.Next() {
+//	    if err := nw.Write(row); err != nil {
+//	        return err
+//	    }
+//	}
+//	return nw.Flush()
+type NDJSONWriter struct {
+	w   io.Writer
+	enc Encoder
+}
+
+// NewNDJSONWriter returns a writer that encodes values to w, one per line.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{w: w, enc: NewEncoder(w)}
+}
+
+// Write encodes v followed by a newline.
+func (nw *NDJSONWriter) Write(v any) error {
+	return nw.enc.Encode(v)
+}
+
+// Flush flushes the underlying writer when it supports flushing, e.g., a
+// *bufio.Writer (Flush() error) or an http.ResponseWriter (http.Flusher).
+// It is a no-op otherwise.
+func (nw *NDJSONWriter) Flush() error {
+	switch f := nw.w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
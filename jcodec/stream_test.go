@@ -208,3 +208,55 @@ func TestUtilityFunctions(t *testing.T) {
 		t.Errorf("HTMLEscape output mismatch: %s", htmlBuf.String())
 	}
 }
+
+type flushCounter struct {
+	bytes.Buffer
+	writes  int
+	flushes int
+}
+
+func (f *flushCounter) Write(p []byte) (int, error) {
+	f.writes++
+	return f.Buffer.Write(p)
+}
+
+func (f *flushCounter) Flush() { f.flushes++ }
+
+func TestNDJSONWriter(t *testing.T) {
+	out := &flushCounter{}
+	nw := NewNDJSONWriter(out)
+
+	records := []map[string]any{
+		{"id": 1, "name": "alice"},
+		{"id": 2, "name": "bob <admin>"},
+		{"id": 3, "tags": []string{"a", "b"}},
+	}
+	for _, r := range records {
+		if err := nw.Write(r); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := nw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if !strings.HasSuffix(out.String(), "\n") {
+		t.Errorf("output should end with a newline: %q", out.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(records) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(records), out.String())
+	}
+	for i, line := range lines {
+		var got map[string]any
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Errorf("line %d is not valid JSON: %v (%q)", i, err, line)
+		}
+	}
+	if out.writes != len(records) {
+		t.Errorf("got %d writes, want one per record (%d)", out.writes, len(records))
+	}
+	if out.flushes != 1 {
+		t.Errorf("got %d flushes, want 1", out.flushes)
+	}
+}
@@ -110,6 +110,62 @@ func (al *AsyncLogger) log(level Level, skipOffset int, msg string, fields ...Fi
 	}
 }
 
+// Trace logs a message at trace level asynchronously.
+//
+// Input:
+//   - args: Variadic arguments to log (can be message string or key-value pairs)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	asyncLogger.Trace("Trace message")
+//	asyncLogger.Trace("Processing user", "user_id", 12345, "action", "create")
+func (al *AsyncLogger) Trace(args ...any) {
+	msg, fields := al.logger.formatArgs(args...)
+	al.log(LevelTrace, 1, msg, fields...)
+}
+
+// Tracef logs a formatted message at trace level asynchronously using Printf-style formatting.
+//
+// Input:
+//   - template: Format string (Printf-style)
+//   - args: Arguments for the format string (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	asyncLogger.Tracef("Processing user %s with id %d", "john", 12345)
+func (al *AsyncLogger) Tracef(template string, args ...any) {
+	msg := fmt.Sprintf(template, args...)
+	al.log(LevelTrace, 1, msg)
+}
+
+// Tracew logs a message with structured key-value pairs at trace level asynchronously.
+//
+// Input:
+//   - msg: Log message
+//   - keysAndValues: Alternating keys and values for structured logging (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	asyncLogger.Tracew("Request received", "method", "GET", "path", "/api/users", "ip", "192.168.1.1")
+func (al *AsyncLogger) Tracew(msg string, keysAndValues ...any) {
+	fsp, n := al.logger.parseKeysAndValues(keysAndValues...)
+	if fsp != nil {
+		al.log(LevelTrace, 1, msg, (*fsp)[:n]...)
+		putFieldSlice(fsp)
+	} else {
+		al.log(LevelTrace, 1, msg)
+	}
+}
+
 // Debug logs a message at debug level asynchronously.
 //
 // Input:
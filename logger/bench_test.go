@@ -2,6 +2,7 @@ package logger
 
 import (
 	"io"
+	"sync"
 	"testing"
 	"time"
 )
@@ -100,3 +101,20 @@ func BenchmarkLogger_Parallel(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkGlobal_Infow_Disabled(b *testing.B) {
+	loggerInstance = nil
+	once = sync.Once{}
+	undo := InitNoop()
+	defer undo()
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Infow("incoming request",
+			"trace_id", "abc123def456",
+			"request_id", "req-001",
+			"method", "GET",
+			"path", "/api/users",
+		)
+	}
+}
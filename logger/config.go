@@ -4,6 +4,7 @@ package logger
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -18,7 +19,7 @@ type Config struct {
 	// LogLevel specifies the minimum log level to output (e.g., DEBUG, INFO, WARN, ERROR).
 	LogLevel Level `yaml:"log_level" json:"log_level"`
 
-	// LogEncoding defines the output format for log entries (e.g., JSON, CONSOLE).
+	// LogEncoding defines the output format for log entries (e.g., JSON, CONSOLE, LOGFMT).
 	LogEncoding Encoding `yaml:"log_encoding" json:"log_encoding"`
 
 	// DisableCaller controls whether to include caller information (file:line) in log entries.
@@ -41,6 +42,31 @@ type Config struct {
 	// Must be 16, 24, or 32 bytes for AES-128, AES-192, or AES-256 respectively.
 	// If empty, masked fields will display "***" instead of encrypted values.
 	MaskKey string `yaml:"mask_key" json:"mask_key"`
+
+	// Sinks routes log output to several destinations, each with its own level and encoding
+	// (e.g., info+ as JSON to stdout and error+ as JSON to a file).
+	// When set, LogLevel, LogEncoding and OutputPaths are ignored.
+	// When empty, the single-output configuration above is used.
+	Sinks []SinkConfig `yaml:"sinks" json:"sinks"`
+
+	// AccessLog, when set, gives HTTP access logs their own level, encoding and
+	// outputs, served by AccessLogger and independent of the app logger's
+	// level and sinks. Timezone and MaskKey are shared with the app logger.
+	// When nil, AccessLogger returns the app logger.
+	AccessLog *SinkConfig `yaml:"access_log" json:"access_log"`
+}
+
+// SinkConfig describes one output destination of a multi-sink logger.
+type SinkConfig struct {
+	// LogLevel specifies the minimum log level written to this sink.
+	LogLevel Level `yaml:"log_level" json:"log_level"`
+
+	// LogEncoding defines the output format for this sink (JSON, CONSOLE or LOGFMT).
+	LogEncoding Encoding `yaml:"log_encoding" json:"log_encoding"`
+
+	// OutputPaths specifies where this sink writes ("stdout", "stderr" or file paths).
+	// If empty, logs to stdout.
+	OutputPaths []string `yaml:"log_output_paths" json:"log_output_paths"`
 }
 
 // Validate checks if the configuration is valid and all required fields are set.
@@ -64,6 +90,17 @@ func (c *Config) Validate() error {
 	if c == nil {
 		return errors.New("config is required, nil is not allowed")
 	}
+	if c.AccessLog != nil {
+		if !c.AccessLog.LogLevel.isValid() {
+			return errors.New("access_log: level is invalid, must be one of: " + strings.Join(levelValues(), ", "))
+		}
+		if !c.AccessLog.LogEncoding.isValid() {
+			return errors.New("access_log: encoding is invalid, must be one of: " + strings.Join(encodingValues(), ", "))
+		}
+	}
+	if len(c.Sinks) > 0 {
+		return c.validateSinks()
+	}
 	if c.LogLevel == "" {
 		return errors.New("level is required")
 	}
@@ -80,13 +117,43 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateSinks() error {
+	for i, sink := range c.Sinks {
+		if !sink.LogLevel.isValid() {
+			return fmt.Errorf("sinks[%d]: level is invalid, must be one of: %s", i, strings.Join(levelValues(), ", "))
+		}
+		if !sink.LogEncoding.isValid() {
+			return fmt.Errorf("sinks[%d]: encoding is invalid, must be one of: %s", i, strings.Join(encodingValues(), ", "))
+		}
+	}
+	return nil
+}
+
 func buildLoggerConfig(config *Config, defaultFields ...Field) *Logger {
+	if len(config.Sinks) > 0 {
+		return buildSinkLogger(config, defaultFields...)
+	}
 	outputs, closers := getOutputWriters(config.OutputPaths)
 	logger := NewLogger(config, outputs, defaultFields...)
 	logger.setClosers(closers)
 	return logger
 }
 
+// buildAccessLogger builds the dedicated access logger described by
+// config.AccessLog. Caller and stack traces are omitted: access entries are
+// always written by the same middleware.
+func buildAccessLogger(config *Config, defaultFields ...Field) *Logger {
+	accessConfig := *config
+	accessConfig.LogLevel = config.AccessLog.LogLevel
+	accessConfig.LogEncoding = config.AccessLog.LogEncoding
+	accessConfig.OutputPaths = config.AccessLog.OutputPaths
+	accessConfig.Sinks = nil
+	accessConfig.AccessLog = nil
+	accessConfig.DisableCaller = true
+	accessConfig.DisableStacktrace = true
+	return buildLoggerConfig(&accessConfig, defaultFields...)
+}
+
 func getOutputWriters(paths []string) ([]io.Writer, []io.Closer) {
 	if len(paths) == 0 {
 		return []io.Writer{os.Stdout}, nil
@@ -46,7 +46,7 @@ func TestConfig_Validate(t *testing.T) {
 				LogEncoding: EncodingJSON,
 			},
 			wantErr: true,
-			errMsg:  "level is invalid, must be one of: debug, info, warn, error",
+			errMsg:  "level is invalid, must be one of: trace, debug, info, warn, error, disabled",
 		},
 		{
 			name: "invalid log encoding should return error",
@@ -55,7 +55,7 @@ func TestConfig_Validate(t *testing.T) {
 				LogEncoding: Encoding("invalid"),
 			},
 			wantErr: true,
-			errMsg:  "encoding is invalid, must be one of: json, console",
+			errMsg:  "encoding is invalid, must be one of: json, console, logfmt",
 		},
 		{
 			name: "valid config should not return error",
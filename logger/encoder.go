@@ -30,6 +30,8 @@ var (
 // levelToLower returns the lowercase level string (switch avoids map lookup overhead).
 func levelToLower(level Level) string {
 	switch level {
+	case LevelTrace:
+		return "trace"
 	case LevelDebug:
 		return "debug"
 	case LevelInfo:
@@ -46,6 +48,8 @@ func levelToLower(level Level) string {
 // levelToUpper returns the uppercase level string.
 func levelToUpper(level Level) string {
 	switch level {
+	case LevelTrace:
+		return "TRACE"
 	case LevelDebug:
 		return "DEBUG"
 	case LevelInfo:
@@ -310,6 +314,10 @@ func appendConsoleFieldValue(buf []byte, f *Field) []byte {
 		return strconv.AppendBool(buf, f.Integer == 1)
 	case FieldTypeFloat64:
 		return strconv.AppendFloat(buf, int64BitsToFloat64(f.Integer), 'f', -1, 64)
+	case FieldTypeDuration:
+		return append(buf, time.Duration(f.Integer).String()...)
+	case FieldTypeTime:
+		return fieldTime(f).AppendFormat(buf, time.RFC3339Nano)
 	default:
 		if f.Iface == nil {
 			return append(buf, "<nil>"...)
@@ -323,6 +331,8 @@ func appendConsoleFieldValue(buf []byte, f *Field) []byte {
 
 func colorizeLevel(levelStr string, level Level) string {
 	switch level {
+	case LevelTrace:
+		return "\033[90m" + levelStr + "\033[0m"
 	case LevelDebug:
 		return "\033[36m" + levelStr + "\033[0m"
 	case LevelInfo:
@@ -349,6 +359,12 @@ func appendTypedFieldValue(buf []byte, f *Field) []byte {
 		return strconv.AppendBool(buf, f.Integer == 1)
 	case FieldTypeFloat64:
 		return appendJSONFloat(buf, int64BitsToFloat64(f.Integer))
+	case FieldTypeDuration:
+		return appendJSONString(buf, time.Duration(f.Integer).String())
+	case FieldTypeTime:
+		buf = append(buf, '"')
+		buf = fieldTime(f).AppendFormat(buf, time.RFC3339Nano)
+		return append(buf, '"')
 	default:
 		return appendJSONValue(buf, f.Iface)
 	}
@@ -5,6 +5,7 @@ package logger
 import (
 	"encoding/binary"
 	"math"
+	"time"
 )
 
 // FieldType indicates how a Field's value is stored, allowing the encoder
@@ -22,6 +23,10 @@ const (
 	FieldTypeBool
 	// FieldTypeFloat64 -- value stored in Integer (math.Float64bits).
 	FieldTypeFloat64
+	// FieldTypeDuration -- value stored in Integer (nanoseconds).
+	FieldTypeDuration
+	// FieldTypeTime -- value stored in Integer (Unix nanoseconds), *time.Location in Iface.
+	FieldTypeTime
 )
 
 // Field represents a key-value pair for structured logging.
@@ -82,6 +87,37 @@ func Bool(key string, val bool) Field {
 	return Field{Key: key, Type: FieldTypeBool, Integer: i}
 }
 
+// Duration creates a Field with a time.Duration value (zero-alloc).
+// It is encoded as a string such as "1.5s", not as integer nanoseconds.
+func Duration(key string, val time.Duration) Field {
+	return Field{Key: key, Type: FieldTypeDuration, Integer: int64(val)}
+}
+
+// minTimeNano and maxTimeNano bound the times representable as Unix nanoseconds.
+var (
+	minTimeNano = time.Unix(0, math.MinInt64)
+	maxTimeNano = time.Unix(0, math.MaxInt64)
+)
+
+// Time creates a Field with a time.Time value, encoded as an RFC 3339 string
+// with nanoseconds in val's location. Times between the years 1678 and 2262
+// are zero-alloc; others are formatted up front into a String field.
+func Time(key string, val time.Time) Field {
+	if val.Before(minTimeNano) || val.After(maxTimeNano) {
+		return String(key, val.Format(time.RFC3339Nano))
+	}
+	return Field{Key: key, Type: FieldTypeTime, Integer: val.UnixNano(), Iface: val.Location()}
+}
+
+// fieldTime rebuilds the time.Time stored in a FieldTypeTime field.
+func fieldTime(f *Field) time.Time {
+	t := time.Unix(0, f.Integer)
+	if loc, ok := f.Iface.(*time.Location); ok && loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
 // Any creates a Field with any value type (may allocate for boxing).
 func Any(key string, val any) Field {
 	// Try to detect common types and use typed fields to avoid boxing
@@ -3,9 +3,13 @@
 package logger
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"math"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestInt64(t *testing.T) {
@@ -220,3 +224,47 @@ func TestErrorField(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldConstructors_Encoding(t *testing.T) {
+	at := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.FixedZone("ICT", 7*3600))
+	ancient := time.Date(1066, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		field    Field
+		wantJSON string
+		wantText string
+	}{
+		{"duration", Duration("elapsed", 1500*time.Millisecond), `"elapsed":"1.5s"`, "elapsed=1.5s"},
+		{"time", Time("at", at), `"at":"2026-03-14T09:26:53.589793+07:00"`, "at=2026-03-14T09:26:53.589793+07:00"},
+		{"time outside nanosecond range", Time("at", ancient), `"at":"1066-10-14T00:00:00Z"`, "at=1066-10-14T00:00:00Z"},
+		{"int", Int("status", 201), `"status":201`, "status=201"},
+		{"bool", Bool("cached", false), `"cached":false`, "cached=false"},
+		{"any slice", Any("ids", []int{7, 9}), `"ids":[7,9]`, "ids=[7 9]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, enc := range []struct {
+				encoding Encoding
+				want     string
+			}{
+				{EncodingJSON, tt.wantJSON},
+				{EncodingConsole, tt.wantText},
+			} {
+				var buf bytes.Buffer
+				log := NewLogger(&Config{
+					LogLevel:      LevelInfo,
+					LogEncoding:   enc.encoding,
+					DisableCaller: true,
+				}, []io.Writer{AddSync(&buf)}, tt.field)
+				log.Info("typed field")
+				log.Sync()
+
+				if !strings.Contains(buf.String(), enc.want) {
+					t.Errorf("%s output = %s, want it to contain %s", enc.encoding, buf.String(), enc.want)
+				}
+			}
+		})
+	}
+}
@@ -3,17 +3,20 @@
 package logger
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"sync"
 )
 
 var (
-	loggerInstance      *Logger
-	asyncLoggerInstance *AsyncLogger
-	once                sync.Once
-	asyncOnce           sync.Once
+	loggerInstance       *Logger
+	accessLoggerInstance *Logger
+	asyncLoggerInstance  *AsyncLogger
+	once                 sync.Once
+	asyncOnce            sync.Once
 )
 
 // InitLogger initializes the logger with custom configuration and optional default log fields.
@@ -46,13 +49,19 @@ func InitLogger(config *Config, defaultLogFields ...Field) func() {
 		}
 
 		loggerInstance = buildLoggerConfig(config, defaultLogFields...)
-		current := loggerInstance
+		if config.AccessLog != nil {
+			accessLoggerInstance = buildAccessLogger(config, defaultLogFields...)
+		}
+		current, access := loggerInstance, accessLoggerInstance
 		undo = func() {
-			if current != nil {
-				current.flushOutputs()
-				current.closeOutputs()
+			for _, l := range []*Logger{current, access} {
+				if l != nil {
+					l.flushOutputs()
+					l.closeOutputs()
+				}
 			}
 			loggerInstance = nil
+			accessLoggerInstance = nil
 			once = sync.Once{}
 		}
 	})
@@ -109,6 +118,39 @@ func InitProductionLogger() func() {
 	return InitLogger(&ProductionConfig)
 }
 
+// InitNoop initializes the global logger with logging disabled (NoopConfig).
+// The package-level functions (Info, Debugw, ...) and loggers derived with
+// NewLoggerWithFields then drop every entry without formatting it, and no
+// default logger is auto-initialized. Useful for benchmarks and for libraries
+// that embed the SDK. Like the other Init* helpers it has no effect if the
+// global logger is already initialized. To turn logging back on, call the
+// returned undo function and initialize a real logger.
+//
+// Input:
+//   - None
+//
+// Output:
+//   - func(): Cleanup function to restore global logger state
+//
+// Example:
+//
+//	undo := logger.InitNoop()
+//	logger.Infow("dropped", "key", "value") // no output
+//	undo()
+//	logger.InitProductionLogger() // logging enabled again
+func InitNoop() func() {
+	undo := func() {}
+	once.Do(func() {
+		loggerInstance = NewLogger(&NoopConfig, []io.Writer{io.Discard})
+		undo = func() {
+			loggerInstance = nil
+			once = sync.Once{}
+		}
+	})
+
+	return undo
+}
+
 // InitAsyncLogger initializes an asynchronous logger with custom configuration and optional default log fields.
 // Log entries are queued and written in a background goroutine, providing non-blocking logging.
 //
@@ -174,6 +216,88 @@ func NewLoggerWithFields(fields ...Field) *Logger {
 	return ensureGlobalLogger().With(fields...)
 }
 
+// AccessLogger returns the logger for HTTP access logs. With Config.AccessLog
+// set in InitLogger it is a separate logger with its own level, encoding and
+// outputs, so pipelines can route access logs apart from application logs;
+// otherwise it is the global app logger.
+//
+// Input:
+//   - None
+//
+// Output:
+//   - *Logger: The access logger, or the global logger when none is configured
+//
+// Example:
+//
+//	undo := logger.InitLogger(&logger.Config{
+//	    LogLevel:    logger.LevelWarn,
+//	    LogEncoding: logger.EncodingConsole,
+//	    AccessLog: &logger.SinkConfig{
+//	        LogLevel:    logger.LevelInfo,
+//	        LogEncoding: logger.EncodingJSON,
+//	        OutputPaths: []string{"/var/log/app/access.log"},
+//	    },
+//	})
+//	defer undo()
+//	logger.AccessLogger().Infow("request completed", "status", 200)
+func AccessLogger() *Logger {
+	if access := accessLoggerInstance; access != nil {
+		return access
+	}
+	return ensureGlobalLogger()
+}
+
+// Trace logs a message at trace level using the global logger.
+// Automatically initializes with default configuration if logger is not initialized.
+//
+// Input:
+//   - args: Arguments to log (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	logger.Trace("Processing request")
+//	logger.Trace("User", "john", "logged in")
+func Trace(args ...any) {
+	logGlobalArgs(LevelTrace, args...)
+}
+
+// Tracef logs a formatted message at trace level using the global logger.
+// Automatically initializes with default configuration if logger is not initialized.
+//
+// Input:
+//   - template: Format string (Printf-style)
+//   - args: Arguments for the format string (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	logger.Tracef("User %s logged in with id %d", "john", 12345)
+func Tracef(template string, args ...any) {
+	logGlobalFormatted(LevelTrace, template, args...)
+}
+
+// Tracew logs a message with structured key-value pairs at trace level using the global logger.
+// Automatically initializes with default configuration if logger is not initialized.
+//
+// Input:
+//   - msg: Log message
+//   - keysAndValues: Alternating keys and values for structured logging (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	logger.Tracew("Request received", "method", "GET", "path", "/api/users")
+func Tracew(msg string, keysAndValues ...any) {
+	logGlobalStructured(LevelTrace, msg, keysAndValues...)
+}
+
 // Debug logs a message at debug level using the global logger.
 // Automatically initializes with default configuration if logger is not initialized.
 //
@@ -448,6 +572,57 @@ func Flush() {
 	if loggerInstance != nil {
 		loggerInstance.flushOutputs()
 	}
+	if accessLoggerInstance != nil {
+		accessLoggerInstance.flushOutputs()
+	}
+}
+
+// Shutdown drains and closes the global sync and async loggers, giving up
+// when ctx is done so a stuck write or file sync cannot hang process exit.
+// Call it last in the shutdown sequence: entries logged afterwards may be lost.
+// The undo functions returned by the Init* helpers remain available; Shutdown
+// offers a deadline-bounded alternative for shutdown hooks.
+//
+// Input:
+//   - ctx: Context bounding how long to wait for the drain
+//
+// Output:
+//   - error: ctx.Err() (wrapped) if the drain did not finish in time, nil otherwise
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := logger.Shutdown(ctx); err != nil {
+//	    fmt.Fprintln(os.Stderr, "logger shutdown:", err)
+//	}
+func Shutdown(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("logger shutdown: %w", err)
+	}
+
+	asyncLogger, syncLogger, accessLogger := asyncLoggerInstance, loggerInstance, accessLoggerInstance
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if asyncLogger != nil && asyncLogger.rt != nil {
+			asyncLogger.Flush()
+			asyncLogger.rt.writer.closeOutputs()
+		}
+		for _, l := range []*Logger{syncLogger, accessLogger} {
+			if l != nil {
+				l.flushOutputs()
+				l.closeOutputs()
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("logger shutdown: %w", ctx.Err())
+	}
 }
 
 func ensureGlobalLogger() *Logger {
@@ -459,26 +634,41 @@ func ensureGlobalLogger() *Logger {
 
 func logGlobalArgs(level Level, args ...any) {
 	if async := asyncLoggerInstance; async != nil {
+		if !async.logger.shouldLog(level) {
+			return
+		}
 		msg, fields := async.logger.formatArgs(args...)
 		async.log(level, globalCallerSkip, msg, fields...)
 		return
 	}
 	logger := ensureGlobalLogger()
+	if !logger.shouldLog(level) {
+		return
+	}
 	msg, fields := logger.formatArgs(args...)
 	logger.log(level, globalCallerSkip, msg, fields...)
 }
 
 func logGlobalFormatted(level Level, template string, args ...any) {
-	msg := fmt.Sprintf(template, args...)
 	if async := asyncLoggerInstance; async != nil {
-		async.log(level, globalCallerSkip, msg)
+		if !async.logger.shouldLog(level) {
+			return
+		}
+		async.log(level, globalCallerSkip, fmt.Sprintf(template, args...))
 		return
 	}
-	ensureGlobalLogger().log(level, globalCallerSkip, msg)
+	logger := ensureGlobalLogger()
+	if !logger.shouldLog(level) {
+		return
+	}
+	logger.log(level, globalCallerSkip, fmt.Sprintf(template, args...))
 }
 
 func logGlobalStructured(level Level, msg string, keysAndValues ...any) {
 	if async := asyncLoggerInstance; async != nil {
+		if !async.logger.shouldLog(level) {
+			return
+		}
 		fsp, n := async.logger.parseKeysAndValues(keysAndValues...)
 		if fsp != nil {
 			async.log(level, globalCallerSkip, msg, (*fsp)[:n]...)
@@ -489,6 +679,9 @@ func logGlobalStructured(level Level, msg string, keysAndValues ...any) {
 		return
 	}
 	logger := ensureGlobalLogger()
+	if !logger.shouldLog(level) {
+		return
+	}
 	fsp, n := logger.parseKeysAndValues(keysAndValues...)
 	if fsp != nil {
 		logger.log(level, globalCallerSkip, msg, (*fsp)[:n]...)
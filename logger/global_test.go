@@ -3,8 +3,13 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -86,6 +91,45 @@ func TestInitDevelopmentLogger(t *testing.T) {
 	}
 }
 
+func TestInitNoop(t *testing.T) {
+	// Reset singleton state for testing
+	loggerInstance = nil
+	once = sync.Once{}
+
+	undo := InitNoop()
+	defer undo()
+
+	if loggerInstance == nil || loggerInstance.config.LogLevel != LevelDisabled {
+		t.Fatal("InitNoop should install a disabled global logger")
+	}
+
+	// Capture whatever the global logger would write
+	var buf bytes.Buffer
+	loggerInstance.outputs = wrapOutputs([]io.Writer{&buf})
+
+	Trace("trace")
+	Debugf("debug %d", 1)
+	Infow("info", "key", "value")
+	Warn("warn", "key", "value")
+	Errorw("error", "error", errors.New("boom"))
+	NewLoggerWithFields(String("component", "lib")).Errorw("derived", "key", "value")
+	Flush()
+
+	if buf.Len() != 0 {
+		t.Errorf("disabled logger emitted %q", buf.String())
+	}
+	if loggerInstance.config.LogLevel != LevelDisabled {
+		t.Error("global functions should not replace the noop logger")
+	}
+
+	// Logging comes back after undo + a real logger
+	undo()
+	undo = InitProductionLogger()
+	if loggerInstance == nil || loggerInstance.config.LogLevel != LevelInfo {
+		t.Error("InitProductionLogger should re-enable logging after undo")
+	}
+}
+
 func TestInitProductionLogger(t *testing.T) {
 	// Reset singleton state for testing
 	loggerInstance = nil
@@ -185,6 +229,115 @@ func TestSingletonBehavior(t *testing.T) {
 	}
 }
 
+func TestAccessLogger(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	t.Run("falls back to the app logger", func(t *testing.T) {
+		loggerInstance = nil
+		once = sync.Once{}
+		undo := InitLogger(&Config{LogLevel: LevelInfo, LogEncoding: EncodingJSON, OutputPaths: []string{"app.log"}})
+		defer undo()
+
+		if AccessLogger() != loggerInstance {
+			t.Error("AccessLogger() should return the global logger without Config.AccessLog")
+		}
+	})
+
+	t.Run("dedicated core", func(t *testing.T) {
+		loggerInstance = nil
+		once = sync.Once{}
+		undo := InitLogger(&Config{
+			LogLevel:    LevelError,
+			LogEncoding: EncodingConsole,
+			OutputPaths: []string{"app-only.log"},
+			AccessLog: &SinkConfig{
+				LogLevel:    LevelInfo,
+				LogEncoding: EncodingJSON,
+				OutputPaths: []string{"access.log"},
+			},
+		})
+
+		AccessLogger().Infow("request completed", "status", 200)
+		Errorw("app failure", "component", "db")
+		undo()
+
+		access, err := os.ReadFile("access.log")
+		if err != nil {
+			t.Fatalf("read access.log: %v", err)
+		}
+		app, err := os.ReadFile("app-only.log")
+		if err != nil {
+			t.Fatalf("read app-only.log: %v", err)
+		}
+		// The access core logs info as JSON although the app logger is at error level
+		if !strings.Contains(string(access), `"msg":"request completed"`) || !strings.Contains(string(access), `"status":200`) {
+			t.Errorf("access.log = %q, want the JSON access entry", access)
+		}
+		if strings.Contains(string(access), "app failure") {
+			t.Errorf("access.log should not contain app entries: %q", access)
+		}
+		if strings.Contains(string(app), "request completed") || !strings.Contains(string(app), "app failure") {
+			t.Errorf("app-only.log = %q, want only the app entry", app)
+		}
+		if accessLoggerInstance != nil {
+			t.Error("undo should reset the access logger")
+		}
+	})
+
+	t.Run("invalid access config", func(t *testing.T) {
+		cfg := &Config{LogLevel: LevelInfo, LogEncoding: EncodingJSON, AccessLog: &SinkConfig{LogLevel: "loud", LogEncoding: EncodingJSON}}
+		if err := cfg.Validate(); err == nil {
+			t.Error("Validate() should reject an invalid access log level")
+		}
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	t.Run("cancelled context returns promptly", func(t *testing.T) {
+		loggerInstance = nil
+		once = sync.Once{}
+		undo := InitLogger(&Config{LogLevel: LevelInfo, LogEncoding: EncodingJSON, OutputPaths: []string{"app.log"}})
+		defer undo()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		start := time.Now()
+		err := Shutdown(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Shutdown() error = %v, want context.Canceled", err)
+		}
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("Shutdown() took %v with a cancelled context", elapsed)
+		}
+	})
+
+	t.Run("drains sync logger", func(t *testing.T) {
+		loggerInstance = nil
+		once = sync.Once{}
+		undo := InitLogger(&Config{LogLevel: LevelInfo, LogEncoding: EncodingJSON, OutputPaths: []string{"drain.log"}})
+		defer undo()
+
+		Info("last words")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+
+		data, err := os.ReadFile("drain.log")
+		if err != nil {
+			t.Fatalf("failed to read drain.log: %v", err)
+		}
+		if !strings.Contains(string(data), "last words") {
+			t.Errorf("drain.log = %q, want it to contain the final entry", data)
+		}
+	})
+}
+
 func TestLogLevelMapping(t *testing.T) {
 	// Test that our level constants are valid
 	levels := []Level{LevelDebug, LevelInfo, LevelWarn, LevelError}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// LogfmtEncoder encodes entries as logfmt: space-separated key=value pairs on
+// one line, with the same keys, field order and levels as JSONEncoder.
+type LogfmtEncoder struct {
+	config   *Config
+	timezone *time.Location
+}
+
+func newLogfmtEncoder(config *Config) *LogfmtEncoder {
+	return &LogfmtEncoder{
+		config:   config,
+		timezone: resolveTimezone(config.Timezone),
+	}
+}
+
+// encodeToBuffer encodes an entry into a pooled byte buffer.
+// Caller must call putBuf(bp) after consuming the buffer.
+func (e *LogfmtEncoder) encodeToBuffer(entry *Entry) *[]byte {
+	bp := getBuf()
+	buf := *bp
+
+	// Fast-path: skip In() when timezone is already UTC
+	entryTime := entry.Time
+	if e.timezone != time.UTC {
+		entryTime = entryTime.In(e.timezone)
+	}
+	buf = append(buf, LogEncoderTimeKey...)
+	buf = append(buf, '=')
+	buf = entryTime.AppendFormat(buf, time.RFC3339Nano)
+
+	if entry.CallerDefined {
+		buf = append(buf, ' ')
+		buf = append(buf, LogEncoderCallerKey...)
+		buf = append(buf, '=')
+		buf = appendLogfmtString(buf, entry.CallerFile+":"+strconv.Itoa(entry.CallerLine))
+	}
+
+	buf = append(buf, ' ')
+	buf = append(buf, LogEncoderLevelKey...)
+	buf = append(buf, '=')
+	buf = append(buf, levelToLower(entry.Level)...)
+
+	buf = append(buf, ' ')
+	buf = append(buf, LogEncoderMessageKey...)
+	buf = append(buf, '=')
+	buf = appendLogfmtString(buf, entry.Message)
+
+	if entry.Stacktrace != "" {
+		buf = append(buf, ' ')
+		buf = append(buf, LogEncoderStacktraceKey...)
+		buf = append(buf, '=')
+		buf = appendLogfmtString(buf, entry.Stacktrace)
+	}
+
+	// trace_id and request_id first, like the JSON encoder
+	priorityIdx := [2]int{-1, -1}
+	for i := range entry.Fields {
+		switch entry.Fields[i].Key {
+		case "trace_id":
+			priorityIdx[0] = i
+		case "request_id":
+			priorityIdx[1] = i
+		}
+	}
+	for _, idx := range priorityIdx {
+		if idx >= 0 {
+			buf = appendLogfmtField(buf, &entry.Fields[idx])
+		}
+	}
+	for i := range entry.Fields {
+		if i == priorityIdx[0] || i == priorityIdx[1] {
+			continue
+		}
+		buf = appendLogfmtField(buf, &entry.Fields[i])
+	}
+
+	buf = append(buf, '\n')
+	*bp = buf
+	return bp
+}
+
+// Encode encodes an entry as logfmt.
+func (e *LogfmtEncoder) Encode(entry *Entry) string {
+	bp := e.encodeToBuffer(entry)
+	result := string(*bp)
+	putBuf(bp)
+	return result
+}
+
+// EncodeTo writes a logfmt-encoded entry directly to WriteSyncer using pooled buffer.
+func (e *LogfmtEncoder) EncodeTo(entry *Entry, ws WriteSyncer) (int, error) {
+	bp := e.encodeToBuffer(entry)
+	n, err := ws.Write(*bp)
+	putBuf(bp)
+	return n, err
+}
+
+// appendLogfmtField appends " key=value" for a field.
+func appendLogfmtField(buf []byte, f *Field) []byte {
+	buf = append(buf, ' ')
+	buf = appendLogfmtKey(buf, f.Key)
+	buf = append(buf, '=')
+	return appendLogfmtValue(buf, f)
+}
+
+// appendLogfmtKey appends a key, replacing the characters logfmt keys cannot
+// hold (space, '=', '"' and control characters) with '_'.
+func appendLogfmtKey(buf []byte, key string) []byte {
+	if key == "" {
+		return append(buf, '_')
+	}
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			buf = append(buf, '_')
+			continue
+		}
+		buf = utf8.AppendRune(buf, r)
+	}
+	return buf
+}
+
+// appendLogfmtValue appends a field value. Numbers and booleans are written
+// bare; everything else is a string, quoted when needed. Any values that are
+// not scalars are written as quoted JSON.
+func appendLogfmtValue(buf []byte, f *Field) []byte {
+	switch f.Type {
+	case FieldTypeString:
+		return appendLogfmtString(buf, f.Str)
+	case FieldTypeInt64:
+		return strconv.AppendInt(buf, f.Integer, 10)
+	case FieldTypeBool:
+		return strconv.AppendBool(buf, f.Integer == 1)
+	case FieldTypeFloat64:
+		return appendLogfmtFloat(buf, int64BitsToFloat64(f.Integer))
+	case FieldTypeDuration:
+		return append(buf, time.Duration(f.Integer).String()...)
+	case FieldTypeTime:
+		return fieldTime(f).AppendFormat(buf, time.RFC3339Nano)
+	default:
+		return appendLogfmtAny(buf, f.Iface)
+	}
+}
+
+// appendLogfmtAny appends a FieldTypeAny value.
+func appendLogfmtAny(buf []byte, v any) []byte {
+	switch val := v.(type) {
+	case string:
+		return appendLogfmtString(buf, val)
+	case float32:
+		return appendLogfmtFloat(buf, float64(val))
+	case float64:
+		return appendLogfmtFloat(buf, val)
+	case bool:
+		return strconv.AppendBool(buf, val)
+	case nil:
+		return append(buf, "null"...)
+	case error:
+		return appendLogfmtString(buf, val.Error())
+	case fmt.Stringer:
+		return appendLogfmtString(buf, val.String())
+	default:
+		if result, ok := appendJSONSignedInt(buf, v); ok {
+			return result
+		}
+		if result, ok := appendJSONUnsignedInt(buf, v); ok {
+			return result
+		}
+		jsonBytes, err := jsonLib.Marshal(val)
+		if err != nil {
+			return appendLogfmtString(buf, fmt.Sprintf("%v", val))
+		}
+		return appendLogfmtString(buf, string(jsonBytes))
+	}
+}
+
+// appendLogfmtFloat appends a float; NaN and infinities are written as NaN,
+// +Inf and -Inf.
+func appendLogfmtFloat(buf []byte, f float64) []byte {
+	return strconv.AppendFloat(buf, f, 'f', -1, 64)
+}
+
+// appendLogfmtString appends s bare when it is a single non-empty token, or
+// double-quoted with JSON escapes when it is empty or contains a space, '=',
+// '"', a control character or invalid UTF-8.
+func appendLogfmtString(buf []byte, s string) []byte {
+	if !logfmtNeedsQuotes(s) {
+		return append(buf, s...)
+	}
+	return appendJSONString(buf, s)
+}
+
+// logfmtNeedsQuotes reports whether s must be quoted to stay one value.
+func logfmtNeedsQuotes(s string) bool {
+	if s == "" {
+		return true
+	}
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				return true
+			}
+			i += size
+			continue
+		}
+		if b <= ' ' || b == '=' || b == '"' || b == '\\' || b == 0x7f {
+			return true
+		}
+		i++
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogfmtEncoder_Logger(t *testing.T) {
+	var out bytes.Buffer
+	config := &Config{
+		LogLevel:          LevelInfo,
+		LogEncoding:       EncodingLogfmt,
+		DisableCaller:     true,
+		DisableStacktrace: true,
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	log := NewLogger(config, []io.Writer{&out}, String("app", "orders"))
+
+	log.Infow("order created",
+		"order_id", "o-1",
+		"amount", 12.5,
+		"items", 3,
+		"paid", true,
+		"note", `says "hi"`,
+		"request_id", "req-1",
+	)
+	log.Sync()
+
+	line := strings.TrimSuffix(out.String(), "\n")
+	if !strings.HasPrefix(line, "ts=") {
+		t.Fatalf("line = %q, want it to start with ts=", line)
+	}
+	_, rest, _ := strings.Cut(line, " ")
+	want := `level=info msg="order created" request_id=req-1 app=orders order_id=o-1 amount=12.5 items=3 paid=true note="says \"hi\""`
+	if rest != want {
+		t.Errorf("line after ts = %q, want %q", rest, want)
+	}
+}
+
+func TestLogfmtEncoder_Values(t *testing.T) {
+	encoder := newLogfmtEncoder(&Config{})
+	entry := &Entry{
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   LevelError,
+		Message: "failed",
+		Fields: []Field{
+			String("empty", ""),
+			String("line", "a\nb"),
+			String("eq", "a=b"),
+			Duration("elapsed", 1500*time.Millisecond),
+			Float64("nan", math.NaN()),
+			{Key: "bad key", Type: FieldTypeAny, Iface: map[string]int{"a": 1}},
+			{Key: "err", Type: FieldTypeAny, Iface: errors.New("boom")},
+			{Key: "nil", Type: FieldTypeAny, Iface: nil},
+		},
+	}
+
+	got := encoder.Encode(entry)
+	want := `ts=2025-01-02T03:04:05Z level=error msg=failed empty="" line="a\nb" eq="a=b" elapsed=1.5s nan=NaN bad_key="{\"a\":1}" err=boom nil=null` + "\n"
+	if got != want {
+		t.Errorf("Encode() = %q, want %q", got, want)
+	}
+}
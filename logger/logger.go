@@ -50,6 +50,9 @@ type Logger struct {
 	mu              sync.RWMutex
 	callerSkip      int
 	encoder         Encoder
+	sinks           []sink // multi-sink routing; when set, outputs only serves Sync/Close
+	metrics         MetricsCounter
+	limiter         *rateLimiter // set by RateLimited
 }
 
 const (
@@ -62,14 +65,18 @@ const (
 // (avoids map hashing and lookup overhead on every log call).
 func levelOrder(l Level) int {
 	switch l {
-	case LevelDebug:
+	case LevelTrace:
 		return 0
-	case LevelInfo:
+	case LevelDebug:
 		return 1
-	case LevelWarn:
+	case LevelInfo:
 		return 2
-	case LevelError:
+	case LevelWarn:
 		return 3
+	case LevelError:
+		return 4
+	case LevelDisabled:
+		return 5
 	default:
 		return -1
 	}
@@ -103,27 +110,34 @@ func NewLogger(config *Config, outputs []io.Writer, fields ...Field) *Logger {
 		processed = append(processed, processField(field, config.MaskKey))
 	}
 
-	// Wrap outputs as BufferedWriteSyncers for non-blocking I/O
+	return &Logger{
+		config:          config,
+		processedFields: processed,
+		outputs:         wrapOutputs(outputs),
+		encoder:         newEncoder(config),
+	}
+}
+
+// wrapOutputs wraps outputs as BufferedWriteSyncers for non-blocking I/O.
+func wrapOutputs(outputs []io.Writer) []WriteSyncer {
 	wsOutputs := make([]WriteSyncer, 0, len(outputs))
 	for _, w := range outputs {
 		ws := AddSync(w)
 		bws := NewBufferedWriteSyncer(Lock(ws), 0, 0)
 		wsOutputs = append(wsOutputs, bws)
 	}
+	return wsOutputs
+}
 
-	// Initialize encoder
-	var encoder Encoder
-	if config.LogEncoding == EncodingJSON {
-		encoder = newJSONEncoder(config)
-	} else {
-		encoder = newConsoleEncoder(config)
-	}
-
-	return &Logger{
-		config:          config,
-		processedFields: processed,
-		outputs:         wsOutputs,
-		encoder:         encoder,
+// newEncoder returns the encoder matching config.LogEncoding.
+func newEncoder(config *Config) Encoder {
+	switch config.LogEncoding {
+	case EncodingJSON:
+		return newJSONEncoder(config)
+	case EncodingLogfmt:
+		return newLogfmtEncoder(config)
+	default:
+		return newConsoleEncoder(config)
 	}
 }
 
@@ -167,6 +181,9 @@ func (l *Logger) With(fields ...Field) *Logger {
 		closers:         l.closers,
 		callerSkip:      l.callerSkip,
 		encoder:         l.encoder,
+		sinks:           l.sinks,
+		metrics:         l.metrics,
+		limiter:         l.limiter,
 	}
 }
 
@@ -198,6 +215,9 @@ func (l *Logger) WithOptions(opts ...Option) *Logger {
 		closers:         l.closers,
 		callerSkip:      l.callerSkip,
 		encoder:         l.encoder,
+		sinks:           l.sinks,
+		metrics:         l.metrics,
+		limiter:         l.limiter,
 	}
 
 	for _, opt := range opts {
@@ -207,6 +227,63 @@ func (l *Logger) WithOptions(opts ...Option) *Logger {
 	return newLogger
 }
 
+// Trace logs a message at trace level, the most verbose level (below debug).
+// Use it for chatty diagnostics that should stay off unless LogLevel is LevelTrace.
+//
+// Input:
+//   - args: Variadic arguments to log (can be message string or key-value pairs)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	logger.Trace("Trace message")
+//	logger.Trace("Processing user", "user_id", 12345, "action", "create")
+func (l *Logger) Trace(args ...any) {
+	msg, fields := l.formatArgs(args...)
+	l.log(LevelTrace, 1, msg, fields...)
+}
+
+// Tracef logs a formatted message at trace level using Printf-style formatting.
+//
+// Input:
+//   - template: Format string (Printf-style)
+//   - args: Arguments for the format string (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	logger.Tracef("Processing user %s with id %d", "john", 12345)
+func (l *Logger) Tracef(template string, args ...any) {
+	msg := fmt.Sprintf(template, args...)
+	l.log(LevelTrace, 1, msg)
+}
+
+// Tracew logs a message with structured key-value pairs at trace level.
+//
+// Input:
+//   - msg: Log message
+//   - keysAndValues: Alternating keys and values for structured logging (variadic any)
+//
+// Output:
+//   - None
+//
+// Example:
+//
+//	logger.Tracew("Request received", "method", "GET", "path", "/api/users", "ip", "192.168.1.1")
+func (l *Logger) Tracew(msg string, keysAndValues ...any) {
+	fsp, n := l.parseKeysAndValues(keysAndValues...)
+	if fsp != nil {
+		l.log(LevelTrace, 1, msg, (*fsp)[:n]...)
+		putFieldSlice(fsp)
+	} else {
+		l.log(LevelTrace, 1, msg)
+	}
+}
+
 // Debug logs a message at debug level.
 //
 // Input:
@@ -554,6 +631,9 @@ func (l *Logger) createEntry(level Level, skipOffset int, msg string, fields []F
 	if !l.shouldLog(level) {
 		return nil
 	}
+	if l.limiter != nil && !l.limiter.allow(l, level, msg) {
+		return nil
+	}
 
 	entry := getEntry()
 	entry.Time = time.Now()
@@ -621,26 +701,39 @@ func (*Logger) getStacktrace() string {
 }
 
 func (l *Logger) writeEntry(entry *Entry) {
-	// No lock needed: outputs is immutable after construction.
-	outputs := l.outputs
+	// No lock needed: outputs and sinks are immutable after construction.
+	if len(l.sinks) > 0 {
+		lv := levelOrder(entry.Level)
+		for i := range l.sinks {
+			if lv >= l.sinks[i].minOrder {
+				encodeTo(l.sinks[i].encoder, entry, l.sinks[i].outputs)
+			}
+		}
+	} else {
+		encodeTo(l.encoder, entry, l.outputs)
+	}
+
+	l.countEntry(entry)
 
+	// Return entry to pool after all writes
+	putEntry(entry)
+}
+
+func encodeTo(encoder Encoder, entry *Entry, outputs []WriteSyncer) {
 	switch len(outputs) {
 	case 0:
 		// no outputs
 	case 1:
 		// Single output: zero-copy path
-		_, _ = l.encoder.EncodeTo(entry, outputs[0])
+		_, _ = encoder.EncodeTo(entry, outputs[0])
 	default:
 		// Multiple outputs: encode once, write bytes to all
-		encoded := l.encoder.Encode(entry)
+		encoded := encoder.Encode(entry)
 		b := []byte(encoded)
 		for _, ws := range outputs {
 			_, _ = ws.Write(b)
 		}
 	}
-
-	// Return entry to pool after all writes
-	putEntry(entry)
 }
 
 // Sync flushes all buffered output to the underlying writers.
@@ -244,3 +244,42 @@ func TestAsyncLogger_LogEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestLogger_TraceLevel(t *testing.T) {
+	t.Run("suppressed at debug level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewLogger(&Config{
+			LogLevel:    LevelDebug,
+			LogEncoding: EncodingJSON,
+		}, []io.Writer{&buf})
+
+		logger.Trace("trace plain")
+		logger.Tracef("trace %s", "formatted")
+		logger.Tracew("trace structured", "key", "value")
+		logger.Sync()
+		if buf.Len() != 0 {
+			t.Errorf("Expected no trace output at debug level, got: %s", buf.String())
+		}
+	})
+
+	t.Run("emitted at trace level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewLogger(&Config{
+			LogLevel:    LevelTrace,
+			LogEncoding: EncodingJSON,
+		}, []io.Writer{&buf})
+
+		logger.Trace("trace plain")
+		logger.Tracef("trace %s", "formatted")
+		logger.Tracew("trace structured", "key", "value")
+		logger.Debug("debug still on")
+		logger.Sync()
+
+		out := buf.String()
+		for _, want := range []string{"trace plain", "trace formatted", "trace structured", `"level":"trace"`, "debug still on"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Expected %q in output, got: %s", want, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import "context"
+
+// LogEntriesMetric is the counter incremented by WithMetricsHook for every
+// written entry, labeled by level.
+const LogEntriesMetric = "log_entries_total"
+
+// MetricsCounter is the subset of a metrics client used by WithMetricsHook.
+// metrics.Client satisfies it, so the logger does not depend on the metrics package.
+type MetricsCounter interface {
+	Inc(ctx context.Context, name string, tags ...string)
+}
+
+// WithMetricsHook creates an Option that increments the log_entries_total
+// counter (labeled by level) on every entry the logger writes. Entries
+// filtered out by the log level are not counted.
+//
+// Input:
+//   - counter: The metrics client to increment (e.g., a metrics.Client)
+//
+// Output:
+//   - Option: An option function that can be used with WithOptions
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp")
+//	log := logger.NewLoggerWithFields().WithOptions(logger.WithMetricsHook(client))
+//	log.Errorw("payment failed") // myapp_log_entries_total{level="error"} += 1
+func WithMetricsHook(counter MetricsCounter) Option {
+	return func(l *Logger) {
+		l.metrics = counter
+	}
+}
+
+// countEntry records entry in the metrics hook, if one is attached.
+func (l *Logger) countEntry(entry *Entry) {
+	if l.metrics != nil {
+		l.metrics.Inc(context.Background(), LogEntriesMetric, "level", string(entry.Level))
+	}
+}
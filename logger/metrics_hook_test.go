@@ -0,0 +1,60 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+)
+
+// recordingCounter counts Inc calls by name and label values.
+type recordingCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (r *recordingCounter) Inc(_ context.Context, name string, tags ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := name
+	for _, tag := range tags {
+		key += "|" + tag
+	}
+	r.counts[key]++
+}
+
+func (r *recordingCounter) get(key string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counts[key]
+}
+
+func TestWithMetricsHook(t *testing.T) {
+	counter := &recordingCounter{counts: make(map[string]int)}
+	base := NewLogger(&Config{LogLevel: LevelInfo, LogEncoding: EncodingJSON}, []io.Writer{io.Discard})
+	logger := base.WithOptions(WithMetricsHook(counter))
+
+	logger.Errorw("payment failed", "order_id", 42)
+	logger.Errorw("payment failed again")
+	logger.Info("ok")
+	logger.Debug("filtered by level")
+	logger.With(String("component", "billing")).Warn("derived logger keeps the hook")
+	base.Error("logger without the hook")
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{key: LogEntriesMetric + "|level|error", want: 2},
+		{key: LogEntriesMetric + "|level|info", want: 1},
+		{key: LogEntriesMetric + "|level|warn", want: 1},
+		{key: LogEntriesMetric + "|level|debug", want: 0},
+	}
+	for _, tt := range tests {
+		if got := counter.get(tt.key); got != tt.want {
+			t.Errorf("count[%s] = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// LoggedEntry is one entry captured by a test logger.
+type LoggedEntry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []Field // default fields first, then per-call fields, after masking
+}
+
+// ContextMap returns the entry's fields keyed by name, with values decoded to
+// their Go types: string, int64, bool, float64, time.Duration, time.Time, or
+// the original value for Any fields. Later fields win on duplicate keys.
+func (e LoggedEntry) ContextMap() map[string]any {
+	m := make(map[string]any, len(e.Fields))
+	for i := range e.Fields {
+		m[e.Fields[i].Key] = fieldValue(&e.Fields[i])
+	}
+	return m
+}
+
+// RecordedLogs holds the entries written to a test logger. It is safe for
+// concurrent use.
+type RecordedLogs struct {
+	mu      sync.Mutex
+	entries []LoggedEntry
+}
+
+// All returns a copy of every captured entry, oldest first.
+func (r *RecordedLogs) All() []LoggedEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]LoggedEntry, len(r.entries))
+	copy(out, r.entries)
+	return out
+}
+
+// Len returns the number of captured entries.
+func (r *RecordedLogs) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// FilterLevel returns the captured entries logged at level.
+func (r *RecordedLogs) FilterLevel(level Level) []LoggedEntry {
+	return r.filter(func(e *LoggedEntry) bool { return e.Level == level })
+}
+
+// FilterMessage returns the captured entries whose message is msg.
+func (r *RecordedLogs) FilterMessage(msg string) []LoggedEntry {
+	return r.filter(func(e *LoggedEntry) bool { return e.Message == msg })
+}
+
+// Reset discards every captured entry.
+func (r *RecordedLogs) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+func (r *RecordedLogs) filter(match func(*LoggedEntry) bool) []LoggedEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []LoggedEntry
+	for i := range r.entries {
+		if match(&r.entries[i]) {
+			out = append(out, r.entries[i])
+		}
+	}
+	return out
+}
+
+func (r *RecordedLogs) record(entry *Entry) {
+	// Entries are pooled, so the fields must be copied before they are reused
+	fields := make([]Field, len(entry.Fields))
+	copy(fields, entry.Fields)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, LoggedEntry{
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Message: entry.Message,
+		Fields:  fields,
+	})
+}
+
+// recordingEncoder is an Encoder that captures entries instead of encoding them.
+type recordingEncoder struct {
+	logs *RecordedLogs
+}
+
+func (e recordingEncoder) Encode(entry *Entry) string {
+	e.logs.record(entry)
+	return ""
+}
+
+func (e recordingEncoder) EncodeTo(entry *Entry, _ WriteSyncer) (int, error) {
+	e.logs.record(entry)
+	return 0, nil
+}
+
+// NewTestLogger creates a logger that captures every entry, at all levels,
+// in the returned RecordedLogs instead of writing it anywhere. It does not
+// touch the global logger, so tests can run in parallel. Loggers derived
+// with With or WithOptions record into the same RecordedLogs. Struct fields
+// tagged `log:"omit"` or `log:"mask"` are processed as usual, with "***" as
+// the mask.
+//
+// Input:
+//   - fields: Optional default fields added to every entry
+//
+// Output:
+//   - *Logger: The logger to pass to the code under test
+//   - *RecordedLogs: The captured entries
+//
+// Example:
+//
+//	log, logs := logger.NewTestLogger()
+//	svc := NewService(log)
+//	svc.Process(ctx)
+//	entries := logs.FilterMessage("order processed")
+//	if len(entries) != 1 || entries[0].ContextMap()["order_id"] != "o-1" {
+//	    t.Errorf("unexpected logs: %+v", logs.All())
+//	}
+func NewTestLogger(fields ...Field) (*Logger, *RecordedLogs) {
+	logs := &RecordedLogs{}
+	config := &Config{
+		LogLevel:          LevelTrace,
+		LogEncoding:       EncodingJSON,
+		DisableCaller:     true,
+		DisableStacktrace: true,
+	}
+	l := NewLogger(config, []io.Writer{io.Discard}, fields...)
+	l.encoder = recordingEncoder{logs: logs}
+	return l, logs
+}
+
+// fieldValue decodes a Field's typed union back into a Go value.
+func fieldValue(f *Field) any {
+	switch f.Type {
+	case FieldTypeString:
+		return f.Str
+	case FieldTypeInt64:
+		return f.Integer
+	case FieldTypeBool:
+		return f.Integer == 1
+	case FieldTypeFloat64:
+		return int64BitsToFloat64(f.Integer)
+	case FieldTypeDuration:
+		return time.Duration(f.Integer)
+	case FieldTypeTime:
+		return fieldTime(f)
+	default:
+		return f.Iface
+	}
+}
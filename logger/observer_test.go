@@ -0,0 +1,97 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewTestLogger(t *testing.T) {
+	log, logs := NewTestLogger(String("service", "orders"))
+
+	log.With(Int("attempt", 2)).Infow("order processed",
+		"order_id", "o-1", "latency", 150*time.Millisecond, "paid", true)
+	log.Debug("debug entry")
+	log.Errorw("charge failed", "error", errors.New("card declined"))
+
+	if got := logs.Len(); got != 3 {
+		t.Fatalf("Len() = %d, want 3", got)
+	}
+
+	entries := logs.FilterMessage("order processed")
+	if len(entries) != 1 {
+		t.Fatalf("FilterMessage() = %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != LevelInfo {
+		t.Errorf("Level = %q, want %q", entry.Level, LevelInfo)
+	}
+	if entry.Time.IsZero() {
+		t.Error("Time should be set")
+	}
+	want := map[string]any{
+		"service":  "orders",
+		"attempt":  int64(2),
+		"order_id": "o-1",
+		"latency":  150 * time.Millisecond,
+		"paid":     true,
+	}
+	got := entry.ContextMap()
+	if len(got) != len(want) {
+		t.Errorf("ContextMap() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("ContextMap()[%q] = %v (%T), want %v (%T)", k, got[k], got[k], v, v)
+		}
+	}
+
+	errs := logs.FilterLevel(LevelError)
+	if len(errs) != 1 {
+		t.Fatalf("FilterLevel(error) = %d entries, want 1", len(errs))
+	}
+	if err, ok := errs[0].ContextMap()["error"].(error); !ok || err.Error() != "card declined" {
+		t.Errorf("error field = %v, want card declined", errs[0].ContextMap()["error"])
+	}
+
+	logs.Reset()
+	if logs.Len() != 0 {
+		t.Errorf("Len() after Reset = %d, want 0", logs.Len())
+	}
+}
+
+func TestNewTestLogger_MasksTaggedFields(t *testing.T) {
+	type credentials struct {
+		User     string `json:"user"`
+		Password string `json:"password" log:"mask"`
+	}
+	log, logs := NewTestLogger()
+	log.Infow("login", "creds", credentials{User: "alice", Password: "secret"})
+
+	creds, ok := logs.All()[0].ContextMap()["creds"].(map[string]any)
+	if !ok {
+		t.Fatalf("creds = %T, want map[string]any", logs.All()[0].ContextMap()["creds"])
+	}
+	if creds["password"] == "secret" {
+		t.Error("password should be masked")
+	}
+}
+
+func TestNewTestLogger_Concurrent(t *testing.T) {
+	log, logs := NewTestLogger()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			for range 10 {
+				log.Info("tick")
+			}
+		})
+	}
+	wg.Wait()
+	if got := logs.Len(); got != 100 {
+		t.Errorf("Len() = %d, want 100", got)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// SuppressedFieldKey is the field holding the number of entries a
+// RateLimited logger dropped, on the summary entry it writes for them.
+const SuppressedFieldKey = "suppressed"
+
+// rateLimiterSweepSize is the number of tracked messages above which expired
+// ones are dropped when a new message arrives.
+const rateLimiterSweepSize = 1024
+
+// rateLimiters holds the limiter of every RateLimited key, so loggers created
+// by separate RateLimited calls with the same key share their windows.
+var rateLimiters sync.Map // map[string]*rateLimiter
+
+// rateLimiter tracks the current window of every message logged under a key.
+type rateLimiter struct {
+	every   time.Duration
+	mu      sync.Mutex
+	windows map[string]*suppressionWindow // by level + message
+}
+
+// suppressionWindow is the window opened by the first entry of a message.
+type suppressionWindow struct {
+	start      time.Time
+	suppressed int
+	level      Level
+	msg        string
+	logger     *Logger // writes the summary
+	timer      *time.Timer
+}
+
+// RateLimited returns a logger that writes a message at most once per every
+// for key: further entries with the same level and message inside the window
+// are dropped, and when the window ends a summary entry with that message and
+// a "suppressed" field counting the dropped entries is written (without
+// caller or stack trace). The next entry after that opens a new window.
+// Different messages are limited independently, so use a constant message
+// with the variable parts as fields. Use it on paths that can fail in a
+// tight loop, so an outage does not flood the log pipeline.
+//
+// Keys are process-wide: every logger rate-limited with the same key shares
+// its windows, and the window of the first RateLimited call for a key is
+// used. A non-positive every returns l unchanged.
+//
+// Example:
+//
+//	log.RateLimited("redis", time.Minute).Errorw("cache write failed", "error", err)
+//	// {"level":"error","msg":"cache write failed","error":"connection refused"}
+//	// ... one minute later:
+//	// {"level":"error","msg":"cache write failed","suppressed":4821}
+func (l *Logger) RateLimited(key string, every time.Duration) *Logger {
+	if every <= 0 {
+		return l
+	}
+	limiter, _ := rateLimiters.LoadOrStore(key, &rateLimiter{
+		every:   every,
+		windows: make(map[string]*suppressionWindow),
+	})
+	rl := l.WithOptions()
+	rl.limiter = limiter.(*rateLimiter)
+	return rl
+}
+
+// allow reports whether an entry with level and msg may be written by l,
+// counting it towards the summary of the open window otherwise.
+func (r *rateLimiter) allow(l *Logger, level Level, msg string) bool {
+	now := time.Now()
+	id := string(level) + "\x00" + msg
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w := r.windows[id]
+	if w == nil || (w.suppressed == 0 && now.Sub(w.start) >= r.every) {
+		if w == nil && len(r.windows) >= rateLimiterSweepSize {
+			r.sweep(now)
+		}
+		r.windows[id] = &suppressionWindow{start: now, level: level, msg: msg}
+		return true
+	}
+
+	w.suppressed++
+	w.logger = l
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.start.Add(r.every).Sub(now), func() { r.flush(id, w) })
+	}
+	return false
+}
+
+// flush closes window w and writes its summary.
+func (r *rateLimiter) flush(id string, w *suppressionWindow) {
+	r.mu.Lock()
+	if r.windows[id] == w {
+		delete(r.windows, id)
+	}
+	suppressed, l := w.suppressed, w.logger
+	r.mu.Unlock()
+
+	l.writeSummary(w.level, w.msg, Int(SuppressedFieldKey, suppressed))
+}
+
+// sweep drops windows that ended without suppressing anything. Windows with
+// suppressed entries are removed by their flush.
+func (r *rateLimiter) sweep(now time.Time) {
+	for id, w := range r.windows {
+		if w.suppressed == 0 && now.Sub(w.start) >= r.every {
+			delete(r.windows, id)
+		}
+	}
+}
+
+// writeSummary writes an entry for a RateLimited summary. It bypasses the
+// limiter and has no caller or stack trace, since it is written from a timer.
+func (l *Logger) writeSummary(level Level, msg string, fields ...Field) {
+	if !l.shouldLog(level) {
+		return
+	}
+	entry := getEntry()
+	entry.Time = time.Now()
+	entry.Level = level
+	entry.Message = msg
+	entry.Fields = append(entry.Fields, l.processedFields...)
+	entry.Fields = append(entry.Fields, fields...)
+	l.writeEntry(entry)
+}
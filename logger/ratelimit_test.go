@@ -0,0 +1,86 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the logger's flush goroutine.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+}
+
+func TestLogger_RateLimited(t *testing.T) {
+	out := &lockedBuffer{}
+	config := &Config{
+		LogLevel:          LevelInfo,
+		LogEncoding:       EncodingLogfmt,
+		DisableCaller:     true,
+		DisableStacktrace: true,
+	}
+	log := NewLogger(config, []io.Writer{out})
+	window := 200 * time.Millisecond
+	key := t.Name() + time.Now().String() // keys are process-wide
+
+	for range 100 {
+		log.RateLimited(key, window).Errorw("cache write failed", "error", "connection refused")
+	}
+	log.RateLimited(key, window).Warnw("cache slow")
+	log.Infow("not limited")
+	log.Infow("not limited")
+	log.Sync()
+
+	got := out.lines()
+	if len(got) != 4 {
+		t.Fatalf("got %d lines before the window ended, want 4:\n%s", len(got), strings.Join(got, "\n"))
+	}
+	if !strings.Contains(got[0], `msg="cache write failed" error="connection refused"`) {
+		t.Errorf("first line = %q, want the first cache write failure", got[0])
+	}
+	if !strings.Contains(got[1], `msg="cache slow"`) {
+		t.Errorf("second line = %q, want the other message, limited separately", got[1])
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(out.lines()) < 5 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		log.Sync()
+	}
+	got = out.lines()
+	if len(got) != 5 {
+		t.Fatalf("got %d lines after the window ended, want 5 with the summary:\n%s", len(got), strings.Join(got, "\n"))
+	}
+	if !strings.Contains(got[4], `level=error msg="cache write failed" suppressed=99`) {
+		t.Errorf("summary = %q, want the message with suppressed=99", got[4])
+	}
+
+	// The window is closed, so the next entry is written again
+	log.RateLimited(key, window).Errorw("cache write failed")
+	log.Sync()
+	if got := out.lines(); len(got) != 6 {
+		t.Errorf("got %d lines, want the entry after the summary written", len(got))
+	}
+
+	if l := log.RateLimited(key, 0); l != log {
+		t.Error("RateLimited(key, 0) should return the logger unchanged")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import "io"
+
+// sink is one output destination of a multi-sink logger with its own
+// minimum level and encoder.
+type sink struct {
+	minOrder int
+	encoder  Encoder
+	outputs  []WriteSyncer
+}
+
+// buildSinkLogger builds a logger that routes each entry to every sink whose
+// level it meets. The returned logger filters at the most verbose sink level so
+// entries no sink accepts are dropped before encoding.
+func buildSinkLogger(config *Config, defaultFields ...Field) *Logger {
+	base := *config
+	base.Sinks = nil
+	base.OutputPaths = nil
+
+	// Share one buffered writer per underlying writer (e.g., two sinks on stdout)
+	shared := make(map[io.Writer]WriteSyncer)
+	var (
+		all     []WriteSyncer
+		closers []io.Closer
+		sinks   = make([]sink, 0, len(config.Sinks))
+		minimum = -1
+	)
+
+	for _, sc := range config.Sinks {
+		writers, c := getOutputWriters(sc.OutputPaths)
+		closers = append(closers, c...)
+
+		outputs := make([]WriteSyncer, 0, len(writers))
+		for _, w := range writers {
+			ws, ok := shared[w]
+			if !ok {
+				ws = wrapOutputs([]io.Writer{w})[0]
+				shared[w] = ws
+				all = append(all, ws)
+			}
+			outputs = append(outputs, ws)
+		}
+
+		sinkConfig := base
+		sinkConfig.LogLevel = sc.LogLevel
+		sinkConfig.LogEncoding = sc.LogEncoding
+
+		order := levelOrder(sc.LogLevel)
+		if minimum < 0 || order < minimum {
+			minimum = order
+			base.LogLevel = sc.LogLevel
+		}
+
+		sinks = append(sinks, sink{
+			minOrder: order,
+			encoder:  newEncoder(&sinkConfig),
+			outputs:  outputs,
+		})
+	}
+	base.LogEncoding = config.Sinks[0].LogEncoding
+
+	processed := make([]Field, 0, len(defaultFields))
+	for _, field := range defaultFields {
+		processed = append(processed, processField(field, base.MaskKey))
+	}
+
+	logger := &Logger{
+		config:          &base,
+		processedFields: processed,
+		outputs:         all,
+		encoder:         newEncoder(&base),
+		sinks:           sinks,
+	}
+	logger.setClosers(closers)
+	return logger
+}
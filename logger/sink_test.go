@@ -0,0 +1,80 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestBuildLoggerConfig_Sinks(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	config := &Config{
+		DisableStacktrace: true,
+		Sinks: []SinkConfig{
+			{LogLevel: LevelInfo, LogEncoding: EncodingJSON, OutputPaths: []string{"app.log"}},
+			{LogLevel: LevelError, LogEncoding: EncodingJSON, OutputPaths: []string{"error.log"}},
+		},
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	logger := buildLoggerConfig(config)
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Error("error message")
+	logger.closeOutputs()
+
+	appLog, err := os.ReadFile("app.log")
+	if err != nil {
+		t.Fatalf("failed to read app.log: %v", err)
+	}
+	errorLog, err := os.ReadFile("error.log")
+	if err != nil {
+		t.Fatalf("failed to read error.log: %v", err)
+	}
+
+	if strings.Contains(string(appLog), "debug message") {
+		t.Errorf("debug entry should be filtered from app.log, got: %s", appLog)
+	}
+	if !strings.Contains(string(appLog), "info message") || !strings.Contains(string(appLog), "error message") {
+		t.Errorf("app.log should contain info and error entries, got: %s", appLog)
+	}
+	if strings.Contains(string(errorLog), "info message") {
+		t.Errorf("info entry should not reach error.log, got: %s", errorLog)
+	}
+	if !strings.Contains(string(errorLog), "error message") {
+		t.Errorf("error.log should contain the error entry, got: %s", errorLog)
+	}
+}
+
+func TestConfig_Validate_Sinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		sink    SinkConfig
+		wantErr string
+	}{
+		{
+			name:    "invalid sink level",
+			sink:    SinkConfig{LogLevel: "verbose", LogEncoding: EncodingJSON},
+			wantErr: "sinks[0]: level is invalid",
+		},
+		{
+			name:    "invalid sink encoding",
+			sink:    SinkConfig{LogLevel: LevelInfo, LogEncoding: "xml"},
+			wantErr: "sinks[0]: encoding is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Config{Sinks: []SinkConfig{tt.sink}}).Validate()
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
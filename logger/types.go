@@ -6,13 +6,15 @@ package logger
 type Level string
 
 var validLevels = map[Level]struct{}{
-	LevelDebug: {},
-	LevelInfo:  {},
-	LevelWarn:  {},
-	LevelError: {},
+	LevelTrace:    {},
+	LevelDebug:    {},
+	LevelInfo:     {},
+	LevelWarn:     {},
+	LevelError:    {},
+	LevelDisabled: {},
 }
 
-var levelValuesCache = []string{"debug", "info", "warn", "error"}
+var levelValuesCache = []string{"trace", "debug", "info", "warn", "error", "disabled"}
 
 func (l Level) isValid() bool {
 	_, ok := validLevels[l]
@@ -29,9 +31,10 @@ type Encoding string
 var validEncodings = map[Encoding]struct{}{
 	EncodingJSON:    {},
 	EncodingConsole: {},
+	EncodingLogfmt:  {},
 }
 
-var encodingValuesCache = []string{"json", "console"}
+var encodingValuesCache = []string{"json", "console", "logfmt"}
 
 func (e Encoding) isValid() bool {
 	_, ok := validEncodings[e]
@@ -44,7 +47,10 @@ func encodingValues() []string {
 
 // Log level constants for filtering log messages.
 const (
-	// LevelDebug represents debug level logs (most verbose).
+	// LevelTrace represents trace level logs (most verbose, below debug).
+	// It is never enabled by the predefined configs; set it explicitly.
+	LevelTrace Level = "trace"
+	// LevelDebug represents debug level logs.
 	LevelDebug Level = "debug"
 	// LevelInfo represents informational level logs.
 	LevelInfo Level = "info"
@@ -52,6 +58,8 @@ const (
 	LevelWarn Level = "warn"
 	// LevelError represents error level logs (least verbose).
 	LevelError Level = "error"
+	// LevelDisabled turns logging off: no entry passes the level filter.
+	LevelDisabled Level = "disabled"
 )
 
 // Log encoding constants for output format.
@@ -60,6 +68,8 @@ const (
 	EncodingJSON Encoding = "json"
 	// EncodingConsole represents human-readable console output format.
 	EncodingConsole Encoding = "console"
+	// EncodingLogfmt represents logfmt output format (key=value pairs).
+	EncodingLogfmt Encoding = "logfmt"
 )
 
 // Log encoder key constants for structured log fields.
@@ -103,3 +113,12 @@ var ProductionConfig = Config{
 	DisableStacktrace: true,
 	IsDevelopment:     false,
 }
+
+// NoopConfig disables all logging. InitNoop installs it as the global logger
+// with a discarding output, so logging calls return before doing any work.
+var NoopConfig = Config{
+	LogLevel:          LevelDisabled,
+	LogEncoding:       EncodingJSON,
+	DisableCaller:     true,
+	DisableStacktrace: true,
+}
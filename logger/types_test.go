@@ -12,6 +12,11 @@ func TestLevel_IsValid(t *testing.T) {
 		level Level
 		want  bool
 	}{
+		{
+			name:  "trace level should be valid",
+			level: LevelTrace,
+			want:  true,
+		},
 		{
 			name:  "debug level should be valid",
 			level: LevelDebug,
@@ -56,7 +61,7 @@ func TestLevel_IsValid(t *testing.T) {
 
 func TestLevelValues(t *testing.T) {
 	got := levelValues()
-	expected := []string{"debug", "info", "warn", "error"}
+	expected := []string{"trace", "debug", "info", "warn", "error", "disabled"}
 
 	if len(got) != len(expected) {
 		t.Errorf("LevelValues() length = %v, want %v", len(got), len(expected))
@@ -110,7 +115,7 @@ func TestEncoding_IsValid(t *testing.T) {
 
 func TestEncodingValues(t *testing.T) {
 	got := encodingValues()
-	expected := []string{"json", "console"}
+	expected := []string{"json", "console", "logfmt"}
 
 	if len(got) != len(expected) {
 		t.Errorf("EncodingValues() length = %v, want %v", len(got), len(expected))
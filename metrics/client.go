@@ -50,20 +50,121 @@ type Client interface {
 	// GaugeDec decrements a gauge by 1
 	GaugeDec(ctx context.Context, name string, tags ...string)
 
+	// GaugeFunc registers a gauge whose value is read from fn at scrape time
+	// (see GaugeFunc on the Prometheus client)
+	GaugeFunc(name string, fn func() float64, tags ...string)
+
 	// Histogram records a value observation
 	Histogram(ctx context.Context, name string, value float64, tags ...string)
 
 	// Duration records the duration since start time
 	Duration(ctx context.Context, name string, start time.Time, tags ...string)
 
+	// Latency records a latency observation in a histogram and, with
+	// WithDualLatency, in a summary named name+"_summary"
+	Latency(ctx context.Context, name string, value float64, tags ...string)
+
+	// Rate records one event and sets a gauge to the events-per-second
+	// observed over a sliding window (see WithRateWindow)
+	Rate(ctx context.Context, name string, tags ...string)
+
+	// CounterHandle returns a counter bound to name and tags, so repeated
+	// increments skip the per-call lookup (see CounterHandle)
+	CounterHandle(name string, tags ...string) CounterHandle
+
+	// GaugeHandle returns a gauge bound to name and tags
+	GaugeHandle(name string, tags ...string) GaugeHandle
+
+	// HistogramHandle returns a histogram bound to name and tags
+	HistogramHandle(name string, tags ...string) HistogramHandle
+
+	// DeleteLabelValues removes the single series of a counter, gauge or
+	// histogram matching labels, without unregistering the metric.
+	// Returns whether a series was actually deleted.
+	DeleteLabelValues(name string, labels map[string]string) bool
+
 	// Handler returns an HTTP handler for exposing metrics
 	Handler() http.Handler
 
+	// SnapshotJSON returns the current value of every metric as indented
+	// JSON, for debugging. Scrapers should use Handler instead.
+	SnapshotJSON() ([]byte, error)
+
 	// Close performs any cleanup needed by the metrics client.
 	// For Prometheus, this is a no-op. For other backends, it may flush buffers.
 	Close() error
 }
 
+// ============================================================================
+// Metric Handles
+// ============================================================================
+
+// CounterHandle is a counter series resolved once by Client.CounterHandle.
+// The metric and its label values are bound when the handle is created, so
+// each call only updates the series; use it in hot loops instead of Inc/Add.
+// Handles are safe for concurrent use. A handle keeps writing to its series
+// after DeleteLabelValues removes it, so get a new handle after deleting.
+//
+// Example:
+//
+//	processed := client.CounterHandle("items_processed_total", "job", "import")
+//	for _, item := range items {
+//	    process(item)
+//	    processed.Inc()
+//	}
+type CounterHandle interface {
+	// Inc increments the counter by 1
+	Inc()
+
+	// Add adds the given value to the counter
+	Add(value int64)
+}
+
+// GaugeHandle is a gauge series resolved once by Client.GaugeHandle.
+// Labels are bound at creation, like CounterHandle.
+type GaugeHandle interface {
+	// Set sets the gauge to a specific value
+	Set(value float64)
+
+	// Inc increments the gauge by 1
+	Inc()
+
+	// Dec decrements the gauge by 1
+	Dec()
+}
+
+// HistogramHandle is a histogram series resolved once by Client.HistogramHandle.
+// Labels are bound at creation, like CounterHandle.
+//
+// Example:
+//
+//	batchSize := client.HistogramHandle("batch_size", "job", "import")
+//	for batch := range batches {
+//	    batchSize.Observe(float64(len(batch)))
+//	}
+type HistogramHandle interface {
+	// Observe records a value observation
+	Observe(value float64)
+
+	// Duration records the duration since start time in seconds
+	Duration(start time.Time)
+}
+
+// BucketsDefaulter is implemented by clients that let a library pick the
+// buckets of the histograms it records, such as a middleware recording sizes
+// rather than latencies. Buckets given with WithBucketsFor take precedence.
+//
+// Example:
+//
+//	if d, ok := client.(metrics.BucketsDefaulter); ok {
+//	    d.DefaultBucketsFor("payload_size_bytes", metrics.SizeBucketsBytes())
+//	}
+type BucketsDefaulter interface {
+	// DefaultBucketsFor sets the buckets of histogram name unless
+	// WithBucketsFor already did; it has no effect once the histogram exists
+	DefaultBucketsFor(name string, buckets []float64)
+}
+
 // ============================================================================
 // Default Histogram Buckets
 // ============================================================================
@@ -91,3 +192,61 @@ func DefaultDurationBuckets() []float64 {
 	copy(out, defaultDurationBuckets)
 	return out
 }
+
+// SummarySuffix is appended to a Latency metric name for the summary that
+// WithDualLatency emits alongside the histogram.
+const SummarySuffix = "_summary"
+
+// DefaultSummaryObjectives returns the quantiles (with their allowed error)
+// of the summaries emitted by WithDualLatency: p50, p90, p95 and p99.
+func DefaultSummaryObjectives() map[float64]float64 {
+	return map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.95: 0.005, 0.99: 0.001}
+}
+
+// ============================================================================
+// Bucket Presets
+// ============================================================================
+
+// LatencyBuckets returns buckets for request latencies in seconds, from 1ms
+// to 30s. Compared to DefaultDurationBuckets it adds resolution below 10ms and
+// covers slow requests up to the usual upstream timeouts.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp", metrics.WithBuckets(metrics.LatencyBuckets()))
+func LatencyBuckets() []float64 {
+	return []float64{
+		0.001, 0.0025, 0.005, 0.0075, // 1ms - 7.5ms
+		0.01, 0.025, 0.05, 0.075, // 10ms - 75ms
+		0.1, 0.25, 0.5, 0.75, // 100ms - 750ms
+		1, 2.5, 5, 10, 30, // 1s - 30s
+	}
+}
+
+// SizeBucketsBytes returns buckets for payload sizes in bytes, growing by a
+// factor of 4 from 64B to 64MB.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithBucketsFor("response_size_bytes", metrics.SizeBucketsBytes()),
+//	)
+func SizeBucketsBytes() []float64 {
+	return []float64{
+		64, 256, 1024, // 64B - 1KB
+		4096, 16384, 65536, 262144, // 4KB - 256KB
+		1048576, 4194304, 16777216, 67108864, // 1MB - 64MB
+	}
+}
+
+// CountBuckets returns buckets for small counts such as batch sizes, retries
+// or items per page, from 1 to 1000.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithBucketsFor("batch_size", metrics.CountBuckets()),
+//	)
+func CountBuckets() []float64 {
+	return []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000}
+}
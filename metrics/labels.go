@@ -0,0 +1,97 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ============================================================================
+// Label Validation
+// ============================================================================
+
+// LabelErrorsMetric is the counter, under the client namespace, of
+// observations dropped because their labels were malformed. Its "metric"
+// label holds the name of the metric the bad call targeted.
+const LabelErrorsMetric = "metrics_label_errors_total"
+
+// ErrOddLabelCount is reported (see WithLabelErrorHandler) when a call passes
+// an odd number of tags, so a key is missing its value.
+var ErrOddLabelCount = errors.New("metrics: odd number of label arguments")
+
+// labels validates tags for metric name. Malformed tags are counted in
+// LabelErrorsMetric and reported to the label error handler, and ok is false
+// so the caller drops the observation instead of creating a bad series. With
+// WithLabelNormalization the returned tags have normalized keys.
+func (c *prometheusClient) labels(name string, tags []string) ([]string, bool) {
+	if len(tags)%2 != 0 {
+		c.labelError(name, fmt.Errorf("%w: metric %q got %d (%s)", ErrOddLabelCount, name, len(tags), strings.Join(tags, ", ")))
+		return nil, false
+	}
+	if c.normalizeLabels {
+		tags = normalizeLabelKeys(tags)
+	}
+	return tags, true
+}
+
+// labelError counts and reports a malformed label set for metric name.
+func (c *prometheusClient) labelError(name string, err error) {
+	c.labelErrorsOnce.Do(func() {
+		counter := prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   c.namespace,
+				Name:        LabelErrorsMetric,
+				Help:        "Observations dropped because of malformed labels.",
+				ConstLabels: c.constLabels,
+			},
+			[]string{"metric"},
+		)
+		// Clients sharing a registry and namespace share the counter
+		if regErr := c.registerer.Register(counter); regErr != nil {
+			var already prometheus.AlreadyRegisteredError
+			if !errors.As(regErr, &already) {
+				panic(regErr)
+			}
+			counter = already.ExistingCollector.(*prometheus.CounterVec)
+		}
+		c.labelErrors = counter
+	})
+	c.labelErrors.WithLabelValues(name).Inc()
+	if c.onLabelError != nil {
+		c.onLabelError(err)
+	}
+}
+
+// normalizeLabelKeys returns a copy of tags with every key normalized by
+// normalizeLabelName; values are unchanged.
+func normalizeLabelKeys(tags []string) []string {
+	out := make([]string, len(tags))
+	copy(out, tags)
+	for i := 0; i < len(out); i += 2 {
+		out[i] = normalizeLabelName(out[i])
+	}
+	return out
+}
+
+// normalizeLabelName lowercases name and makes it a valid Prometheus label
+// name: characters other than [a-z0-9_] become '_', and a leading digit or an
+// empty name gets a '_' prefix.
+func normalizeLabelName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 1)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		b.WriteByte('_')
+	}
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
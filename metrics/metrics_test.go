@@ -4,13 +4,19 @@ package metrics
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ============================================================================
@@ -200,6 +206,78 @@ func TestHandler_ReturnsInstanceMetrics(t *testing.T) {
 	}
 }
 
+func TestNewClient_WithRegistry(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector())
+	external := prometheus.NewCounter(prometheus.CounterOpts{Name: "otherlib_jobs_total", Help: "Jobs run by another library"})
+	registry.MustRegister(external)
+	external.Add(3)
+
+	// The Go collector is already registered; NewClient must not panic
+	client := NewClient("myapp", WithRegistry(registry), WithoutProcessCollector())
+	client.Inc(context.Background(), "test_counter", "label", "value")
+
+	rec := httptest.NewRecorder()
+	client.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{`myapp_test_counter{label="value"} 1`, "otherlib_jobs_total 3", "go_goroutines"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected handler to expose %q, got:\n%s", want, body)
+		}
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	var found bool
+	for _, mf := range families {
+		found = found || mf.GetName() == "myapp_test_counter"
+	}
+	if !found {
+		t.Error("expected myapp_test_counter to be registered in the shared registry")
+	}
+}
+
+func TestLatency_WithDualLatency(t *testing.T) {
+	ctx := context.Background()
+	scrape := func(client Client) string {
+		rec := httptest.NewRecorder()
+		client.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	dual := NewClient("myapp", WithDualLatency(), WithoutGoCollector(), WithoutProcessCollector())
+	for _, v := range []float64{0.01, 0.02, 0.3} {
+		dual.Latency(ctx, "request_duration_seconds", v, "route", "/users")
+	}
+	body := scrape(dual)
+	for _, want := range []string{
+		`myapp_request_duration_seconds_bucket{route="/users",le="0.025"} 2`,
+		`myapp_request_duration_seconds_count{route="/users"} 3`,
+		`myapp_request_duration_seconds_summary{route="/users",quantile="0.5"} 0.02`,
+		`myapp_request_duration_seconds_summary_count{route="/users"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in scrape, got:\n%s", want, body)
+		}
+	}
+
+	if !dual.DeleteLabelValues("request_duration_seconds", map[string]string{"route": "/users"}) {
+		t.Error("DeleteLabelValues() = false, want true")
+	}
+	if body := scrape(dual); strings.Contains(body, `route="/users"`) {
+		t.Errorf("expected both series deleted, got:\n%s", body)
+	}
+
+	single := NewClient("myapp", WithoutGoCollector(), WithoutProcessCollector())
+	single.Latency(ctx, "request_duration_seconds", 0.01)
+	body = scrape(single)
+	if !strings.Contains(body, "myapp_request_duration_seconds_bucket") || strings.Contains(body, "quantile=") {
+		t.Errorf("without WithDualLatency expected a histogram only, got:\n%s", body)
+	}
+}
+
 func TestHandler_WithGoCollectors(t *testing.T) {
 	registry := prometheus.NewRegistry()
 	client := NewClientWithRegistry("myapp", registry)
@@ -240,6 +318,16 @@ func TestNoopClient(t *testing.T) {
 		client.Duration(ctx, "duration", time.Now())
 	})
 
+	t.Run("rate operations", func(t *testing.T) {
+		client.Rate(ctx, "rate", "key", "value")
+	})
+
+	t.Run("handle operations", func(t *testing.T) {
+		client.CounterHandle("counter").Add(2)
+		client.GaugeHandle("gauge").Set(1)
+		client.HistogramHandle("histogram").Observe(0.5)
+	})
+
 	t.Run("handler returns 200", func(t *testing.T) {
 		handler := client.Handler()
 		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
@@ -347,43 +435,211 @@ func TestWithBuckets(t *testing.T) {
 	}
 }
 
+func TestWithBucketsFor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("myapp", registry,
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+		WithBuckets(LatencyBuckets()),
+		WithBucketsFor("response_size_bytes", SizeBucketsBytes()),
+	)
+
+	ctx := context.Background()
+	client.Histogram(ctx, "request_duration", 0.3)
+	client.Histogram(ctx, "response_size_bytes", 2048)
+
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	want := map[string][]float64{
+		"myapp_request_duration":    LatencyBuckets(),
+		"myapp_response_size_bytes": SizeBucketsBytes(),
+	}
+	for _, mf := range metricFamilies {
+		buckets, ok := want[mf.GetName()]
+		if !ok {
+			continue
+		}
+		delete(want, mf.GetName())
+		got := mf.GetMetric()[0].GetHistogram().GetBucket()
+		if len(got) != len(buckets) {
+			t.Fatalf("%s: expected %d buckets, got %d", mf.GetName(), len(buckets), len(got))
+		}
+		for i, b := range got {
+			if b.GetUpperBound() != buckets[i] {
+				t.Errorf("%s: bucket %d = %v, want %v", mf.GetName(), i, b.GetUpperBound(), buckets[i])
+			}
+		}
+	}
+	for name := range want {
+		t.Errorf("histogram %s not found", name)
+	}
+}
+
+func TestDefaultBucketsFor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("myapp", registry,
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+		WithBucketsFor("override_bytes", CountBuckets()),
+	)
+	d, ok := client.(BucketsDefaulter)
+	if !ok {
+		t.Fatal("Prometheus client should implement BucketsDefaulter")
+	}
+
+	ctx := context.Background()
+	client.Histogram(ctx, "created_bytes", 1)
+	d.DefaultBucketsFor("created_bytes", SizeBucketsBytes())
+	d.DefaultBucketsFor("size_bytes", SizeBucketsBytes())
+	d.DefaultBucketsFor("override_bytes", SizeBucketsBytes())
+	client.Histogram(ctx, "size_bytes", 2048)
+	client.Histogram(ctx, "override_bytes", 3)
+
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	want := map[string]int{
+		"myapp_created_bytes":  len(DefaultDurationBuckets()),
+		"myapp_size_bytes":     len(SizeBucketsBytes()),
+		"myapp_override_bytes": len(CountBuckets()),
+	}
+	for _, mf := range metricFamilies {
+		if n, ok := want[mf.GetName()]; ok {
+			if got := len(mf.GetMetric()[0].GetHistogram().GetBucket()); got != n {
+				t.Errorf("%s: %d buckets, want %d", mf.GetName(), got, n)
+			}
+			delete(want, mf.GetName())
+		}
+	}
+	for name := range want {
+		t.Errorf("histogram %s not found", name)
+	}
+}
+
+func TestBucketPresets(t *testing.T) {
+	presets := map[string][]float64{
+		"LatencyBuckets":   LatencyBuckets(),
+		"SizeBucketsBytes": SizeBucketsBytes(),
+		"CountBuckets":     CountBuckets(),
+	}
+	for name, buckets := range presets {
+		if len(buckets) == 0 {
+			t.Errorf("%s() returned no buckets", name)
+		}
+		for i := 1; i < len(buckets); i++ {
+			if buckets[i] <= buckets[i-1] {
+				t.Errorf("%s() buckets not strictly increasing at %d: %v", name, i, buckets)
+			}
+		}
+	}
+}
+
 // ============================================================================
 // Edge Cases
 // ============================================================================
 
 func TestOddTagsHandling(t *testing.T) {
 	registry := prometheus.NewRegistry()
-	client := NewClientWithRegistry("test", registry)
+	var reported []error
+	client := NewClientWithRegistry("test", registry,
+		WithoutGoCollector(), WithoutProcessCollector(),
+		WithLabelErrorHandler(func(err error) { reported = append(reported, err) }),
+	)
 	ctx := context.Background()
 
 	// Odd number of tags should not panic
 	client.Inc(ctx, "odd_tags_counter", "method")
 	client.SetGauge(ctx, "odd_tags_gauge", 1, "key")
 	client.Histogram(ctx, "odd_tags_histogram", 0.5, "endpoint")
+	client.Inc(ctx, "odd_tags_counter", "method", "GET", "status")
+	client.CounterHandle("odd_tags_handle", "key").Inc()
 
-	// Verify metrics were created (with "unknown" as the missing value)
 	metricFamilies, err := registry.Gather()
 	if err != nil {
 		t.Fatalf("failed to gather metrics: %v", err)
 	}
 
-	expectedMetrics := map[string]bool{
-		"test_odd_tags_counter":   false,
-		"test_odd_tags_gauge":     false,
-		"test_odd_tags_histogram": false,
+	errorsByMetric := map[string]float64{}
+	for _, mf := range metricFamilies {
+		switch mf.GetName() {
+		case "test_" + LabelErrorsMetric:
+			for _, m := range mf.GetMetric() {
+				errorsByMetric[m.GetLabel()[0].GetValue()] = m.GetCounter().GetValue()
+			}
+		default:
+			if strings.HasPrefix(mf.GetName(), "test_odd_tags") {
+				t.Errorf("metric %q should not be created from odd tags", mf.GetName())
+			}
+		}
+	}
+
+	want := map[string]float64{
+		"odd_tags_counter":   2,
+		"odd_tags_gauge":     1,
+		"odd_tags_histogram": 1,
+		"odd_tags_handle":    1,
+	}
+	for name, count := range want {
+		if errorsByMetric[name] != count {
+			t.Errorf("%s{metric=%q} = %v, want %v", LabelErrorsMetric, name, errorsByMetric[name], count)
+		}
+	}
+	if len(reported) != 5 || !errors.Is(reported[0], ErrOddLabelCount) {
+		t.Errorf("reported errors = %v, want 5 wrapping ErrOddLabelCount", reported)
 	}
 
+	// Even tags still work afterwards
+	client.Inc(ctx, "odd_tags_counter", "method", "GET")
+	metricFamilies, err = registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var found bool
 	for _, mf := range metricFamilies {
-		if _, ok := expectedMetrics[mf.GetName()]; ok {
-			expectedMetrics[mf.GetName()] = true
+		if mf.GetName() == "test_odd_tags_counter" {
+			found = mf.GetMetric()[0].GetCounter().GetValue() == 1
 		}
 	}
+	if !found {
+		t.Error("expected test_odd_tags_counter = 1 after a valid call")
+	}
+}
 
-	for name, found := range expectedMetrics {
-		if !found {
-			t.Errorf("expected metric %q to be registered", name)
+func TestLabelNormalization(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("test", registry,
+		WithoutGoCollector(), WithoutProcessCollector(), WithLabelNormalization())
+	ctx := context.Background()
+
+	client.Inc(ctx, "requests_total", "HTTP-Method", "GET")
+	client.Inc(ctx, "requests_total", "http_method", "GET")
+	client.SetGauge(ctx, "queue_depth", 3, "1st.queue", "Orders")
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	labels := map[string]string{}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				labels[mf.GetName()+"/"+l.GetName()] = l.GetValue()
+			}
+			if mf.GetName() == "test_requests_total" && m.GetCounter().GetValue() != 2 {
+				t.Errorf("requests_total = %v, want 2 in one series", m.GetCounter().GetValue())
+			}
 		}
 	}
+	if labels["test_requests_total/http_method"] != "GET" {
+		t.Errorf("labels = %v, want http_method=GET", labels)
+	}
+	if labels["test_queue_depth/_1st_queue"] != "Orders" {
+		t.Errorf("labels = %v, want _1st_queue=Orders (value unchanged)", labels)
+	}
 }
 
 func TestNewClientWithRegisterer(t *testing.T) {
@@ -472,6 +728,124 @@ func (m *mockRegisterer) Unregister(prometheus.Collector) bool {
 	return true
 }
 
+func TestRate(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("test", registry, WithRateWindow(time.Second))
+	ctx := context.Background()
+
+	for range 50 {
+		client.Rate(ctx, "requests_per_second", "endpoint", "/users")
+	}
+
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	found := false
+	for _, mf := range metricFamilies {
+		if mf.GetName() == "test_requests_per_second" {
+			found = true
+			for _, m := range mf.GetMetric() {
+				if m.GetGauge().GetValue() <= 0 {
+					t.Errorf("expected nonzero rate, got %v", m.GetGauge().GetValue())
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("gauge metric 'test_requests_per_second' not found")
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	w := newSlidingWindow(10 * time.Second)
+	base := time.Unix(1000, 0)
+
+	// 10 events per second for 20 seconds (two full windows)
+	var last time.Time
+	for sec := range 20 {
+		for i := range 10 {
+			last = base.Add(time.Duration(sec)*time.Second + time.Duration(i)*time.Millisecond)
+			w.add(last)
+		}
+	}
+	if rate := w.rate(last); rate < 9 || rate > 11 {
+		t.Errorf("expected rate ~10/s, got %v", rate)
+	}
+
+	// Without new events the rate decays as buckets leave the window
+	if rate := w.rate(last.Add(5 * time.Second)); rate < 4 || rate > 6 {
+		t.Errorf("expected rate ~5/s half a window later, got %v", rate)
+	}
+	if rate := w.rate(last.Add(11 * time.Second)); rate != 0 {
+		t.Errorf("expected rate 0 a window later, got %v", rate)
+	}
+	if w.idle(last.Add(11*time.Second)) || !w.idle(last.Add(21*time.Second)) {
+		t.Error("expected the window idle only after two windows without events")
+	}
+
+	// A single event long after the window has passed should drop old buckets
+	w.add(base.Add(time.Minute))
+	if rate := w.rate(base.Add(time.Minute)); rate != 0.1 {
+		t.Errorf("expected rate 0.1/s after idle period, got %v", rate)
+	}
+}
+
+func TestRate_Collection(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("test", registry, WithRateWindow(50*time.Millisecond),
+		WithoutGoCollector(), WithoutProcessCollector())
+	ctx := context.Background()
+	gather := func() map[string]float64 {
+		t.Helper()
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Gather() error = %v", err)
+		}
+		rates := make(map[string]float64)
+		for _, mf := range families {
+			if mf.GetName() != "test_requests_per_second" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				rates[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+			}
+		}
+		return rates
+	}
+
+	for range 10 {
+		client.Rate(ctx, "requests_per_second", "endpoint", "/users")
+		client.Rate(ctx, "requests_per_second", "endpoint", "/orders")
+	}
+	if rates := gather(); rates["/users"] <= 0 || rates["/orders"] <= 0 {
+		t.Fatalf("rates = %v, want both series nonzero", rates)
+	}
+
+	// DeleteLabelValues drops the series and its window
+	if !client.DeleteLabelValues("requests_per_second", map[string]string{"endpoint": "/orders"}) {
+		t.Error("DeleteLabelValues() = false, want true")
+	}
+	if _, ok := gather()["/orders"]; ok {
+		t.Error("deleted series still collected")
+	}
+
+	// The rate decays to 0 once a window passes without events, then the
+	// series is evicted
+	time.Sleep(60 * time.Millisecond)
+	if rates := gather(); rates["/users"] != 0 {
+		t.Errorf("rates = %v, want /users decayed to 0", rates)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if rates := gather(); len(rates) != 0 {
+		t.Errorf("rates = %v, want idle series evicted", rates)
+	}
+	client.Rate(ctx, "requests_per_second", "endpoint", "/users")
+	if rates := gather(); rates["/users"] <= 0 {
+		t.Errorf("rates = %v, want /users back after a new event", rates)
+	}
+}
+
 func TestClose(t *testing.T) {
 	client := NewClient("test")
 	if err := client.Close(); err != nil {
@@ -499,6 +873,28 @@ func BenchmarkPrometheusInc(b *testing.B) {
 	})
 }
 
+func BenchmarkPrometheusHistogram(b *testing.B) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("bench", registry)
+	ctx := context.Background()
+
+	b.Run("per-call", func(b *testing.B) {
+		client.Histogram(ctx, "batch_size", 1, "job", "import", "source", "s3")
+		b.ResetTimer()
+		for i := range b.N {
+			client.Histogram(ctx, "batch_size", float64(i), "job", "import", "source", "s3")
+		}
+	})
+
+	b.Run("handle", func(b *testing.B) {
+		h := client.HistogramHandle("batch_size", "job", "import", "source", "s3")
+		b.ResetTimer()
+		for i := range b.N {
+			h.Observe(float64(i))
+		}
+	})
+}
+
 func BenchmarkPrometheusDuration(b *testing.B) {
 	registry := prometheus.NewRegistry()
 	client := NewClientWithRegistry("bench", registry)
@@ -515,3 +911,459 @@ func BenchmarkPrometheusDuration(b *testing.B) {
 		}
 	})
 }
+
+func TestDeleteLabelValues(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("test", registry)
+	ctx := context.Background()
+
+	client.SetGauge(ctx, "connection_bytes", 512, "conn_id", "c-1")
+	client.SetGauge(ctx, "connection_bytes", 256, "conn_id", "c-2")
+	client.Inc(ctx, "connection_errors", "conn_id", "c-1")
+
+	if !client.DeleteLabelValues("connection_bytes", map[string]string{"conn_id": "c-1"}) {
+		t.Error("expected gauge series to be deleted")
+	}
+	if !client.DeleteLabelValues("connection_errors", map[string]string{"conn_id": "c-1"}) {
+		t.Error("expected counter series to be deleted")
+	}
+	if client.DeleteLabelValues("connection_bytes", map[string]string{"conn_id": "c-1"}) {
+		t.Error("expected second delete to report false")
+	}
+	if client.DeleteLabelValues("unknown_metric", map[string]string{"conn_id": "c-1"}) {
+		t.Error("expected delete of unknown metric to report false")
+	}
+
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var remaining []string
+	for _, mf := range metricFamilies {
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if lp.GetName() == "conn_id" {
+					remaining = append(remaining, mf.GetName()+"/"+lp.GetValue())
+				}
+			}
+		}
+	}
+	if len(remaining) != 1 || remaining[0] != "test_connection_bytes/c-2" {
+		t.Errorf("remaining series = %v, want [test_connection_bytes/c-2]", remaining)
+	}
+
+	if NewNoopClient().DeleteLabelValues("connection_bytes", map[string]string{"conn_id": "c-2"}) {
+		t.Error("noop client should report false")
+	}
+}
+
+func TestMetricHandles(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("test", registry,
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+		WithBucketsFor("batch_size", CountBuckets()),
+	)
+	ctx := context.Background()
+
+	counter := client.CounterHandle("items_total", "job", "import")
+	counter.Inc()
+	counter.Add(4)
+	// Per-call updates with the same labels hit the same series
+	client.Inc(ctx, "items_total", "job", "import")
+	client.CounterHandle("items_total", "job", "export").Inc()
+
+	gauge := client.GaugeHandle("in_flight", "pool", "images")
+	gauge.Set(10)
+	gauge.Inc()
+	gauge.Dec()
+	gauge.Dec()
+
+	histogram := client.HistogramHandle("batch_size", "job", "import")
+	histogram.Observe(3)
+	histogram.Observe(30)
+	client.HistogramHandle("step_duration", "step", "resize").Duration(time.Now())
+
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	found := map[string]bool{}
+	for _, mf := range metricFamilies {
+		found[mf.GetName()] = true
+		switch mf.GetName() {
+		case "test_items_total":
+			got := map[string]float64{}
+			for _, m := range mf.GetMetric() {
+				got[m.GetLabel()[0].GetValue()] = m.GetCounter().GetValue()
+			}
+			if got["import"] != 6 || got["export"] != 1 {
+				t.Errorf("items_total = %v, want import=6 export=1", got)
+			}
+		case "test_in_flight":
+			if v := mf.GetMetric()[0].GetGauge().GetValue(); v != 9 {
+				t.Errorf("in_flight = %v, want 9", v)
+			}
+		case "test_batch_size":
+			h := mf.GetMetric()[0].GetHistogram()
+			if h.GetSampleCount() != 2 || h.GetSampleSum() != 33 {
+				t.Errorf("batch_size count=%d sum=%v, want count=2 sum=33", h.GetSampleCount(), h.GetSampleSum())
+			}
+			if len(h.GetBucket()) != len(CountBuckets()) {
+				t.Errorf("batch_size has %d buckets, want %d", len(h.GetBucket()), len(CountBuckets()))
+			}
+		case "test_step_duration":
+			if c := mf.GetMetric()[0].GetHistogram().GetSampleCount(); c != 1 {
+				t.Errorf("step_duration count = %d, want 1", c)
+			}
+		}
+	}
+	for _, name := range []string{"test_items_total", "test_in_flight", "test_batch_size", "test_step_duration"} {
+		if !found[name] {
+			t.Errorf("metric %s not found", name)
+		}
+	}
+}
+
+func TestSnapshotJSON(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("test", registry,
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+	)
+	ctx := context.Background()
+
+	client.Inc(ctx, "orders_total", "status", "paid")
+	client.Add(ctx, "orders_total", 2, "status", "paid")
+	client.Histogram(ctx, "batch_size", 0.2)
+
+	data, err := client.SnapshotJSON()
+	if err != nil {
+		t.Fatalf("SnapshotJSON() error = %v", err)
+	}
+
+	var families []metricSnapshot
+	if err := json.Unmarshal(data, &families); err != nil {
+		t.Fatalf("snapshot is not valid JSON: %v\n%s", err, data)
+	}
+	byName := make(map[string]metricSnapshot, len(families))
+	for _, f := range families {
+		byName[f.Name] = f
+	}
+
+	orders, ok := byName["test_orders_total"]
+	if !ok {
+		t.Fatalf("test_orders_total missing from snapshot:\n%s", data)
+	}
+	if orders.Type != "counter" || len(orders.Samples) != 1 {
+		t.Fatalf("test_orders_total = %+v, want one counter sample", orders)
+	}
+	sample := orders.Samples[0]
+	if sample.Labels["status"] != "paid" || sample.Value == nil || *sample.Value != 3 {
+		t.Errorf("counter sample = %+v, want status=paid value=3", sample)
+	}
+
+	batch := byName["test_batch_size"]
+	if batch.Type != "histogram" || len(batch.Samples) != 1 {
+		t.Fatalf("test_batch_size = %+v, want one histogram sample", batch)
+	}
+	if h := batch.Samples[0]; h.Count == nil || *h.Count != 1 || h.Buckets["0.25"] != 1 || h.Buckets["0.1"] != 0 {
+		t.Errorf("histogram sample = %+v, want one observation in the 0.25 bucket", h)
+	}
+
+	if data, err := NewNoopClient().SnapshotJSON(); err != nil || string(data) != "[]" {
+		t.Errorf("noop SnapshotJSON() = %q, %v; want [] and nil", data, err)
+	}
+}
+
+func TestWithCreatedTimestamps(t *testing.T) {
+	scrape := func(client Client, accept string) string {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		client.Handler().ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+	const openMetrics = "application/openmetrics-text; version=1.0.0"
+	ctx := context.Background()
+
+	enabled := NewClientWithRegistry("test", prometheus.NewRegistry(),
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+		WithCreatedTimestamps(),
+	)
+	enabled.Inc(ctx, "jobs_total", "queue", "email")
+	enabled.Histogram(ctx, "job_duration", 0.3)
+
+	body := scrape(enabled, openMetrics)
+	for _, want := range []string{`test_jobs_created{queue="email"}`, "test_job_duration_created"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("OpenMetrics scrape missing %q:\n%s", want, body)
+		}
+	}
+	if body := scrape(enabled, "text/plain"); strings.Contains(body, "_created") {
+		t.Errorf("text format scrape should not contain _created lines:\n%s", body)
+	}
+
+	disabled := NewClientWithRegistry("test", prometheus.NewRegistry(),
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+	)
+	disabled.Inc(ctx, "jobs_total", "queue", "email")
+	if body := scrape(disabled, openMetrics); strings.Contains(body, "_created") {
+		t.Errorf("_created lines should be off by default:\n%s", body)
+	}
+}
+
+func TestWithExemplars(t *testing.T) {
+	scrape := func(client Client, accept string) string {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		client.Handler().ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+	const openMetrics = "application/openmetrics-text; version=1.0.0"
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	wantExemplar := `test_orders_failed_total{reason="payment"} 2.0 # {trace_id="` + traceID.String() + `"} 2.0`
+
+	enabled := NewClientWithRegistry("test", prometheus.NewRegistry(),
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+		WithExemplars(),
+	)
+	enabled.Add(spanCtx, "orders_failed_total", 2, "reason", "payment")
+	enabled.Inc(context.Background(), "orders_total")
+
+	body := scrape(enabled, openMetrics)
+	if !strings.Contains(body, wantExemplar) {
+		t.Errorf("OpenMetrics scrape missing exemplar %q:\n%s", wantExemplar, body)
+	}
+	if !strings.Contains(body, "test_orders_total 1.0\n") {
+		t.Errorf("increment without a span should have no exemplar:\n%s", body)
+	}
+	if body := scrape(enabled, "text/plain"); strings.Contains(body, "trace_id") {
+		t.Errorf("text format scrape should not contain exemplars:\n%s", body)
+	}
+
+	disabled := NewClientWithRegistry("test", prometheus.NewRegistry(),
+		WithoutGoCollector(),
+		WithoutProcessCollector(),
+	)
+	disabled.Inc(spanCtx, "orders_failed_total", "reason", "payment")
+	if body := scrape(disabled, openMetrics); strings.Contains(body, "trace_id") {
+		t.Errorf("exemplars should be off by default:\n%s", body)
+	}
+}
+
+func TestRecordingClient(t *testing.T) {
+	client := NewRecordingClient()
+	ctx := context.Background()
+	paid := map[string]string{"status": "paid"}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Inc(ctx, "orders_total", "status", "paid")
+		}()
+	}
+	wg.Wait()
+	client.Add(ctx, "orders_total", 5, "status", "paid")
+	client.CounterHandle("orders_total", "status", "paid").Add(2)
+	client.Inc(ctx, "orders_total", "status", "failed")
+
+	client.SetGauge(ctx, "queue_depth", 7)
+	client.GaugeInc(ctx, "queue_depth")
+	client.GaugeHandle("queue_depth").Dec()
+	client.GaugeDec(ctx, "queue_depth")
+
+	client.Histogram(ctx, "batch_size", 3, "job", "import")
+	client.HistogramHandle("batch_size", "job", "import").Observe(8)
+	client.Duration(ctx, "request_duration", time.Now().Add(-time.Second))
+	client.Rate(ctx, "requests_per_second")
+
+	if got := client.Counter("orders_total", paid); got != 17 {
+		t.Errorf("Counter(paid) = %v, want 17", got)
+	}
+	if got := client.Counter("orders_total", map[string]string{"status": "failed"}); got != 1 {
+		t.Errorf("Counter(failed) = %v, want 1", got)
+	}
+	if got := client.Counter("orders_total", nil); got != 0 {
+		t.Errorf("Counter(unlabeled) = %v, want 0", got)
+	}
+	if got := client.Gauge("queue_depth", nil); got != 6 {
+		t.Errorf("Gauge() = %v, want 6", got)
+	}
+	obs := client.Observations("batch_size", map[string]string{"job": "import"})
+	if len(obs) != 2 || obs[0] != 3 || obs[1] != 8 {
+		t.Errorf("Observations() = %v, want [3 8]", obs)
+	}
+	if d := client.Observations("request_duration", nil); len(d) != 1 || d[0] < 1 {
+		t.Errorf("Observations(duration) = %v, want one value >= 1s", d)
+	}
+
+	calls := client.Calls()
+	if len(calls) != 21 {
+		t.Fatalf("len(Calls()) = %d, want 21", len(calls))
+	}
+	last := calls[len(calls)-1]
+	if last.Op != OpRate || last.Name != "requests_per_second" || last.Value != 1 {
+		t.Errorf("last call = %+v, want rate of requests_per_second", last)
+	}
+	calls[0].Labels["status"] = "mutated"
+	if got := client.Counter("orders_total", paid); got != 17 {
+		t.Error("Calls() should return copies of the label maps")
+	}
+
+	if !client.DeleteLabelValues("orders_total", paid) {
+		t.Error("DeleteLabelValues() = false, want true")
+	}
+	if got := client.Counter("orders_total", paid); got != 0 {
+		t.Errorf("Counter() after delete = %v, want 0", got)
+	}
+
+	client.Reset()
+	if len(client.Calls()) != 0 {
+		t.Error("Reset() should discard every call")
+	}
+}
+
+func TestRecordingClient_OddTags(t *testing.T) {
+	client := NewRecordingClient()
+	ctx := context.Background()
+
+	// A trailing key without a value is dropped and counted, not recorded
+	client.Inc(ctx, "orders_total", "method")
+	client.Add(ctx, "orders_total", 3, "status", "paid", "region")
+	client.SetGauge(ctx, "queue_depth", 7, "queue")
+	client.Histogram(ctx, "batch_size", 3, "job")
+	client.Rate(ctx, "requests_per_second", "route")
+	client.GaugeFunc("cache_size", func() float64 { return 1 }, "cache")
+	client.CounterHandle("orders_total", "method").Inc()
+	client.GaugeHandle("queue_depth", "queue").Set(1)
+	client.HistogramHandle("batch_size", "job").Observe(1)
+
+	for _, call := range client.Calls() {
+		if call.Name != LabelErrorsMetric {
+			t.Errorf("recorded %+v, want odd-tag calls dropped", call)
+		}
+	}
+	for name, want := range map[string]float64{
+		"orders_total": 3, "queue_depth": 2, "batch_size": 2, "requests_per_second": 1, "cache_size": 1,
+	} {
+		if got := client.Counter(LabelErrorsMetric, map[string]string{"metric": name}); got != want {
+			t.Errorf("%s{metric=%q} = %v, want %v", LabelErrorsMetric, name, got, want)
+		}
+	}
+	if got := client.Gauge("cache_size", nil); got != 0 {
+		t.Errorf("Gauge(cache_size) = %v, want 0: the callback was not kept", got)
+	}
+}
+
+func TestWithScrapeMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("myapp", registry, WithScrapeMetrics(), WithoutGoCollector(), WithoutProcessCollector())
+	handler := client.Handler()
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	// A scrape is recorded once served, so the first one sees none
+	if body := scrape(); !strings.Contains(body, ScrapesTotalMetric+" 0") {
+		t.Errorf("first scrape: want %s 0, got:\n%s", ScrapesTotalMetric, body)
+	}
+	body := scrape()
+	for _, want := range []string{ScrapesTotalMetric + " 1", ScrapeDurationMetric + "_count 1"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("second scrape: want %q, got:\n%s", want, body)
+		}
+	}
+
+	// Another handler of the same client shares the metrics
+	client.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if body := scrape(); !strings.Contains(body, ScrapesTotalMetric+" 3") {
+		t.Errorf("want %s 3 after a scrape through a second handler, got:\n%s", ScrapesTotalMetric, body)
+	}
+
+	rec := httptest.NewRecorder()
+	NewClient("plain", WithoutGoCollector(), WithoutProcessCollector()).Handler().
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if strings.Contains(rec.Body.String(), ScrapesTotalMetric) {
+		t.Errorf("without WithScrapeMetrics: want no %s", ScrapesTotalMetric)
+	}
+}
+
+func TestWithScrapeMetrics_ConflictingCollector(t *testing.T) {
+	for name, existing := range map[string]prometheus.Collector{
+		// Same descriptor, so Register reports it as already registered
+		"same descriptor": prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: ScrapesTotalMetric, Help: "Scrapes served by the metrics handler.",
+		}, nil),
+		"other labels": prometheus.NewCounterVec(prometheus.CounterOpts{Name: ScrapesTotalMetric, Help: "user"}, []string{"path"}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(existing)
+			client := NewClientWithRegistry("myapp", registry, WithScrapeMetrics(), WithoutGoCollector(), WithoutProcessCollector())
+			client.Inc(context.Background(), "requests_total")
+
+			// The handler serves without the scrape metrics instead of panicking
+			rec := httptest.NewRecorder()
+			client.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "myapp_requests_total 1") {
+				t.Errorf("status = %d, body:\n%s\nwant the client's metrics", rec.Code, rec.Body.String())
+			}
+			if strings.Contains(rec.Body.String(), ScrapeDurationMetric) {
+				t.Errorf("want no %s next to a conflicting %s", ScrapeDurationMetric, ScrapesTotalMetric)
+			}
+		})
+	}
+}
+
+func TestGaugeFunc(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := NewClientWithRegistry("myapp", registry, WithConstLabels(map[string]string{"env": "test"}),
+		WithoutGoCollector(), WithoutProcessCollector())
+	handler := client.Handler()
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	var depth atomic.Int64
+	depth.Store(3)
+	client.GaugeFunc("queue_depth", func() float64 { return float64(depth.Load()) }, "queue", "emails")
+	client.GaugeFunc("queue_depth", func() float64 { return 9 }, "queue", "sms")
+
+	if body := scrape(); !strings.Contains(body, `myapp_queue_depth{env="test",queue="emails"} 3`) ||
+		!strings.Contains(body, `myapp_queue_depth{env="test",queue="sms"} 9`) {
+		t.Errorf("want both queue_depth series, got:\n%s", body)
+	}
+	depth.Store(42)
+	if body := scrape(); !strings.Contains(body, `myapp_queue_depth{env="test",queue="emails"} 42`) {
+		t.Errorf("want the callback's current value 42, got:\n%s", body)
+	}
+
+	// Registering the series again replaces its callback
+	client.GaugeFunc("queue_depth", func() float64 { return 1 }, "queue", "emails")
+	if body := scrape(); !strings.Contains(body, `myapp_queue_depth{env="test",queue="emails"} 1`) {
+		t.Errorf("want the replaced callback's value 1, got:\n%s", body)
+	}
+
+	rec := NewRecordingClient()
+	rec.GaugeFunc("cache_size", func() float64 { return float64(depth.Load()) }, "cache", "users")
+	if got := rec.Gauge("cache_size", map[string]string{"cache": "users"}); got != 42 {
+		t.Errorf("RecordingClient.Gauge() = %v, want the callback's 42", got)
+	}
+	NewNoopClient().GaugeFunc("cache_size", func() float64 { return 1 })
+}
@@ -15,6 +15,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	metrics "github.com/anthanhphan/gosdk/metrics"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -73,6 +74,39 @@ func (mr *MockClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockClient)(nil).Close))
 }
 
+// CounterHandle mocks base method.
+func (m *MockClient) CounterHandle(name string, tags ...string) metrics.CounterHandle {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CounterHandle", varargs...)
+	ret0, _ := ret[0].(metrics.CounterHandle)
+	return ret0
+}
+
+// CounterHandle indicates an expected call of CounterHandle.
+func (mr *MockClientMockRecorder) CounterHandle(name any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CounterHandle", reflect.TypeOf((*MockClient)(nil).CounterHandle), varargs...)
+}
+
+// DeleteLabelValues mocks base method.
+func (m *MockClient) DeleteLabelValues(name string, labels map[string]string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabelValues", name, labels)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DeleteLabelValues indicates an expected call of DeleteLabelValues.
+func (mr *MockClientMockRecorder) DeleteLabelValues(name, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabelValues", reflect.TypeOf((*MockClient)(nil).DeleteLabelValues), name, labels)
+}
+
 // Duration mocks base method.
 func (m *MockClient) Duration(ctx context.Context, name string, start time.Time, tags ...string) {
 	m.ctrl.T.Helper()
@@ -107,6 +141,42 @@ func (mr *MockClientMockRecorder) GaugeDec(ctx, name any, tags ...any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GaugeDec", reflect.TypeOf((*MockClient)(nil).GaugeDec), varargs...)
 }
 
+// GaugeFunc mocks base method.
+func (m *MockClient) GaugeFunc(name string, fn func() float64, tags ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{name, fn}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "GaugeFunc", varargs...)
+}
+
+// GaugeFunc indicates an expected call of GaugeFunc.
+func (mr *MockClientMockRecorder) GaugeFunc(name, fn any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name, fn}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GaugeFunc", reflect.TypeOf((*MockClient)(nil).GaugeFunc), varargs...)
+}
+
+// GaugeHandle mocks base method.
+func (m *MockClient) GaugeHandle(name string, tags ...string) metrics.GaugeHandle {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GaugeHandle", varargs...)
+	ret0, _ := ret[0].(metrics.GaugeHandle)
+	return ret0
+}
+
+// GaugeHandle indicates an expected call of GaugeHandle.
+func (mr *MockClientMockRecorder) GaugeHandle(name any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GaugeHandle", reflect.TypeOf((*MockClient)(nil).GaugeHandle), varargs...)
+}
+
 // GaugeInc mocks base method.
 func (m *MockClient) GaugeInc(ctx context.Context, name string, tags ...string) {
 	m.ctrl.T.Helper()
@@ -155,6 +225,25 @@ func (mr *MockClientMockRecorder) Histogram(ctx, name, value any, tags ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Histogram", reflect.TypeOf((*MockClient)(nil).Histogram), varargs...)
 }
 
+// HistogramHandle mocks base method.
+func (m *MockClient) HistogramHandle(name string, tags ...string) metrics.HistogramHandle {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HistogramHandle", varargs...)
+	ret0, _ := ret[0].(metrics.HistogramHandle)
+	return ret0
+}
+
+// HistogramHandle indicates an expected call of HistogramHandle.
+func (mr *MockClientMockRecorder) HistogramHandle(name any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistogramHandle", reflect.TypeOf((*MockClient)(nil).HistogramHandle), varargs...)
+}
+
 // Inc mocks base method.
 func (m *MockClient) Inc(ctx context.Context, name string, tags ...string) {
 	m.ctrl.T.Helper()
@@ -172,6 +261,40 @@ func (mr *MockClientMockRecorder) Inc(ctx, name any, tags ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockClient)(nil).Inc), varargs...)
 }
 
+// Latency mocks base method.
+func (m *MockClient) Latency(ctx context.Context, name string, value float64, tags ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name, value}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Latency", varargs...)
+}
+
+// Latency indicates an expected call of Latency.
+func (mr *MockClientMockRecorder) Latency(ctx, name, value any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name, value}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latency", reflect.TypeOf((*MockClient)(nil).Latency), varargs...)
+}
+
+// Rate mocks base method.
+func (m *MockClient) Rate(ctx context.Context, name string, tags ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Rate", varargs...)
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockClientMockRecorder) Rate(ctx, name any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockClient)(nil).Rate), varargs...)
+}
+
 // SetGauge mocks base method.
 func (m *MockClient) SetGauge(ctx context.Context, name string, value float64, tags ...string) {
 	m.ctrl.T.Helper()
@@ -188,3 +311,174 @@ func (mr *MockClientMockRecorder) SetGauge(ctx, name, value any, tags ...any) *g
 	varargs := append([]any{ctx, name, value}, tags...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockClient)(nil).SetGauge), varargs...)
 }
+
+// SnapshotJSON mocks base method.
+func (m *MockClient) SnapshotJSON() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotJSON")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotJSON indicates an expected call of SnapshotJSON.
+func (mr *MockClientMockRecorder) SnapshotJSON() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotJSON", reflect.TypeOf((*MockClient)(nil).SnapshotJSON))
+}
+
+// MockCounterHandle is a mock of CounterHandle interface.
+type MockCounterHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterHandleMockRecorder
+	isgomock struct{}
+}
+
+// MockCounterHandleMockRecorder is the mock recorder for MockCounterHandle.
+type MockCounterHandleMockRecorder struct {
+	mock *MockCounterHandle
+}
+
+// NewMockCounterHandle creates a new mock instance.
+func NewMockCounterHandle(ctrl *gomock.Controller) *MockCounterHandle {
+	mock := &MockCounterHandle{ctrl: ctrl}
+	mock.recorder = &MockCounterHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterHandle) EXPECT() *MockCounterHandleMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCounterHandle) Add(value int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", value)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCounterHandleMockRecorder) Add(value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCounterHandle)(nil).Add), value)
+}
+
+// Inc mocks base method.
+func (m *MockCounterHandle) Inc() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Inc")
+}
+
+// Inc indicates an expected call of Inc.
+func (mr *MockCounterHandleMockRecorder) Inc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockCounterHandle)(nil).Inc))
+}
+
+// MockGaugeHandle is a mock of GaugeHandle interface.
+type MockGaugeHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockGaugeHandleMockRecorder
+	isgomock struct{}
+}
+
+// MockGaugeHandleMockRecorder is the mock recorder for MockGaugeHandle.
+type MockGaugeHandleMockRecorder struct {
+	mock *MockGaugeHandle
+}
+
+// NewMockGaugeHandle creates a new mock instance.
+func NewMockGaugeHandle(ctrl *gomock.Controller) *MockGaugeHandle {
+	mock := &MockGaugeHandle{ctrl: ctrl}
+	mock.recorder = &MockGaugeHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGaugeHandle) EXPECT() *MockGaugeHandleMockRecorder {
+	return m.recorder
+}
+
+// Dec mocks base method.
+func (m *MockGaugeHandle) Dec() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Dec")
+}
+
+// Dec indicates an expected call of Dec.
+func (mr *MockGaugeHandleMockRecorder) Dec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dec", reflect.TypeOf((*MockGaugeHandle)(nil).Dec))
+}
+
+// Inc mocks base method.
+func (m *MockGaugeHandle) Inc() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Inc")
+}
+
+// Inc indicates an expected call of Inc.
+func (mr *MockGaugeHandleMockRecorder) Inc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockGaugeHandle)(nil).Inc))
+}
+
+// Set mocks base method.
+func (m *MockGaugeHandle) Set(value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", value)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockGaugeHandleMockRecorder) Set(value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGaugeHandle)(nil).Set), value)
+}
+
+// MockHistogramHandle is a mock of HistogramHandle interface.
+type MockHistogramHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistogramHandleMockRecorder
+	isgomock struct{}
+}
+
+// MockHistogramHandleMockRecorder is the mock recorder for MockHistogramHandle.
+type MockHistogramHandleMockRecorder struct {
+	mock *MockHistogramHandle
+}
+
+// NewMockHistogramHandle creates a new mock instance.
+func NewMockHistogramHandle(ctrl *gomock.Controller) *MockHistogramHandle {
+	mock := &MockHistogramHandle{ctrl: ctrl}
+	mock.recorder = &MockHistogramHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistogramHandle) EXPECT() *MockHistogramHandleMockRecorder {
+	return m.recorder
+}
+
+// Duration mocks base method.
+func (m *MockHistogramHandle) Duration(start time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Duration", start)
+}
+
+// Duration indicates an expected call of Duration.
+func (mr *MockHistogramHandleMockRecorder) Duration(start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duration", reflect.TypeOf((*MockHistogramHandle)(nil).Duration), start)
+}
+
+// Observe mocks base method.
+func (m *MockHistogramHandle) Observe(value float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Observe", value)
+}
+
+// Observe indicates an expected call of Observe.
+func (mr *MockHistogramHandleMockRecorder) Observe(value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Observe", reflect.TypeOf((*MockHistogramHandle)(nil).Observe), value)
+}
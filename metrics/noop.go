@@ -46,8 +46,16 @@ func (*noopClient) Add(_ context.Context, _ string, _ int64, _ ...string)
 func (*noopClient) SetGauge(_ context.Context, _ string, _ float64, _ ...string)   {}
 func (*noopClient) GaugeInc(_ context.Context, _ string, _ ...string)              {}
 func (*noopClient) GaugeDec(_ context.Context, _ string, _ ...string)              {}
+func (*noopClient) GaugeFunc(_ string, _ func() float64, _ ...string)              {}
 func (*noopClient) Histogram(_ context.Context, _ string, _ float64, _ ...string)  {}
 func (*noopClient) Duration(_ context.Context, _ string, _ time.Time, _ ...string) {}
+func (*noopClient) Latency(_ context.Context, _ string, _ float64, _ ...string)    {}
+func (*noopClient) Rate(_ context.Context, _ string, _ ...string)                  {}
+func (*noopClient) DeleteLabelValues(_ string, _ map[string]string) bool           { return false }
+func (*noopClient) CounterHandle(_ string, _ ...string) CounterHandle              { return noopHandle{} }
+func (*noopClient) GaugeHandle(_ string, _ ...string) GaugeHandle                  { return noopHandle{} }
+func (*noopClient) HistogramHandle(_ string, _ ...string) HistogramHandle          { return noopHandle{} }
+func (*noopClient) SnapshotJSON() ([]byte, error)                                  { return []byte("[]"), nil }
 func (*noopClient) Close() error                                                   { return nil }
 
 // Handler returns a handler that responds with 200 OK and an empty body.
@@ -56,3 +64,13 @@ func (*noopClient) Handler() http.Handler {
 		w.WriteHeader(http.StatusOK)
 	})
 }
+
+// noopHandle discards updates; it implements every handle interface.
+type noopHandle struct{}
+
+func (noopHandle) Inc()                 {}
+func (noopHandle) Add(_ int64)          {}
+func (noopHandle) Set(_ float64)        {}
+func (noopHandle) Dec()                 {}
+func (noopHandle) Observe(_ float64)    {}
+func (noopHandle) Duration(_ time.Time) {}
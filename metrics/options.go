@@ -2,7 +2,11 @@
 
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 // ============================================================================
 // Client Options (Functional Options Pattern)
@@ -17,6 +21,9 @@ type clientOptions struct {
 	// buckets overrides the default histogram buckets
 	buckets []float64
 
+	// bucketsFor overrides buckets for individual histograms, keyed by metric name
+	bucketsFor map[string][]float64
+
 	// constLabels are labels that are applied to every metric
 	constLabels prometheus.Labels
 
@@ -28,6 +35,30 @@ type clientOptions struct {
 
 	// enableProcessCollector enables the process collector (default: true)
 	enableProcessCollector bool
+
+	// rateWindow is the sliding window used by Rate (default: DefaultRateWindow)
+	rateWindow time.Duration
+
+	// createdTimestamps adds OpenMetrics _created lines to Handler output (default: false)
+	createdTimestamps bool
+
+	// registry is a shared registry NewClient uses instead of creating its own
+	registry *prometheus.Registry
+
+	// dualLatency makes Latency also record a summary (default: false)
+	dualLatency bool
+
+	// normalizeLabels lowercases label keys and replaces invalid characters (default: false)
+	normalizeLabels bool
+
+	// onLabelError receives malformed label errors (default: nil)
+	onLabelError func(error)
+
+	// exemplars attaches the context's trace ID to counter increments (default: false)
+	exemplars bool
+
+	// scrapeMetrics makes Handler record its own scrape count and duration (default: false)
+	scrapeMetrics bool
 }
 
 // defaultClientOptions returns the default client options.
@@ -36,11 +67,13 @@ func defaultClientOptions() *clientOptions {
 		buckets:                DefaultDurationBuckets(),
 		enableGoCollector:      true,
 		enableProcessCollector: true,
+		rateWindow:             DefaultRateWindow,
 	}
 }
 
 // WithBuckets sets custom histogram buckets for duration and histogram metrics.
-// If not set, DefaultDurationBuckets will be used.
+// If not set, DefaultDurationBuckets will be used. Buckets set for a specific
+// metric with WithBucketsFor take precedence.
 //
 // Example:
 //
@@ -55,6 +88,30 @@ func WithBuckets(buckets []float64) Option {
 	}
 }
 
+// WithBucketsFor sets histogram buckets for the metric `name` only, overriding
+// the client-wide buckets from WithBuckets. name is the metric name passed to
+// Duration/Histogram, without namespace or subsystem. It can be given once per
+// metric; a later call for the same name wins.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithBuckets(metrics.LatencyBuckets()),
+//	    metrics.WithBucketsFor("response_size_bytes", metrics.SizeBucketsBytes()),
+//	    metrics.WithBucketsFor("batch_size", metrics.CountBuckets()),
+//	)
+func WithBucketsFor(name string, buckets []float64) Option {
+	return func(o *clientOptions) {
+		if name == "" || len(buckets) == 0 {
+			return
+		}
+		if o.bucketsFor == nil {
+			o.bucketsFor = make(map[string][]float64)
+		}
+		o.bucketsFor[name] = buckets
+	}
+}
+
 // WithConstLabels sets constant labels that are applied to every metric.
 // These labels cannot be changed after creation. Use for service-level identifiers
 // like environment, region, or pod name.
@@ -103,3 +160,158 @@ func WithoutProcessCollector() Option {
 		o.enableProcessCollector = false
 	}
 }
+
+// WithRateWindow sets the sliding window over which Rate computes events per second.
+// A longer window gives a smoother but slower-reacting gauge.
+// If not set (or non-positive), DefaultRateWindow (10s) is used.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithRateWindow(30*time.Second),
+//	)
+func WithRateWindow(window time.Duration) Option {
+	return func(o *clientOptions) {
+		if window > 0 {
+			o.rateWindow = window
+		}
+	}
+}
+
+// WithRegistry makes NewClient register its metrics into an existing registry,
+// e.g., one another library already populates, instead of creating its own.
+// Handler then serves every metric in that registry, so one endpoint exposes
+// both sources. Const labels from WithConstLabels still apply only to the
+// collectors this client creates. The Go and process collectors are skipped if
+// the registry already has them. NewClientWithRegistry and
+// NewClientWithRegisterer ignore this option in favor of their argument.
+//
+// Example:
+//
+//	reg := prometheus.NewRegistry()
+//	reg.MustRegister(otherLibCollector)
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithRegistry(reg),
+//	)
+func WithRegistry(registry *prometheus.Registry) Option {
+	return func(o *clientOptions) {
+		o.registry = registry
+	}
+}
+
+// WithCreatedTimestamps makes Handler emit an OpenMetrics `_created` line
+// (the time the series was created) for every counter, histogram and summary
+// series. The lines only appear when the scraper negotiates OpenMetrics via
+// its Accept header, as Prometheus does by default; the classic text format
+// is unchanged. Off by default so existing scrapes see no new series.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithCreatedTimestamps(),
+//	)
+func WithCreatedTimestamps() Option {
+	return func(o *clientOptions) {
+		o.createdTimestamps = true
+	}
+}
+
+// WithDualLatency makes Client.Latency record every observation twice: in the
+// histogram name and in a summary named name+"_summary" with
+// DefaultSummaryObjectives quantiles. Use it while migrating dashboards from
+// client-side quantiles to histograms, then remove it; Latency keeps
+// recording the histogram.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp", metrics.WithDualLatency())
+//	client.Latency(ctx, "request_duration_seconds", 0.042, "route", "/users")
+//	// myapp_request_duration_seconds_bucket{route="/users",le="0.05"} 1
+//	// myapp_request_duration_seconds_summary{route="/users",quantile="0.99"} 0.042
+func WithDualLatency() Option {
+	return func(o *clientOptions) {
+		o.dualLatency = true
+	}
+}
+
+// WithLabelNormalization makes the client normalize label keys before use:
+// they are lowercased, characters outside [a-z0-9_] become '_', and a key
+// starting with a digit gets a '_' prefix. "Status-Code" and "status_code"
+// then land in the same series instead of failing or splitting it. Values
+// are left unchanged.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp", metrics.WithLabelNormalization())
+//	client.Inc(ctx, "requests_total", "HTTP-Method", "GET") // label http_method
+func WithLabelNormalization() Option {
+	return func(o *clientOptions) {
+		o.normalizeLabels = true
+	}
+}
+
+// WithLabelErrorHandler sets a function called with every malformed label
+// error (e.g., ErrOddLabelCount), for logging or failing tests. Such calls
+// are always dropped and counted in LabelErrorsMetric; the handler only adds
+// visibility. It may be called concurrently.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp",
+//	    metrics.WithLabelErrorHandler(func(err error) {
+//	        logger.Warnw("bad metric labels", "error", err)
+//	    }),
+//	)
+func WithLabelErrorHandler(fn func(error)) Option {
+	return func(o *clientOptions) {
+		o.onLabelError = fn
+	}
+}
+
+// ExemplarTraceIDLabel is the exemplar label WithExemplars stores the trace ID in.
+const ExemplarTraceIDLabel = "trace_id"
+
+// WithExemplars attaches an exemplar to counter increments (Inc and Add) made
+// with a context that carries an OpenTelemetry span: the span's trace ID under
+// ExemplarTraceIDLabel, so a spike on a dashboard links to a trace. Exemplars
+// only appear when the scraper negotiates OpenMetrics via its Accept header,
+// as Prometheus does with exemplar storage enabled; the classic text format is
+// unchanged. Without a span in the context the increment has no exemplar.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp", metrics.WithExemplars())
+//	client.Inc(ctx, "orders_failed_total", "reason", "payment")
+//	// myapp_orders_failed_total{reason="payment"} 1 # {trace_id="4bf92f35..."} 1 1.7e+09
+func WithExemplars() Option {
+	return func(o *clientOptions) {
+		o.exemplars = true
+	}
+}
+
+// ScrapesTotalMetric and ScrapeDurationMetric are the metrics WithScrapeMetrics
+// records; they are not prefixed with the client namespace.
+const (
+	ScrapesTotalMetric   = "promhttp_scrapes_total"
+	ScrapeDurationMetric = "promhttp_scrape_duration_seconds"
+)
+
+// WithScrapeMetrics makes Handler record how often and how long it is scraped,
+// in ScrapesTotalMetric and ScrapeDurationMetric, so a slow collector that
+// degrades scrapes shows up before scrapes start timing out. The metrics are
+// registered, without the client namespace, when Handler is first called; a
+// scrape is recorded after it is served, so it shows up in the next one. If
+// the registry already holds another collector under either name, Handler
+// serves without them.
+//
+// Example:
+//
+//	client := metrics.NewClient("myapp", metrics.WithScrapeMetrics())
+//	http.Handle("/metrics", client.Handler())
+//	// promhttp_scrapes_total 41
+//	// promhttp_scrape_duration_seconds_bucket{le="0.01"} 39
+func WithScrapeMetrics() Option {
+	return func(o *clientOptions) {
+		o.scrapeMetrics = true
+	}
+}
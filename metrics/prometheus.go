@@ -4,13 +4,21 @@ package metrics
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ============================================================================
@@ -29,6 +37,7 @@ type prometheusClient struct {
 	subsystem   string
 	constLabels prometheus.Labels
 	buckets     []float64
+	bucketsFor  map[string][]float64
 
 	counterMu   sync.RWMutex
 	counters    map[string]*prometheus.CounterVec
@@ -36,10 +45,24 @@ type prometheusClient struct {
 	histograms  map[string]*prometheus.HistogramVec
 	gaugeMu     sync.RWMutex
 	gauges      map[string]*prometheus.GaugeVec
+	summaryMu   sync.RWMutex
+	summaries   map[string]*prometheus.SummaryVec
+	rateWindow  time.Duration
+	rateMu      sync.Mutex
+	rates       map[string]*rateCollector
+
+	createdTimestamps bool
+	dualLatency       bool
+	normalizeLabels   bool
+	onLabelError      func(error)
+	exemplars         bool
+	scrapeMetrics     bool
+	labelErrorsOnce   sync.Once
+	labelErrors       *prometheus.CounterVec
 }
 
-// NewClient creates a new Prometheus metrics client with its own isolated registry.
-// The namespace is used as a prefix for all metric names to avoid naming collisions.
+// NewClient creates a new Prometheus metrics client with its own isolated registry,
+// or with the registry given by WithRegistry. The namespace is used as a prefix for all metric names to avoid naming collisions.
 //
 // Input:
 //   - namespace: Prefix for all metric names (e.g., "myapp" results in "myapp_requests_total")
@@ -65,14 +88,18 @@ func NewClient(namespace string, opts ...Option) Client {
 		opt(options)
 	}
 
-	registry := prometheus.NewRegistry()
+	registry := options.registry
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+	}
 
-	// Register default collectors based on options
+	// Register default collectors based on options. A shared registry may
+	// already have them, which is not an error.
 	if options.enableGoCollector {
-		registry.MustRegister(collectors.NewGoCollector())
+		registerCollector(registry, collectors.NewGoCollector())
 	}
 	if options.enableProcessCollector {
-		registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		registerCollector(registry, collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	}
 
 	return &prometheusClient{
@@ -82,9 +109,31 @@ func NewClient(namespace string, opts ...Option) Client {
 		subsystem:   options.subsystem,
 		constLabels: options.constLabels,
 		buckets:     options.buckets,
+		bucketsFor:  options.bucketsFor,
 		counters:    make(map[string]*prometheus.CounterVec),
 		histograms:  make(map[string]*prometheus.HistogramVec),
 		gauges:      make(map[string]*prometheus.GaugeVec),
+		summaries:   make(map[string]*prometheus.SummaryVec),
+		rateWindow:  options.rateWindow,
+		rates:       make(map[string]*rateCollector),
+
+		createdTimestamps: options.createdTimestamps,
+		dualLatency:       options.dualLatency,
+		normalizeLabels:   options.normalizeLabels,
+		onLabelError:      options.onLabelError,
+		exemplars:         options.exemplars,
+		scrapeMetrics:     options.scrapeMetrics,
+	}
+}
+
+// registerCollector registers c, tolerating a collector that is already
+// registered. Any other registration error panics like MustRegister.
+func registerCollector(registry *prometheus.Registry, c prometheus.Collector) {
+	if err := registry.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			panic(err)
+		}
 	}
 }
 
@@ -136,9 +185,20 @@ func NewClientWithRegisterer(namespace string, registerer prometheus.Registerer,
 		subsystem:   options.subsystem,
 		constLabels: options.constLabels,
 		buckets:     options.buckets,
+		bucketsFor:  options.bucketsFor,
 		counters:    make(map[string]*prometheus.CounterVec),
 		histograms:  make(map[string]*prometheus.HistogramVec),
 		gauges:      make(map[string]*prometheus.GaugeVec),
+		summaries:   make(map[string]*prometheus.SummaryVec),
+		rateWindow:  options.rateWindow,
+		rates:       make(map[string]*rateCollector),
+
+		createdTimestamps: options.createdTimestamps,
+		dualLatency:       options.dualLatency,
+		normalizeLabels:   options.normalizeLabels,
+		onLabelError:      options.onLabelError,
+		exemplars:         options.exemplars,
+		scrapeMetrics:     options.scrapeMetrics,
 	}
 }
 
@@ -178,9 +238,20 @@ func NewClientWithRegistry(namespace string, registry *prometheus.Registry, opts
 		subsystem:   options.subsystem,
 		constLabels: options.constLabels,
 		buckets:     options.buckets,
+		bucketsFor:  options.bucketsFor,
 		counters:    make(map[string]*prometheus.CounterVec),
 		histograms:  make(map[string]*prometheus.HistogramVec),
 		gauges:      make(map[string]*prometheus.GaugeVec),
+		summaries:   make(map[string]*prometheus.SummaryVec),
+		rateWindow:  options.rateWindow,
+		rates:       make(map[string]*rateCollector),
+
+		createdTimestamps: options.createdTimestamps,
+		dualLatency:       options.dualLatency,
+		normalizeLabels:   options.normalizeLabels,
+		onLabelError:      options.onLabelError,
+		exemplars:         options.exemplars,
+		scrapeMetrics:     options.scrapeMetrics,
 	}
 }
 
@@ -192,7 +263,8 @@ func NewClientWithRegistry(namespace string, registry *prometheus.Registry, opts
 // Counters are monotonically increasing values, typically used for request counts.
 //
 // Input:
-//   - ctx: Context for the operation (reserved for future use)
+//   - ctx: Context for the operation; with WithExemplars, its trace ID is
+//     attached to the increment as an exemplar
 //   - name: Name of the counter metric
 //   - tags: Alternating key-value pairs for metric labels
 //
@@ -207,7 +279,8 @@ func (c *prometheusClient) Inc(ctx context.Context, name string, tags ...string)
 // Use this when you need to increment by more than 1.
 //
 // Input:
-//   - ctx: Context for the operation (reserved for future use)
+//   - ctx: Context for the operation; with WithExemplars, its trace ID is
+//     attached to the increment as an exemplar
 //   - name: Name of the counter metric
 //   - value: Amount to add to the counter (must be positive)
 //   - tags: Alternating key-value pairs for metric labels
@@ -215,7 +288,38 @@ func (c *prometheusClient) Inc(ctx context.Context, name string, tags ...string)
 // Example:
 //
 //	client.Add(ctx, "bytes_sent", 1024, "endpoint", "/upload")
-func (c *prometheusClient) Add(_ context.Context, name string, value int64, tags ...string) {
+func (c *prometheusClient) Add(ctx context.Context, name string, value int64, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
+	counter := c.getOrCreateCounter(name, tags)
+	labelValues := extractLabelValues(tags)
+	series := counter.WithLabelValues(labelValues...)
+	if exemplar := c.exemplarLabels(ctx); exemplar != nil {
+		series.(prometheus.ExemplarAdder).AddWithExemplar(float64(value), exemplar)
+		return
+	}
+	series.Add(float64(value))
+}
+
+// exemplarLabels returns the exemplar for an observation made with ctx: the
+// trace ID of its span when WithExemplars is set and ctx carries a valid span
+// context, or nil.
+func (c *prometheusClient) exemplarLabels(ctx context.Context) prometheus.Labels {
+	if !c.exemplars || ctx == nil {
+		return nil
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return nil
+	}
+	return prometheus.Labels{ExemplarTraceIDLabel: sc.TraceID().String()}
+}
+
+// getOrCreateCounter retrieves an existing counter or creates a new one if it doesn't exist.
+// This method is thread-safe and uses double-checked locking for performance.
+func (c *prometheusClient) getOrCreateCounter(name string, tags []string) *prometheus.CounterVec {
 	c.counterMu.RLock()
 	counter, exists := c.counters[name]
 	c.counterMu.RUnlock()
@@ -241,8 +345,7 @@ func (c *prometheusClient) Add(_ context.Context, name string, value int64, tags
 		c.counterMu.Unlock()
 	}
 
-	labelValues := extractLabelValues(tags)
-	counter.WithLabelValues(labelValues...).Add(float64(value))
+	return counter
 }
 
 // ============================================================================
@@ -263,6 +366,10 @@ func (c *prometheusClient) Add(_ context.Context, name string, value int64, tags
 //	client.SetGauge(ctx, "active_connections", 42, "service", "api")
 //	client.SetGauge(ctx, "memory_usage_bytes", 1073741824, "pod", "web-1")
 func (c *prometheusClient) SetGauge(_ context.Context, name string, value float64, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
 	gauge := c.getOrCreateGauge(name, tags)
 	labelValues := extractLabelValues(tags)
 	gauge.WithLabelValues(labelValues...).Set(value)
@@ -281,6 +388,10 @@ func (c *prometheusClient) SetGauge(_ context.Context, name string, value float6
 //	// At request start
 //	client.GaugeInc(ctx, "active_requests", "handler", "GetUser")
 func (c *prometheusClient) GaugeInc(_ context.Context, name string, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
 	gauge := c.getOrCreateGauge(name, tags)
 	labelValues := extractLabelValues(tags)
 	gauge.WithLabelValues(labelValues...).Inc()
@@ -299,11 +410,61 @@ func (c *prometheusClient) GaugeInc(_ context.Context, name string, tags ...stri
 //	// At request end
 //	client.GaugeDec(ctx, "active_requests", "handler", "GetUser")
 func (c *prometheusClient) GaugeDec(_ context.Context, name string, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
 	gauge := c.getOrCreateGauge(name, tags)
 	labelValues := extractLabelValues(tags)
 	gauge.WithLabelValues(labelValues...).Dec()
 }
 
+// GaugeFunc registers a gauge whose value is computed by fn when the metrics
+// are gathered, for values that are cheap to read but would change too often
+// to push with SetGauge, such as a queue depth or a cache size. Registering
+// the same name and tags again replaces fn.
+//
+// fn runs on every scrape (and SnapshotJSON), concurrently with the rest of
+// the program and possibly with itself, so it must be fast, must not block and
+// must be safe for concurrent use. The name cannot also be used with SetGauge,
+// GaugeInc or GaugeDec, and every series of the name must use the same tag keys.
+//
+// Input:
+//   - name: Name of the gauge metric
+//   - fn: Callback returning the current value
+//   - tags: Alternating key-value pairs for metric labels
+//
+// Example:
+//
+//	client.GaugeFunc("queue_depth", func() float64 { return float64(queue.Len()) }, "queue", "emails")
+func (c *prometheusClient) GaugeFunc(name string, fn func() float64, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
+	labels := make(prometheus.Labels, len(c.constLabels)+len(tags)/2)
+	maps.Copy(labels, c.constLabels)
+	for i := 0; i < len(tags); i += 2 {
+		labels[tags[i]] = tags[i+1]
+	}
+	gauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   c.namespace,
+		Subsystem:   c.subsystem,
+		Name:        name,
+		Help:        name,
+		ConstLabels: labels,
+	}, fn)
+
+	if err := c.registerer.Register(gauge); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			panic(err)
+		}
+		c.registerer.Unregister(already.ExistingCollector)
+		c.registerer.MustRegister(gauge)
+	}
+}
+
 // getOrCreateGauge retrieves an existing gauge or creates a new one if it doesn't exist.
 // This method is thread-safe and uses double-checked locking for performance.
 func (c *prometheusClient) getOrCreateGauge(name string, tags []string) *prometheus.GaugeVec {
@@ -352,6 +513,10 @@ func (c *prometheusClient) getOrCreateGauge(name string, tags []string) *prometh
 //	client.Histogram(ctx, "request_size_bytes", 1024, "endpoint", "/upload")
 //	client.Histogram(ctx, "batch_size", 100, "job", "import")
 func (c *prometheusClient) Histogram(_ context.Context, name string, value float64, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
 	histogram := c.getOrCreateHistogram(name, tags)
 	labelValues := extractLabelValues(tags)
 	histogram.WithLabelValues(labelValues...).Observe(value)
@@ -374,11 +539,34 @@ func (c *prometheusClient) Histogram(_ context.Context, name string, value float
 //	client.Duration(ctx, "request_duration_seconds", start, "endpoint", "/users")
 func (c *prometheusClient) Duration(_ context.Context, name string, start time.Time, tags ...string) {
 	elapsed := time.Since(start).Seconds()
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
 	histogram := c.getOrCreateHistogram(name, tags)
 	labelValues := extractLabelValues(tags)
 	histogram.WithLabelValues(labelValues...).Observe(elapsed)
 }
 
+// DefaultBucketsFor implements BucketsDefaulter. The map is copied rather
+// than written in place since it may be shared with the options it came from.
+func (c *prometheusClient) DefaultBucketsFor(name string, buckets []float64) {
+	if name == "" || len(buckets) == 0 {
+		return
+	}
+	c.histogramMu.Lock()
+	defer c.histogramMu.Unlock()
+	if _, ok := c.bucketsFor[name]; ok {
+		return
+	}
+	bucketsFor := maps.Clone(c.bucketsFor)
+	if bucketsFor == nil {
+		bucketsFor = make(map[string][]float64, 1)
+	}
+	bucketsFor[name] = buckets
+	c.bucketsFor = bucketsFor
+}
+
 // getOrCreateHistogram retrieves an existing histogram or creates a new one if it doesn't exist.
 // This method is thread-safe and uses double-checked locking for performance.
 func (c *prometheusClient) getOrCreateHistogram(name string, tags []string) *prometheus.HistogramVec {
@@ -390,7 +578,10 @@ func (c *prometheusClient) getOrCreateHistogram(name string, tags []string) *pro
 		labelNames := extractLabelNames(tags)
 		c.histogramMu.Lock()
 		if histogram, exists = c.histograms[name]; !exists {
-			buckets := c.buckets
+			buckets, ok := c.bucketsFor[name]
+			if !ok {
+				buckets = c.buckets
+			}
 			if len(buckets) == 0 {
 				buckets = DefaultDurationBuckets()
 			}
@@ -414,6 +605,209 @@ func (c *prometheusClient) getOrCreateHistogram(name string, tags []string) *pro
 	return histogram
 }
 
+// Latency records a latency observation in the histogram name. With
+// WithDualLatency it is also recorded in the summary name+"_summary", so
+// dashboards built on client-side quantiles keep working while they move to
+// the histogram.
+//
+// Input:
+//   - ctx: Context for the operation (reserved for future use)
+//   - name: Name of the histogram metric
+//   - value: The observed latency, in seconds by convention
+//   - tags: Alternating key-value pairs for metric labels, shared by both metrics
+//
+// Example:
+//
+//	client.Latency(ctx, "request_duration_seconds", elapsed.Seconds(), "route", "/users")
+func (c *prometheusClient) Latency(_ context.Context, name string, value float64, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
+	labelValues := extractLabelValues(tags)
+	c.getOrCreateHistogram(name, tags).WithLabelValues(labelValues...).Observe(value)
+	if c.dualLatency {
+		summary := c.getOrCreateSummary(name+SummarySuffix, tags)
+		summary.WithLabelValues(labelValues...).Observe(value)
+	}
+}
+
+// getOrCreateSummary retrieves an existing summary or creates a new one with
+// DefaultSummaryObjectives if it doesn't exist. Thread-safe like getOrCreateHistogram.
+func (c *prometheusClient) getOrCreateSummary(name string, tags []string) *prometheus.SummaryVec {
+	c.summaryMu.RLock()
+	summary, exists := c.summaries[name]
+	c.summaryMu.RUnlock()
+
+	if !exists {
+		labelNames := extractLabelNames(tags)
+		c.summaryMu.Lock()
+		if summary, exists = c.summaries[name]; !exists {
+			summary = prometheus.NewSummaryVec(
+				prometheus.SummaryOpts{
+					Namespace:   c.namespace,
+					Subsystem:   c.subsystem,
+					Name:        name,
+					Help:        name,
+					Objectives:  DefaultSummaryObjectives(),
+					ConstLabels: c.constLabels,
+				},
+				labelNames,
+			)
+			c.registerer.MustRegister(summary)
+			c.summaries[name] = summary
+		}
+		c.summaryMu.Unlock()
+	}
+
+	return summary
+}
+
+// ============================================================================
+// Metric Handles
+// ============================================================================
+
+// CounterHandle returns a counter series bound to name and tags.
+// The metric is created on first use exactly like Inc/Add, so a handle and
+// per-call Inc/Add with the same name and tags update the same series.
+//
+// Input:
+//   - name: Name of the counter metric
+//   - tags: Alternating key-value pairs for metric labels, bound to the handle
+//
+// Output:
+//   - CounterHandle: Handle whose Inc/Add skip the metric and label lookup
+//
+// Example:
+//
+//	errors := client.CounterHandle("import_errors_total", "source", "s3")
+//	errors.Inc()
+func (c *prometheusClient) CounterHandle(name string, tags ...string) CounterHandle {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return noopHandle{}
+	}
+	counter := c.getOrCreateCounter(name, tags)
+	return promCounterHandle{counter.WithLabelValues(extractLabelValues(tags)...)}
+}
+
+// GaugeHandle returns a gauge series bound to name and tags.
+// See CounterHandle for how the handle relates to per-call methods.
+//
+// Example:
+//
+//	inFlight := client.GaugeHandle("worker_in_flight", "pool", "images")
+//	inFlight.Inc()
+//	defer inFlight.Dec()
+func (c *prometheusClient) GaugeHandle(name string, tags ...string) GaugeHandle {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return noopHandle{}
+	}
+	gauge := c.getOrCreateGauge(name, tags)
+	return promGaugeHandle{gauge.WithLabelValues(extractLabelValues(tags)...)}
+}
+
+// HistogramHandle returns a histogram series bound to name and tags.
+// Buckets are chosen like Histogram/Duration (WithBucketsFor, then WithBuckets).
+//
+// Example:
+//
+//	latency := client.HistogramHandle("step_duration_seconds", "step", "resize")
+//	start := time.Now()
+//	resize(img)
+//	latency.Duration(start)
+func (c *prometheusClient) HistogramHandle(name string, tags ...string) HistogramHandle {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return noopHandle{}
+	}
+	histogram := c.getOrCreateHistogram(name, tags)
+	return promHistogramHandle{histogram.WithLabelValues(extractLabelValues(tags)...)}
+}
+
+// promCounterHandle adapts a resolved prometheus.Counter to CounterHandle.
+type promCounterHandle struct{ counter prometheus.Counter }
+
+func (h promCounterHandle) Inc()            { h.counter.Inc() }
+func (h promCounterHandle) Add(value int64) { h.counter.Add(float64(value)) }
+
+// promGaugeHandle adapts a resolved prometheus.Gauge to GaugeHandle.
+type promGaugeHandle struct{ gauge prometheus.Gauge }
+
+func (h promGaugeHandle) Set(value float64) { h.gauge.Set(value) }
+func (h promGaugeHandle) Inc()              { h.gauge.Inc() }
+func (h promGaugeHandle) Dec()              { h.gauge.Dec() }
+
+// promHistogramHandle adapts a resolved prometheus.Observer to HistogramHandle.
+type promHistogramHandle struct{ observer prometheus.Observer }
+
+func (h promHistogramHandle) Observe(value float64) { h.observer.Observe(value) }
+func (h promHistogramHandle) Duration(start time.Time) {
+	h.observer.Observe(time.Since(start).Seconds())
+}
+
+// ============================================================================
+// Series Deletion
+// ============================================================================
+
+// DeleteLabelValues removes one series of a counter, gauge, histogram or Rate
+// gauge so that it no longer appears in scrapes, e.g., when a per-connection
+// entity goes away.
+// The metric itself stays registered and other series are untouched.
+// labels must name every label of the metric exactly; otherwise nothing is deleted.
+//
+// Input:
+//   - name: Name of the metric
+//   - labels: The full label set identifying the series
+//
+// Output:
+//   - bool: True if a series was deleted, false if the metric or series didn't exist
+//
+// Example:
+//
+//	client.SetGauge(ctx, "connection_bytes", 512, "conn_id", "c-42")
+//	client.DeleteLabelValues("connection_bytes", map[string]string{"conn_id": "c-42"})
+func (c *prometheusClient) DeleteLabelValues(name string, labels map[string]string) bool {
+	c.counterMu.RLock()
+	counter, exists := c.counters[name]
+	c.counterMu.RUnlock()
+	if exists {
+		return counter.Delete(labels)
+	}
+
+	c.gaugeMu.RLock()
+	gauge, exists := c.gauges[name]
+	c.gaugeMu.RUnlock()
+	if exists {
+		return gauge.Delete(labels)
+	}
+
+	c.histogramMu.RLock()
+	histogram, exists := c.histograms[name]
+	c.histogramMu.RUnlock()
+	if exists {
+		deleted := histogram.Delete(labels)
+		// Drop the dual-emitted summary series along with the histogram's
+		c.summaryMu.RLock()
+		summary, dual := c.summaries[name+SummarySuffix]
+		c.summaryMu.RUnlock()
+		if dual {
+			summary.Delete(labels)
+		}
+		return deleted
+	}
+
+	c.rateMu.Lock()
+	rate, exists := c.rates[name]
+	c.rateMu.Unlock()
+	if exists {
+		return rate.delete(labels)
+	}
+
+	return false
+}
+
 // ============================================================================
 // HTTP Handler
 // ============================================================================
@@ -424,6 +818,10 @@ func (c *prometheusClient) getOrCreateHistogram(name string, tags []string) *pro
 // This handler uses the instance's own gatherer, ensuring only metrics registered
 // with this client are exposed. This is critical when using custom registries.
 //
+// The format is negotiated from the Accept header: OpenMetrics when the scraper
+// asks for it, the classic text format otherwise. With WithCreatedTimestamps,
+// OpenMetrics responses also carry _created lines.
+//
 // Output:
 //   - http.Handler: Handler that serves metrics in Prometheus exposition format
 //
@@ -433,9 +831,154 @@ func (c *prometheusClient) getOrCreateHistogram(name string, tags []string) *pro
 //	http.Handle("/metrics", client.Handler())
 //	http.ListenAndServe(":8080", nil)
 func (c *prometheusClient) Handler() http.Handler {
-	return promhttp.HandlerFor(c.gatherer, promhttp.HandlerOpts{
-		EnableOpenMetrics: true,
+	handler := promhttp.HandlerFor(c.gatherer, promhttp.HandlerOpts{
+		EnableOpenMetrics:                   true,
+		EnableOpenMetricsTextCreatedSamples: c.createdTimestamps,
 	})
+	if !c.scrapeMetrics {
+		return handler
+	}
+
+	scrapes, err := registerOrExisting(c.registerer, prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        ScrapesTotalMetric,
+		Help:        "Scrapes served by the metrics handler.",
+		ConstLabels: c.constLabels,
+	}))
+	if err != nil {
+		return handler
+	}
+	duration, err := registerOrExisting(c.registerer, prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:        ScrapeDurationMetric,
+		Help:        "Time taken to gather and write the metrics of a scrape.",
+		ConstLabels: c.constLabels,
+		Buckets:     prometheus.DefBuckets,
+	}))
+	if err != nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		handler.ServeHTTP(w, r)
+		duration.Observe(time.Since(start).Seconds())
+		scrapes.Inc()
+	})
+}
+
+// registerOrExisting registers c and returns it, or returns the equal
+// collector already registered, e.g., by an earlier Handler call or another
+// client sharing the registry. It fails when registration fails otherwise or
+// the registered collector is of another type, e.g., a *CounterVec a user
+// registered under the same name.
+func registerOrExisting[T prometheus.Collector](registerer prometheus.Registerer, c T) (T, error) {
+	if err := registerer.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			return c, err
+		}
+		existing, ok := already.ExistingCollector.(T)
+		if !ok {
+			return c, fmt.Errorf("metrics: collector registered as %T, want %T", already.ExistingCollector, c)
+		}
+		return existing, nil
+	}
+	return c, nil
+}
+
+// metricSnapshot is one metric family in a SnapshotJSON document.
+type metricSnapshot struct {
+	Name    string           `json:"name"`
+	Help    string           `json:"help,omitempty"`
+	Type    string           `json:"type"`
+	Samples []sampleSnapshot `json:"samples"`
+}
+
+// sampleSnapshot is one series of a metric family. Counters and gauges set
+// Value; histograms and summaries set Count, Sum and Buckets or Quantiles.
+// Non-finite values (NaN, ±Inf) are omitted since JSON cannot represent them.
+type sampleSnapshot struct {
+	Labels    map[string]string  `json:"labels,omitempty"`
+	Value     *float64           `json:"value,omitempty"`
+	Count     *uint64            `json:"count,omitempty"`
+	Sum       *float64           `json:"sum,omitempty"`
+	Buckets   map[string]uint64  `json:"buckets,omitempty"`
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+}
+
+// SnapshotJSON gathers the registry, the same way Handler does for a scrape,
+// and renders every metric family with its samples as indented JSON. Histogram
+// buckets are keyed by upper bound and hold cumulative counts, as in the
+// Prometheus text format.
+//
+// It is meant for local debugging; Prometheus should scrape Handler.
+//
+// Example:
+//
+//	mux.HandleFunc("/debug/metrics.json", func(w http.ResponseWriter, _ *http.Request) {
+//	    data, err := client.SnapshotJSON()
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusInternalServerError)
+//	        return
+//	    }
+//	    w.Header().Set("Content-Type", "application/json")
+//	    _, _ = w.Write(data)
+//	})
+func (c *prometheusClient) SnapshotJSON() ([]byte, error) {
+	families, err := c.gatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make([]metricSnapshot, 0, len(families))
+	for _, mf := range families {
+		family := metricSnapshot{
+			Name:    mf.GetName(),
+			Help:    mf.GetHelp(),
+			Type:    strings.ToLower(mf.GetType().String()),
+			Samples: make([]sampleSnapshot, 0, len(mf.GetMetric())),
+		}
+		for _, m := range mf.GetMetric() {
+			var sample sampleSnapshot
+			if len(m.GetLabel()) > 0 {
+				sample.Labels = make(map[string]string, len(m.GetLabel()))
+				for _, lp := range m.GetLabel() {
+					sample.Labels[lp.GetName()] = lp.GetValue()
+				}
+			}
+
+			switch {
+			case m.Counter != nil:
+				sample.Value = finite(m.GetCounter().GetValue())
+			case m.Gauge != nil:
+				sample.Value = finite(m.GetGauge().GetValue())
+			case m.Untyped != nil:
+				sample.Value = finite(m.GetUntyped().GetValue())
+			case m.Histogram != nil:
+				h := m.GetHistogram()
+				count := h.GetSampleCount()
+				sample.Count = &count
+				sample.Sum = finite(h.GetSampleSum())
+				sample.Buckets = make(map[string]uint64, len(h.GetBucket())+1)
+				for _, b := range h.GetBucket() {
+					sample.Buckets[formatBound(b.GetUpperBound())] = b.GetCumulativeCount()
+				}
+				sample.Buckets["+Inf"] = count
+			case m.Summary != nil:
+				s := m.GetSummary()
+				count := s.GetSampleCount()
+				sample.Count = &count
+				sample.Sum = finite(s.GetSampleSum())
+				sample.Quantiles = make(map[string]float64, len(s.GetQuantile()))
+				for _, q := range s.GetQuantile() {
+					if v := finite(q.GetValue()); v != nil {
+						sample.Quantiles[formatBound(q.GetQuantile())] = *v
+					}
+				}
+			}
+			family.Samples = append(family.Samples, sample)
+		}
+		snapshot = append(snapshot, family)
+	}
+	return json.MarshalIndent(snapshot, "", "  ")
 }
 
 // Close performs cleanup for the Prometheus client.
@@ -449,12 +992,25 @@ func (*prometheusClient) Close() error {
 // Helper Functions
 // ============================================================================
 
-// extractLabelNames extracts the label names (keys) from alternating key-value tag pairs.
-// If the number of tags is odd, it appends "unknown" to make it even.
-func extractLabelNames(tags []string) []string {
-	if len(tags)%2 != 0 {
-		tags = append(tags, "unknown")
+// finite returns a pointer to v, or nil when v is NaN or infinite.
+func finite(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
 	}
+	return &v
+}
+
+// formatBound renders a bucket bound or quantile the way Prometheus does ("0.5", "+Inf").
+func formatBound(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// extractLabelNames extracts the label names (keys) from alternating key-value
+// tag pairs, already validated by labels.
+func extractLabelNames(tags []string) []string {
 	names := make([]string, 0, len(tags)/2)
 	for i := 0; i < len(tags); i += 2 {
 		names = append(names, tags[i])
@@ -462,12 +1018,9 @@ func extractLabelNames(tags []string) []string {
 	return names
 }
 
-// extractLabelValues extracts the label values from alternating key-value tag pairs.
-// If the number of tags is odd, it appends "unknown" to make it even.
+// extractLabelValues extracts the label values from alternating key-value tag
+// pairs, already validated by labels.
 func extractLabelValues(tags []string) []string {
-	if len(tags)%2 != 0 {
-		tags = append(tags, "unknown")
-	}
 	values := make([]string, 0, len(tags)/2)
 	for i := 1; i < len(tags); i += 2 {
 		values = append(values, tags[i])
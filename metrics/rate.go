@@ -0,0 +1,241 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ============================================================================
+// Rate (Sliding-Window Gauge)
+// ============================================================================
+
+// DefaultRateWindow is the default sliding window used by Rate.
+const DefaultRateWindow = 10 * time.Second
+
+// rateBuckets is the number of sub-buckets a window is divided into.
+// More buckets give a smoother rate at the cost of a little memory per series.
+const rateBuckets = 10
+
+// rateIdleWindows is how many windows a Rate series may go without events
+// before it is evicted. It reports 0 in between, so dashboards see it drop.
+const rateIdleWindows = 2
+
+// Rate records one event for the gauge `name`, which reports the number of
+// events per second observed over the client's sliding window (see
+// WithRateWindow). It is meant for dashboards or debug endpoints that cannot
+// run rate() queries.
+//
+// The rate is computed when the gauge is collected, so a series that stops
+// receiving events decays to 0. A series without events for rateIdleWindows
+// windows is evicted until Rate is called for it again; DeleteLabelValues
+// removes one right away.
+//
+// Input:
+//   - ctx: Context for the operation (reserved for future use)
+//   - name: Name of the gauge metric
+//   - tags: Alternating key-value pairs for metric labels
+//
+// Example:
+//
+//	client.Rate(ctx, "requests_per_second", "endpoint", "/users")
+func (c *prometheusClient) Rate(_ context.Context, name string, tags ...string) {
+	tags, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
+	c.getOrCreateRate(name, tags).record(extractLabelValues(tags), time.Now())
+}
+
+// getOrCreateRate retrieves the collector exporting the Rate gauge name,
+// creating and registering it on first use.
+func (c *prometheusClient) getOrCreateRate(name string, tags []string) *rateCollector {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	collector, exists := c.rates[name]
+	if !exists {
+		collector = &rateCollector{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(c.namespace, c.subsystem, name),
+				name,
+				extractLabelNames(tags),
+				c.constLabels,
+			),
+			labelNames: extractLabelNames(tags),
+			window:     c.rateWindow,
+			series:     make(map[string]*rateSeries),
+		}
+		c.registerer.MustRegister(collector)
+		c.rates[name] = collector
+	}
+	return collector
+}
+
+// rateCollector exports the series of one Rate gauge, computing each rate
+// from its sliding window at collection time.
+type rateCollector struct {
+	desc       *prometheus.Desc
+	labelNames []string
+	window     time.Duration
+
+	mu     sync.Mutex
+	series map[string]*rateSeries
+}
+
+// rateSeries is one label set of a Rate gauge.
+type rateSeries struct {
+	labelValues []string
+	window      *slidingWindow
+}
+
+// record adds one event at now to the series of labelValues. Creating a
+// series first evicts the idle ones, which bounds memory on clients that are
+// never scraped. Like GaugeVec.WithLabelValues, it panics when the number of
+// values does not match the gauge's labels.
+func (rc *rateCollector) record(labelValues []string, now time.Time) {
+	if len(labelValues) != len(rc.labelNames) {
+		panic(fmt.Sprintf("metrics: rate %s has labels %q but got %d values %q",
+			rc.desc, rc.labelNames, len(labelValues), labelValues))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	series, exists := rc.series[key]
+	if !exists {
+		rc.evictIdle(now)
+		series = &rateSeries{labelValues: labelValues, window: newSlidingWindow(rc.window)}
+		rc.series[key] = series
+	}
+	series.window.add(now)
+}
+
+// evictIdle drops the series that had no events for rateIdleWindows windows.
+// The caller holds rc.mu.
+func (rc *rateCollector) evictIdle(now time.Time) {
+	for key, series := range rc.series {
+		if series.window.idle(now) {
+			delete(rc.series, key)
+		}
+	}
+}
+
+// delete removes the series identified by labels, which must name every label
+// of the gauge. Returns whether a series was removed.
+func (rc *rateCollector) delete(labels map[string]string) bool {
+	if len(labels) != len(rc.labelNames) {
+		return false
+	}
+	values := make([]string, len(rc.labelNames))
+	for i, name := range rc.labelNames {
+		value, ok := labels[name]
+		if !ok {
+			return false
+		}
+		values[i] = value
+	}
+	key := strings.Join(values, "\xff")
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	_, exists := rc.series[key]
+	delete(rc.series, key)
+	return exists
+}
+
+// Describe implements prometheus.Collector.
+func (rc *rateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.desc
+}
+
+// Collect implements prometheus.Collector, evicting idle series first.
+func (rc *rateCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+	rc.mu.Lock()
+	rc.evictIdle(now)
+	metrics := make([]prometheus.Metric, 0, len(rc.series))
+	for _, series := range rc.series {
+		metrics = append(metrics, prometheus.MustNewConstMetric(
+			rc.desc, prometheus.GaugeValue, series.window.rate(now), series.labelValues...))
+	}
+	rc.mu.Unlock()
+
+	for _, m := range metrics {
+		ch <- m
+	}
+}
+
+// slidingWindow counts events in fixed-width buckets covering the last window.
+// Buckets are recycled in a ring as time advances. It is not safe for
+// concurrent use; rateCollector guards its windows.
+type slidingWindow struct {
+	window    time.Duration
+	width     time.Duration
+	counts    [rateBuckets]int64
+	starts    [rateBuckets]int64 // bucket start time (unix nanos); 0 means unused
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// newSlidingWindow creates a window of the given length.
+func newSlidingWindow(window time.Duration) *slidingWindow {
+	if window <= 0 {
+		window = DefaultRateWindow
+	}
+	width := window / rateBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return &slidingWindow{window: window, width: width}
+}
+
+// add records one event at now.
+func (w *slidingWindow) add(now time.Time) {
+	if w.firstSeen.IsZero() {
+		w.firstSeen = now
+	}
+	w.lastSeen = now
+
+	nanos := now.UnixNano()
+	start := nanos - nanos%int64(w.width)
+	idx := (start / int64(w.width)) % rateBuckets
+	if w.starts[idx] != start {
+		w.starts[idx] = start
+		w.counts[idx] = 0
+	}
+	w.counts[idx]++
+}
+
+// rate returns the events-per-second rate over the window ending at now.
+func (w *slidingWindow) rate(now time.Time) float64 {
+	cutoff := now.UnixNano() - int64(w.window)
+	var total int64
+	for i := range w.counts {
+		if w.starts[i] > cutoff {
+			total += w.counts[i]
+		}
+	}
+
+	// Until a full window has elapsed, divide by the observed span so the
+	// rate isn't underestimated right after startup.
+	span := now.Sub(w.firstSeen)
+	if span > w.window {
+		span = w.window
+	}
+	if span < w.width {
+		span = w.width
+	}
+	return float64(total) / span.Seconds()
+}
+
+// idle reports whether the window had no events for rateIdleWindows windows.
+func (w *slidingWindow) idle(now time.Time) bool {
+	return now.Sub(w.lastSeen) > rateIdleWindows*w.window
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// RecordingClient (In-Memory Client for Tests)
+// ============================================================================
+
+// Op identifies the Client method that produced a Record.
+type Op string
+
+// Recorded operations. Handle calls are recorded as the matching Client call,
+// e.g., CounterHandle(...).Add records OpAdd.
+const (
+	OpInc       Op = "inc"
+	OpAdd       Op = "add"
+	OpSetGauge  Op = "set_gauge"
+	OpGaugeInc  Op = "gauge_inc"
+	OpGaugeDec  Op = "gauge_dec"
+	OpHistogram Op = "histogram"
+	OpDuration  Op = "duration"
+	OpRate      Op = "rate"
+)
+
+// Record is one metric call captured by a RecordingClient.
+type Record struct {
+	Op     Op                `json:"op"`
+	Name   string            `json:"name"`
+	Value  float64           `json:"value"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// RecordingClient is a Client that keeps every call in memory so tests can
+// assert on instrumentation without scraping. Inc and GaugeInc record a value
+// of 1, GaugeDec a value of -1, Duration the elapsed seconds and Rate one event.
+// GaugeFunc callbacks are kept and called by Gauge. Calls with an odd number of
+// tags are dropped and counted under LabelErrorsMetric, as on the Prometheus
+// client. It is safe for concurrent use.
+//
+// Example:
+//
+//	rec := metrics.NewRecordingClient()
+//	svc := NewService(rec)
+//	svc.CreateUser(ctx, user)
+//	if got := rec.Counter("users_created_total", map[string]string{"plan": "pro"}); got != 1 {
+//	    t.Errorf("users_created_total = %v, want 1", got)
+//	}
+type RecordingClient struct {
+	mu         sync.Mutex
+	records    []Record
+	gaugeFuncs []recordedGaugeFunc
+}
+
+// recordedGaugeFunc is a callback registered with GaugeFunc.
+type recordedGaugeFunc struct {
+	name   string
+	labels map[string]string
+	fn     func() float64
+}
+
+// NewRecordingClient creates an empty RecordingClient.
+func NewRecordingClient() *RecordingClient {
+	return &RecordingClient{}
+}
+
+var _ Client = (*RecordingClient)(nil)
+
+// record appends one call.
+func (c *RecordingClient) record(op Op, name string, value float64, labels map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, Record{Op: op, Name: name, Value: value, Labels: labels})
+}
+
+// labels converts alternating key-value tags into a label map. An odd number
+// of tags is rejected like the Prometheus client does: the call is dropped and
+// counted under LabelErrorsMetric with the metric name as its "metric" label.
+func (c *RecordingClient) labels(name string, tags []string) (map[string]string, bool) {
+	if len(tags)%2 != 0 {
+		c.record(OpInc, LabelErrorsMetric, 1, map[string]string{"metric": name})
+		return nil, false
+	}
+	if len(tags) == 0 {
+		return nil, true
+	}
+	labels := make(map[string]string, len(tags)/2)
+	for i := 0; i < len(tags); i += 2 {
+		labels[tags[i]] = tags[i+1]
+	}
+	return labels, true
+}
+
+// recordTags records one call after converting tags, dropping it on an odd
+// tag count.
+func (c *RecordingClient) recordTags(op Op, name string, value float64, tags []string) {
+	if labels, ok := c.labels(name, tags); ok {
+		c.record(op, name, value, labels)
+	}
+}
+
+func (c *RecordingClient) Inc(_ context.Context, name string, tags ...string) {
+	c.recordTags(OpInc, name, 1, tags)
+}
+
+func (c *RecordingClient) Add(_ context.Context, name string, value int64, tags ...string) {
+	c.recordTags(OpAdd, name, float64(value), tags)
+}
+
+func (c *RecordingClient) SetGauge(_ context.Context, name string, value float64, tags ...string) {
+	c.recordTags(OpSetGauge, name, value, tags)
+}
+
+func (c *RecordingClient) GaugeInc(_ context.Context, name string, tags ...string) {
+	c.recordTags(OpGaugeInc, name, 1, tags)
+}
+
+func (c *RecordingClient) GaugeDec(_ context.Context, name string, tags ...string) {
+	c.recordTags(OpGaugeDec, name, -1, tags)
+}
+
+// GaugeFunc keeps fn for Gauge, replacing the callback registered for the same
+// name and labels. It records no call.
+func (c *RecordingClient) GaugeFunc(name string, fn func() float64, tags ...string) {
+	labels, ok := c.labels(name, tags)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gaugeFuncs = slices.DeleteFunc(c.gaugeFuncs, func(g recordedGaugeFunc) bool {
+		return g.name == name && maps.Equal(g.labels, labels)
+	})
+	c.gaugeFuncs = append(c.gaugeFuncs, recordedGaugeFunc{name: name, labels: labels, fn: fn})
+}
+
+func (c *RecordingClient) Histogram(_ context.Context, name string, value float64, tags ...string) {
+	c.recordTags(OpHistogram, name, value, tags)
+}
+
+func (c *RecordingClient) Duration(_ context.Context, name string, start time.Time, tags ...string) {
+	c.recordTags(OpDuration, name, time.Since(start).Seconds(), tags)
+}
+
+// Latency records OpHistogram under name; the summary WithDualLatency adds on
+// the Prometheus client is not recorded.
+func (c *RecordingClient) Latency(_ context.Context, name string, value float64, tags ...string) {
+	c.recordTags(OpHistogram, name, value, tags)
+}
+
+func (c *RecordingClient) Rate(_ context.Context, name string, tags ...string) {
+	c.recordTags(OpRate, name, 1, tags)
+}
+
+func (c *RecordingClient) CounterHandle(name string, tags ...string) CounterHandle {
+	labels, ok := c.labels(name, tags)
+	if !ok {
+		return noopHandle{}
+	}
+	return recordingHandle{client: c, name: name, labels: labels}
+}
+
+func (c *RecordingClient) GaugeHandle(name string, tags ...string) GaugeHandle {
+	labels, ok := c.labels(name, tags)
+	if !ok {
+		return noopHandle{}
+	}
+	return recordingGaugeHandle{client: c, name: name, labels: labels}
+}
+
+func (c *RecordingClient) HistogramHandle(name string, tags ...string) HistogramHandle {
+	labels, ok := c.labels(name, tags)
+	if !ok {
+		return noopHandle{}
+	}
+	return recordingHandle{client: c, name: name, labels: labels}
+}
+
+// DeleteLabelValues drops every record of the series matching labels exactly.
+// Returns whether any record was removed.
+func (c *RecordingClient) DeleteLabelValues(name string, labels map[string]string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := c.records[:0]
+	for _, r := range c.records {
+		if r.Name != name || !maps.Equal(r.Labels, labels) {
+			kept = append(kept, r)
+		}
+	}
+	deleted := len(kept) < len(c.records)
+	clear(c.records[len(kept):])
+	c.records = kept
+	return deleted
+}
+
+// Handler returns a handler that serves SnapshotJSON.
+func (c *RecordingClient) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		data, err := c.SnapshotJSON()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// SnapshotJSON returns every recorded call, in order, as indented JSON.
+func (c *RecordingClient) SnapshotJSON() ([]byte, error) {
+	return json.MarshalIndent(c.Calls(), "", "  ")
+}
+
+func (*RecordingClient) Close() error { return nil }
+
+// ============================================================================
+// Accessors
+// ============================================================================
+
+// Calls returns a copy of every recorded call in the order it was made.
+func (c *RecordingClient) Calls() []Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := make([]Record, len(c.records))
+	for i, r := range c.records {
+		r.Labels = maps.Clone(r.Labels)
+		calls[i] = r
+	}
+	return calls
+}
+
+// Counter returns the total of the Inc and Add calls for the series matching
+// name and labels exactly. A nil or empty labels map matches unlabeled calls.
+func (c *RecordingClient) Counter(name string, labels map[string]string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var total float64
+	for _, r := range c.records {
+		if (r.Op == OpInc || r.Op == OpAdd) && r.Name == name && maps.Equal(r.Labels, labels) {
+			total += r.Value
+		}
+	}
+	return total
+}
+
+// Gauge returns the current value of the gauge series matching name and
+// labels exactly, replaying SetGauge, GaugeInc and GaugeDec calls in order, or
+// calling the GaugeFunc callback registered for the series.
+func (c *RecordingClient) Gauge(name string, labels map[string]string) float64 {
+	if fn := c.gaugeFunc(name, labels); fn != nil {
+		return fn()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var value float64
+	for _, r := range c.records {
+		if r.Name != name || !maps.Equal(r.Labels, labels) {
+			continue
+		}
+		switch r.Op {
+		case OpSetGauge:
+			value = r.Value
+		case OpGaugeInc, OpGaugeDec:
+			value += r.Value
+		}
+	}
+	return value
+}
+
+// gaugeFunc returns the GaugeFunc callback of the series, or nil.
+func (c *RecordingClient) gaugeFunc(name string, labels map[string]string) func() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, g := range c.gaugeFuncs {
+		if g.name == name && maps.Equal(g.labels, labels) {
+			return g.fn
+		}
+	}
+	return nil
+}
+
+// Observations returns the Histogram, Latency and Duration values recorded for the
+// series matching name and labels exactly, in order.
+func (c *RecordingClient) Observations(name string, labels map[string]string) []float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var values []float64
+	for _, r := range c.records {
+		if (r.Op == OpHistogram || r.Op == OpDuration) && r.Name == name && maps.Equal(r.Labels, labels) {
+			values = append(values, r.Value)
+		}
+	}
+	return values
+}
+
+// Reset discards every recorded call. GaugeFunc callbacks are kept.
+func (c *RecordingClient) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = nil
+}
+
+// ============================================================================
+// Recording Handles
+// ============================================================================
+
+// recordingHandle records handle calls on its client. It implements
+// CounterHandle and HistogramHandle.
+type recordingHandle struct {
+	client *RecordingClient
+	name   string
+	labels map[string]string
+}
+
+func (h recordingHandle) Inc()            { h.client.record(OpInc, h.name, 1, h.labels) }
+func (h recordingHandle) Add(value int64) { h.client.record(OpAdd, h.name, float64(value), h.labels) }
+func (h recordingHandle) Observe(value float64) {
+	h.client.record(OpHistogram, h.name, value, h.labels)
+}
+func (h recordingHandle) Duration(start time.Time) {
+	h.client.record(OpDuration, h.name, time.Since(start).Seconds(), h.labels)
+}
+
+// recordingGaugeHandle records gauge handle calls on its client.
+type recordingGaugeHandle struct {
+	client *RecordingClient
+	name   string
+	labels map[string]string
+}
+
+func (h recordingGaugeHandle) Set(value float64) {
+	h.client.record(OpSetGauge, h.name, value, h.labels)
+}
+func (h recordingGaugeHandle) Inc() { h.client.record(OpGaugeInc, h.name, 1, h.labels) }
+func (h recordingGaugeHandle) Dec() { h.client.record(OpGaugeDec, h.name, -1, h.labels) }
@@ -205,6 +205,7 @@ func registerAllRoutes(srv *server.Server) error {
 	}
 
 	apiV1 := routing.NewGroupRoute("/api/v1").
+		Headers(map[string]string{"X-API-Version": "v1", "Cache-Control": "no-store"}).
 		GET("/status", apiStatusHandler).
 		GET("/version", apiVersionHandler).
 		Build()
@@ -4,40 +4,66 @@
 package configuration
 
 import (
-	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
 	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/validator"
 )
 
 // Validate checks the configuration for common mistakes and returns an error
 // if anything is misconfigured. Call this at startup for fail-fast behavior.
+// Every invalid field is reported, not just the first: the error is a
+// validator.ValidationErrors whose entries name the field by its YAML key.
+// Validate also keeps the parsed TrustedProxies for TrustedProxyPrefixes.
+//
+// Port 0 passes validation; NewServer replaces it with DefaultPort.
 func (c *Config) Validate() error {
+	var errs validator.ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, validator.ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
 	if c.ServiceName == "" {
-		return errors.New("service_name is required")
+		add("service_name", "is required")
 	}
 	if c.Port < 0 || c.Port > 65535 {
-		return fmt.Errorf("port must be 0-65535, got %d", c.Port)
-	}
-	if err := c.validateTimeouts(); err != nil {
-		return err
+		add("port", "must be 0-65535, got %d", c.Port)
 	}
+	c.validateTimeouts(add)
 	if c.MaxBodySize < 0 {
-		return errors.New("max_body_size cannot be negative")
+		add("max_body_size", "cannot be negative, got %d", c.MaxBodySize)
 	}
 	if c.MaxConcurrentConnections < 0 {
-		return errors.New("max_concurrent_connections cannot be negative")
+		add("max_concurrent_connections", "cannot be negative, got %d", c.MaxConcurrentConnections)
+	}
+	if c.Compression != nil && c.Compression.MinSize < 0 {
+		add("compression.min_size", "cannot be negative, got %d", c.Compression.MinSize)
 	}
-	if err := c.validateCORS(); err != nil {
-		return err
+	c.trustedProxyPrefixes = make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		prefix, err := ParseTrustedProxy(proxy)
+		if err != nil {
+			add("trusted_proxies", "invalid IP or CIDR %q", proxy)
+			continue
+		}
+		c.trustedProxyPrefixes = append(c.trustedProxyPrefixes, prefix)
 	}
+	c.validateCORS(add)
 	if c.EnableCSRF && c.CSRF == nil {
-		return errors.New("csrf config is required when enable_csrf is true")
+		add("csrf", "is required when enable_csrf is true")
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
 
 // validateTimeouts checks that all timeout durations are positive when set.
-func (c *Config) validateTimeouts() error {
+func (c *Config) validateTimeouts(add func(field, format string, args ...any)) {
 	checks := []struct {
 		name  string
 		value *time.Duration
@@ -50,34 +76,60 @@ func (c *Config) validateTimeouts() error {
 	}
 	for _, tc := range checks {
 		if tc.value != nil && *tc.value <= 0 {
-			return fmt.Errorf("%s must be positive, got %v", tc.name, *tc.value)
+			add(tc.name, "must be positive, got %v", *tc.value)
 		}
 	}
-	return nil
 }
 
 // validateCORS checks CORS configuration completeness and correctness.
-func (c *Config) validateCORS() error {
+func (c *Config) validateCORS(add func(field, format string, args ...any)) {
 	if !c.EnableCORS {
-		return nil
+		return
 	}
 	if c.CORS == nil {
-		return errors.New("cors config is required when enable_cors is true")
+		add("cors", "is required when enable_cors is true")
+		return
 	}
 	if len(c.CORS.AllowOrigins) == 0 {
-		return errors.New("cors allow_origins is required")
+		add("cors.allow_origins", "is required")
 	}
 	if len(c.CORS.AllowMethods) == 0 {
-		return errors.New("cors allow_methods is required")
+		add("cors.allow_methods", "is required")
 	}
-	if c.CORS.AllowCredentials {
-		for _, origin := range c.CORS.AllowOrigins {
-			if origin == "*" {
-				return errors.New("CORS: AllowOrigins cannot be '*' when AllowCredentials is true (browsers reject this)")
-			}
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowOrigins, "*") {
+		add("cors.allow_origins", "cannot be '*' when allow_credentials is true (browsers reject this)")
+	}
+}
+
+// TrustedProxyPrefixes returns TrustedProxies as prefixes, skipping invalid
+// entries. Validate parses them once and keeps the result, so the server does
+// not parse them per request; on a config that was not validated they are
+// parsed on every call.
+func (c *Config) TrustedProxyPrefixes() []netip.Prefix {
+	if c.trustedProxyPrefixes != nil {
+		return c.trustedProxyPrefixes
+	}
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		if prefix, err := ParseTrustedProxy(proxy); err == nil {
+			prefixes = append(prefixes, prefix)
 		}
 	}
-	return nil
+	return prefixes
+}
+
+// ParseTrustedProxy parses a TrustedProxies entry, an IP or a CIDR range. An IP
+// is returned as a single-address prefix.
+func ParseTrustedProxy(s string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
 }
 
 // MiddlewareConfig holds configuration for default middlewares.
@@ -226,6 +278,9 @@ type Config struct {
 	// EnableCSRF enables Cross-Site Request Forgery protection.
 	// Protects against CSRF attacks with token validation.
 	// Requires CSRF config to be set if enabled.
+	// It installs Fiber's CSRF middleware on every route, which keeps tokens
+	// in the process's memory. With several replicas, or to protect only some
+	// routes, use middleware.CSRF instead; its signed tokens need no storage.
 	// Default: false
 	EnableCSRF bool `yaml:"enable_csrf" json:"enable_csrf"`
 
@@ -255,6 +310,13 @@ type Config struct {
 	// Default: false (for backward compatibility)
 	UseProperHTTPStatus bool `yaml:"use_proper_http_status" json:"use_proper_http_status"`
 
+	// CollapseValidationErrors reports one error per field in validation error
+	// responses (the first failing rule in tag order) and adds the rule name and
+	// the offending value to each entry. Note that the value is echoed back to
+	// the client as sent.
+	// Default: false (every failing rule is listed with field and message only)
+	CollapseValidationErrors bool `yaml:"collapse_validation_errors" json:"collapse_validation_errors"`
+
 	// SlowRequestThreshold is the duration threshold for slow request detection.
 	// Requests exceeding this threshold will be logged with a warning.
 	// Set 0 to disable slow request detection (default).
@@ -269,6 +331,11 @@ type Config struct {
 	// 1 = BestSpeed, 2 = BestCompression. Default: 1 (BestSpeed).
 	CompressionLevel *int `yaml:"compression_level" json:"compression_level"`
 
+	// Compression tunes which responses are compressed. Setting it enables
+	// compression even when MiddlewareConfig.DisableCompression is true.
+	// Default: nil (compress every compressible response of 200 bytes or more)
+	Compression *CompressionConfig `yaml:"compression" json:"compression"`
+
 	// RequestTimeout is the maximum duration for processing a single request.
 	// Requests exceeding this timeout will receive a 408 Request Timeout error.
 	// Default: 30 seconds. Set nil to disable.
@@ -285,6 +352,47 @@ type Config struct {
 	// When set, the server will serve static files from the specified root directory.
 	// Default: nil (disabled)
 	Static *StaticFileConfig `yaml:"static" json:"static"`
+
+	// UploadDir is the directory ctx.SaveUploadedFile writes into. Destination
+	// paths are resolved inside it and cannot escape it.
+	// Default: "" (current working directory)
+	// Example: "/var/lib/app/uploads"
+	UploadDir string `yaml:"upload_dir" json:"upload_dir"`
+
+	// MethodOverride lets POST requests be routed as PUT, PATCH or DELETE
+	// through the X-HTTP-Method-Override header, for clients and proxies that
+	// only speak GET and POST. Other methods and override values are ignored.
+	// Only enable it when every mutating route is authenticated and CSRF-protected.
+	// Default: false
+	MethodOverride bool `yaml:"method_override" json:"method_override"`
+
+	// StrictSlash makes "/users" and "/users/" distinct paths. By default a
+	// trailing slash is ignored and both reach the same handler.
+	// Default: false
+	StrictSlash bool `yaml:"strict_slash" json:"strict_slash"`
+
+	// RedirectTrailingSlash answers a request that matches no route, but would
+	// with its trailing slash added or removed, with a 308 redirect to that
+	// path. It implies StrictSlash.
+	// Default: false
+	RedirectTrailingSlash bool `yaml:"redirect_trailing_slash" json:"redirect_trailing_slash"`
+
+	// TrustedProxies lists the IPs and CIDR ranges of the reverse proxies in
+	// front of the server. ctx.IPInfo walks X-Forwarded-For from the right,
+	// skipping these addresses, to find the client IP; entries left of an
+	// untrusted hop are ignored since anyone can send them.
+	// Default: nil (no proxy is trusted, the client IP is the socket peer)
+	// Example: []string{"10.0.0.0/8", "192.168.1.10"}
+	TrustedProxies []string `yaml:"trusted_proxies" json:"trusted_proxies"`
+
+	// ContextFactory, when set, wraps the Context of every request once; the
+	// wrapper is what middlewares and handlers receive. Set it in code with
+	// server.WithContextFactory.
+	// Default: nil (handlers receive the framework's Context)
+	ContextFactory func(core.Context) core.Context `yaml:"-" json:"-"`
+
+	// trustedProxyPrefixes is TrustedProxies as parsed by Validate.
+	trustedProxyPrefixes []netip.Prefix
 }
 
 // StaticFileConfig represents static file serving configuration.
@@ -309,6 +417,23 @@ type StaticFileConfig struct {
 	MaxAge int `yaml:"max_age" json:"max_age"`
 }
 
+// CompressionConfig represents response compression configuration.
+// Responses are compressed with brotli, gzip or deflate, as the client's
+// Accept-Encoding allows, at Config.CompressionLevel.
+type CompressionConfig struct {
+	// MinSize is the smallest response body, in bytes, that is compressed.
+	// Bodies under 200 bytes are never compressed; streamed bodies always are.
+	// Default: 0 (200 bytes)
+	// Example: 1024
+	MinSize int `yaml:"min_size" json:"min_size"`
+
+	// SkipContentTypes lists response Content-Type prefixes that are sent
+	// uncompressed, matched case-insensitively. Types that do not compress
+	// (e.g., image/png) are always skipped.
+	// Example: []string{"application/zip", "text/event-stream"}
+	SkipContentTypes []string `yaml:"skip_content_types" json:"skip_content_types"`
+}
+
 // CSRFConfig represents CSRF protection configuration.
 type CSRFConfig struct {
 	// KeyLookup defines where to find the CSRF token in the request.
@@ -1,8 +1,13 @@
 package configuration
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/anthanhphan/gosdk/validator"
 )
 
 func TestConfigValidator_Validate_WriteTimeout_Negative(t *testing.T) {
@@ -141,6 +146,47 @@ func TestConfigValidator_Validate_Port_Zero(t *testing.T) {
 	}
 }
 
+func TestConfigValidator_Validate_CORS_NilConfig(t *testing.T) {
+	config := &Config{
+		ServiceName: "test",
+		Port:        8080,
+		EnableCORS:  true,
+	}
+	err := config.Validate()
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "cors" {
+		t.Errorf("Validate() = %v, want a single cors error", err)
+	}
+}
+
+func TestConfigValidator_Validate_ReportsEveryField(t *testing.T) {
+	negative := -time.Second
+	config := &Config{
+		Port:        70000,
+		ReadTimeout: &negative,
+		MaxBodySize: -1,
+		EnableCORS:  true,
+		CORS:        &CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true},
+	}
+	err := config.Validate()
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate() error = %T, want validator.ValidationErrors", err)
+	}
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	want := []string{"service_name", "port", "read_timeout", "max_body_size", "cors.allow_methods", "cors.allow_origins"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("Validate() fields = %v, want %v", fields, want)
+	}
+	if !strings.Contains(err.Error(), "port: must be 0-65535, got 70000") {
+		t.Errorf("Validate() error = %q, want it to describe the port", err)
+	}
+}
+
 func TestConfigValidator_Validate_AllTimeouts_Valid(t *testing.T) {
 	read := 5 * time.Second
 	write := 10 * time.Second
@@ -2,8 +2,12 @@ package configuration
 
 import (
 	"errors"
+	"net/netip"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/anthanhphan/gosdk/validator"
 )
 
 func TestConfigValidator_Validate(t *testing.T) {
@@ -122,6 +126,21 @@ func TestConfigValidator_Validate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "invalid trusted proxy should return error",
+			config: &Config{
+				ServiceName:    "Test Service",
+				Port:           8080,
+				TrustedProxies: []string{"10.0.0.0/8", "192.168.1.10", "proxy.internal"},
+			},
+			wantErr: true,
+			check: func(t *testing.T, err error) {
+				var errs validator.ValidationErrors
+				if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "trusted_proxies" {
+					t.Errorf("Validate() = %v, want one trusted_proxies error", err)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,3 +161,21 @@ func TestConfigValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_TrustedProxyPrefixes(t *testing.T) {
+	conf := &Config{ServiceName: "test", TrustedProxies: []string{"10.0.0.0/8", "192.168.1.10", "::ffff:172.16.0.1"}}
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+		netip.MustParsePrefix("172.16.0.1/32"),
+	}
+	if got := conf.TrustedProxyPrefixes(); !slices.Equal(got, want) {
+		t.Errorf("TrustedProxyPrefixes() before Validate = %v, want %v", got, want)
+	}
+	if err := conf.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := conf.TrustedProxyPrefixes(); !slices.Equal(got, want) || &got[0] != &conf.trustedProxyPrefixes[0] {
+		t.Errorf("TrustedProxyPrefixes() after Validate = %v, want %v kept by Validate", got, want)
+	}
+}
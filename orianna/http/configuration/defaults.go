@@ -58,3 +58,23 @@ const (
 	DefaultCSRFExpiration   = 24 * time.Hour
 	DefaultCSRFCookieSecure = true
 )
+
+// Maintenance mode defaults
+const (
+	DefaultMaintenanceMessage    = "Service is under maintenance"
+	DefaultMaintenanceRetryAfter = 60 * time.Second
+)
+
+// Shadow traffic defaults
+const (
+	// DefaultShadowMaxInFlight is the number of shadow requests a route runs
+	// at once; samples taken while all are busy are dropped.
+	DefaultShadowMaxInFlight = 64
+)
+
+// Readiness gate defaults
+var (
+	// DefaultReadinessGateExemptPaths are the path prefixes that stay reachable
+	// while the readiness gate is closed so probes and scrapers keep working.
+	DefaultReadinessGateExemptPaths = []string{"/health", "/healthz", "/live", "/livez", "/ready", "/readyz", "/metrics"}
+)
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	routine "github.com/anthanhphan/gosdk/goroutine"
+	"github.com/anthanhphan/gosdk/validator"
+)
+
+// DefaultBatchMaxItems is the item cap BatchHandler applies when
+// BatchOptions.MaxItems is not set.
+const DefaultBatchMaxItems = 100
+
+// BatchOptions configures BatchHandler.
+type BatchOptions struct {
+	// MaxItems caps the number of items in one request. Larger batches are
+	// rejected with 413 before any item runs (default: DefaultBatchMaxItems).
+	MaxItems int
+	// Concurrency is the number of items processed at once (default: 1,
+	// sequential). With more than one worker, perItem runs on other
+	// goroutines and must only read from the Context.
+	Concurrency int
+}
+
+// BatchResult is the outcome of one batch item. Index is the item's position
+// in the request array. Exactly one of Data and Error is set.
+type BatchResult[Res any] struct {
+	Index  int            `json:"index"`
+	Status int            `json:"status"`
+	Data   *Res           `json:"data,omitempty"`
+	Error  *ErrorResponse `json:"error,omitempty"`
+}
+
+// BatchHandler creates a handler for bulk endpoints. It binds a JSON array of
+// Req, validates and runs perItem for each item, and responds 207 Multi-Status
+// with one BatchResult per item, in request order. Item failures do not fail
+// the request: an *ErrorResponse keeps its status, validation errors become a
+// 400 VALIDATION_FAILED result and any other error a 500 INTERNAL_ERROR.
+//
+// The request body is bounded by the server's MaxBodySize like any other
+// request; BatchOptions.MaxItems additionally caps the item count.
+//
+// Example:
+//
+//	server.POST("/users/batch", core.BatchHandler(
+//	    func(ctx core.Context, req CreateUserRequest) (User, error) {
+//	        return service.CreateUser(ctx.Context(), req)
+//	    },
+//	    core.BatchOptions{MaxItems: 50, Concurrency: 4},
+//	))
+func BatchHandler[Req, Res any](perItem func(Context, Req) (Res, error), opts ...BatchOptions) Handler {
+	opt := BatchOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.MaxItems <= 0 {
+		opt.MaxItems = DefaultBatchMaxItems
+	}
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = 1
+	}
+
+	return func(ctx Context) error {
+		var items []Req
+		if err := ctx.BodyParser(&items); err != nil {
+			handleBindError(ctx, err)
+			return nil
+		}
+		if len(items) > opt.MaxItems {
+			return SendError(ctx, NewErrorResponse("BATCH_TOO_LARGE", StatusRequestEntityTooLarge,
+				fmt.Sprintf("Batch exceeds the limit of %d items", opt.MaxItems)))
+		}
+
+		results := make([]BatchResult[Res], len(items))
+		runItem := func(i int) {
+			results[i] = batchItem(ctx, i, items[i], perItem)
+		}
+
+		if opt.Concurrency == 1 {
+			for i := range items {
+				runItem(i)
+			}
+		} else {
+			indexes := make([]int, len(items))
+			for i := range indexes {
+				indexes[i] = i
+			}
+			// Panics are recovered per item; unprocessed items are filled in below
+			_ = routine.ForEach(ctx.Context(), indexes, opt.Concurrency, func(_ context.Context, i int) error {
+				runItem(i)
+				return nil
+			})
+			for i := range results {
+				if results[i].Status == 0 {
+					results[i] = BatchResult[Res]{Index: i, Status: StatusInternalServerError,
+						Error: batchItemError(ctx, i, errors.New("batch item was not processed"))}
+				}
+			}
+		}
+
+		return SendSuccess(ctx, NewSuccessResponse(StatusMultiStatus, "", results))
+	}
+}
+
+// batchItem validates and runs one batch item.
+func batchItem[Req, Res any](ctx Context, index int, item Req, perItem func(Context, Req) (Res, error)) BatchResult[Res] {
+	var err error
+	if isStructValue(item) {
+		err = validator.Validate(item)
+	}
+	var res Res
+	if err == nil {
+		res, err = perItem(ctx, item)
+	}
+	if err != nil {
+		errResp := batchItemError(ctx, index, err)
+		return BatchResult[Res]{Index: index, Status: errResp.HTTPStatus, Error: errResp}
+	}
+	return BatchResult[Res]{Index: index, Status: StatusOK, Data: &res}
+}
+
+// batchItemError converts a batch item failure into the ErrorResponse
+// reported for it, following the same mapping as TypedHandler.
+func batchItemError(ctx Context, index int, err error) *ErrorResponse {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return validationErrorResponse(ctx, validationErrors)
+	}
+	var validationError *validator.ValidationError
+	if errors.As(err, &validationError) {
+		return validationErrorResponse(ctx, validator.ValidationErrors{*validationError})
+	}
+
+	responseLog.Warnw("batch item failed",
+		"request_id", ctx.RequestID(),
+		"index", index,
+		"cause", errorString(err),
+	)
+	return NewErrorResponse("INTERNAL_ERROR", StatusInternalServerError, "An internal error occurred")
+}
+
+// isStructValue reports whether v is a struct or a non-nil pointer to one, so
+// batches of scalars (e.g., IDs) skip struct validation.
+func isStructValue(v any) bool {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv.Kind() == reflect.Struct
+}
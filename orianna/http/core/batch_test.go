@@ -0,0 +1,82 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+)
+
+func TestBatchHandler_Server(t *testing.T) {
+	type createItem struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type created struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	srv := testutil.NewServer(t)
+	handler := core.BatchHandler(func(_ core.Context, req createItem) (created, error) {
+		if req.Name == "taken" {
+			return created{}, core.NewErrorResponse("CONFLICT", core.StatusConflict, "Name already taken")
+		}
+		if req.Name == "boom" {
+			return created{}, errors.New("database unavailable")
+		}
+		return created{ID: len(req.Name), Name: req.Name}, nil
+	}, core.BatchOptions{MaxItems: 5, Concurrency: 2})
+	if err := srv.POST("/users/batch", handler); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+
+	post := func(body string) (*http.Response, map[string]any) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/users/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, raw := testutil.Send(t, srv, req)
+		var out map[string]any
+		if err := jcodec.Unmarshal([]byte(raw), &out); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp, out
+	}
+
+	resp, out := post(`[{"name":"alice"},{"name":"taken"},{"name":""},{"name":"boom"}]`)
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("status = %d, want 207", resp.StatusCode)
+	}
+	results, _ := out["data"].([]any)
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4: %v", len(results), out)
+	}
+	wantStatus := []float64{200, 409, 400, 500}
+	wantCode := []string{"", "CONFLICT", "VALIDATION_FAILED", "INTERNAL_ERROR"}
+	for i, r := range results {
+		item := r.(map[string]any)
+		if item["index"] != float64(i) || item["status"] != wantStatus[i] {
+			t.Errorf("result %d = %v, want index %d status %v", i, item, i, wantStatus[i])
+		}
+		if wantCode[i] == "" {
+			if data, _ := item["data"].(map[string]any); data["name"] != "alice" || item["error"] != nil {
+				t.Errorf("result %d = %v, want data for alice", i, item)
+			}
+			continue
+		}
+		if e, _ := item["error"].(map[string]any); e["code"] != wantCode[i] || item["data"] != nil {
+			t.Errorf("result %d = %v, want error code %s", i, item, wantCode[i])
+		}
+	}
+
+	_, out = post(`[{"name":"a"},{"name":"b"},{"name":"c"},{"name":"d"},{"name":"e"},{"name":"f"}]`)
+	if out["code"] != "BATCH_TOO_LARGE" || out["http_status"] != float64(http.StatusRequestEntityTooLarge) {
+		t.Errorf("oversized batch response = %v, want BATCH_TOO_LARGE 413", out)
+	}
+}
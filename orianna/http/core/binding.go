@@ -4,7 +4,10 @@ package core
 
 import (
 	"errors"
+	"slices"
+	"strings"
 
+	"github.com/anthanhphan/gosdk/jcodec"
 	"github.com/anthanhphan/gosdk/validator"
 )
 
@@ -112,6 +115,39 @@ func MustBind[T any](ctx Context, opts ...BindOptions) (T, bool) {
 	return result, true
 }
 
+// BindValidated is the single entry point for binding from any source. It
+// binds T from opts.Source (body by default) and always validates it; the
+// Validate option is ignored. A non-nil error is always an *ErrorResponse of
+// the same shape whatever the source: BAD_REQUEST when the input cannot be
+// parsed, VALIDATION_FAILED with an "errors" detail when validation fails.
+// Return it from a TypedHandler or send it with HandleError.
+//
+// Example:
+//
+//	type SearchParams struct {
+//	    Q    string `query:"q" validate:"required"`
+//	    Page int    `query:"page" validate:"min=1"`
+//	}
+//
+//	params, err := core.BindValidated[SearchParams](ctx, core.BindOptions{Source: core.BindSourceQuery})
+//	if err != nil {
+//	    core.HandleError(ctx, err)
+//	    return nil
+//	}
+func BindValidated[T any](ctx Context, opts ...BindOptions) (T, error) {
+	opt := DefaultBindOptions()
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt.Validate = true
+
+	result, err := Bind[T](ctx, opt)
+	if err != nil {
+		return result, bindErrorResponse(ctx, err)
+	}
+	return result, nil
+}
+
 // BindBody is a shorthand for binding from request body
 func BindBody[T any](ctx Context, validate bool) (T, error) {
 	return Bind[T](ctx, BindOptions{Source: BindSourceBody, Validate: validate})
@@ -127,36 +163,151 @@ func BindParams[T any](ctx Context, validate bool) (T, error) {
 	return Bind[T](ctx, BindOptions{Source: BindSourceParams, Validate: validate})
 }
 
+// BindHeaders is a shorthand for binding from request headers
+func BindHeaders[T any](ctx Context, validate bool) (T, error) {
+	return Bind[T](ctx, BindOptions{Source: BindSourceHeaders, Validate: validate})
+}
+
+// Partial Updates
+
+// FieldSet is the set of top-level JSON keys present in a PATCH body, as sent
+// by the client.
+type FieldSet map[string]struct{}
+
+// Has reports whether the client sent key. Keys are matched exactly.
+func (fs FieldSet) Has(key string) bool {
+	_, ok := fs[key]
+	return ok
+}
+
+// Keys returns the present keys in sorted order.
+func (fs FieldSet) Keys() []string {
+	keys := make([]string, 0, len(fs))
+	for k := range fs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// hasFold reports whether key is present ignoring case, matching how JSON
+// keys are bound to struct fields.
+func (fs FieldSet) hasFold(key string) bool {
+	if fs.Has(key) {
+		return true
+	}
+	for k := range fs {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// patchValidator reports field errors by JSON name so they can be matched
+// against the keys of a PATCH body.
+var patchValidator = validator.New(validator.WithFieldNameTag("json"))
+
+// BindPatch parses a JSON object body into T and reports which top-level keys
+// the client sent, so handlers can update only the provided columns instead of
+// overwriting them with zero values. Validation runs on the whole struct but
+// only errors for present fields are returned, so a `required` rule on an
+// omitted field does not fail a partial update.
+//
+// Example:
+//
+//	req, fields, err := orianna.BindPatch[UpdateUserRequest](ctx)
+//	if err != nil {
+//	    return orianna.SendValidationError(ctx, err)
+//	}
+//	if fields.Has("email") {
+//	    user.Email = req.Email
+//	}
+func BindPatch[T any](ctx Context) (T, FieldSet, error) {
+	var result T
+	var raw map[string]jcodec.RawMessage
+	if err := jcodec.Unmarshal(ctx.Body(), &raw); err != nil || raw == nil {
+		if err == nil {
+			err = errors.New("patch body must be a JSON object")
+		}
+		return result, nil, WrapError(err, "failed to parse request")
+	}
+	fields := make(FieldSet, len(raw))
+	for k := range raw {
+		fields[k] = struct{}{}
+	}
+
+	if err := ctx.BodyParser(&result); err != nil {
+		return result, fields, WrapError(err, "failed to parse request")
+	}
+
+	err := patchValidator.ValidateStruct(result)
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return result, fields, err
+	}
+	var present validator.ValidationErrors
+	for _, e := range errs {
+		if fields.hasFold(topLevelField(e.Field)) {
+			present = append(present, e)
+		}
+	}
+	if len(present) > 0 {
+		return result, fields, present
+	}
+	return result, fields, nil
+}
+
+// topLevelField returns the first segment of a validation field path,
+// e.g. "address" for "address.city" and "tags" for "tags[0]".
+func topLevelField(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
 // Error Handling
 
 // handleBindError sends appropriate error response based on error type.
-// Uses errors.As for proper support of wrapped errors.
 func handleBindError(ctx Context, err error) {
+	_ = SendError(ctx, bindErrorResponse(ctx, err))
+}
+
+// bindErrorResponse converts a Bind error to the response sent for it.
+// Uses errors.As for proper support of wrapped errors.
+func bindErrorResponse(ctx Context, err error) *ErrorResponse {
 	// Check if it's a validation error (supports wrapped errors)
 	var validationErrors validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
-		_ = sendValidationErrorResponse(ctx, validationErrors)
-		return
+		return validationErrorResponse(ctx, validationErrors)
 	}
 
 	// Check if single validation error (supports wrapped errors)
 	var validationError *validator.ValidationError
 	if errors.As(err, &validationError) {
-		_ = sendValidationErrorResponse(ctx, validator.ValidationErrors{*validationError})
-		return
+		return validationErrorResponse(ctx, validator.ValidationErrors{*validationError})
 	}
 
 	// Default to bad request — use generic message to avoid leaking internal details
-	errResp := NewErrorResponse("BAD_REQUEST", StatusBadRequest, "Invalid request").
+	return NewErrorResponse("BAD_REQUEST", StatusBadRequest, "Invalid request").
 		WithInternalMsg("bind error: %s", err.Error()).
 		WithCause(err)
-	_ = SendError(ctx, errResp)
 }
 
 // sendValidationErrorResponse sends a validation error response.
 // This is a shared helper to avoid code duplication between handleBindError and handleTypedError.
+// With CollapseValidationErrors enabled, each field is reported once along
+// with the failing rule and value.
 func sendValidationErrorResponse(ctx Context, errs validator.ValidationErrors) error {
-	resp := NewErrorResponse("VALIDATION_FAILED", StatusBadRequest, "Validation failed").
-		WithDetails("errors", errs.ToArray())
-	return SendError(ctx, resp)
+	return SendError(ctx, validationErrorResponse(ctx, errs))
+}
+
+// validationErrorResponse builds the VALIDATION_FAILED response for errs.
+func validationErrorResponse(ctx Context, errs validator.ValidationErrors) *ErrorResponse {
+	resp := NewErrorResponse("VALIDATION_FAILED", StatusBadRequest, "Validation failed")
+	if ctx.CollapseValidationErrors() {
+		return resp.WithDetails("errors", errs.Collapse().ToDetailedArray())
+	}
+	return resp.WithDetails("errors", errs.ToArray())
 }
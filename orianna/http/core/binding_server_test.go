@@ -0,0 +1,125 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+)
+
+func TestBindValidated_Server(t *testing.T) {
+	type input struct {
+		Name string `json:"name" query:"name" uri:"name" header:"X-Name" validate:"required,min=3"`
+	}
+	srv := testutil.NewServer(t)
+	handler := func(source core.BindSource) core.Handler {
+		return func(ctx core.Context) error {
+			in, err := core.BindValidated[input](ctx, core.BindOptions{Source: source})
+			if core.HandleError(ctx, err) {
+				return nil
+			}
+			return ctx.SendString(in.Name)
+		}
+	}
+	if err := srv.POST("/body", handler(core.BindSourceBody)); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+	if err := srv.GET("/query", handler(core.BindSourceQuery)); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	if err := srv.GET("/params/:name", handler(core.BindSourceParams)); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	if err := srv.GET("/headers", handler(core.BindSourceHeaders)); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	newRequest := func(source, name string) *http.Request {
+		switch source {
+		case "body":
+			req := httptest.NewRequest(http.MethodPost, "/body", strings.NewReader(`{"name":"`+name+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			return req
+		case "query":
+			return httptest.NewRequest(http.MethodGet, "/query?name="+name, nil)
+		case "params":
+			return httptest.NewRequest(http.MethodGet, "/params/"+name, nil)
+		default:
+			req := httptest.NewRequest(http.MethodGet, "/headers", nil)
+			req.Header.Set("X-Name", name)
+			return req
+		}
+	}
+
+	for _, source := range []string{"body", "query", "params", "headers"} {
+		t.Run(source, func(t *testing.T) {
+			resp, body := testutil.Send(t, srv, newRequest(source, "alice"))
+			if resp.StatusCode != http.StatusOK || body != "alice" {
+				t.Errorf("valid input: status = %d, body = %q, want 200 alice", resp.StatusCode, body)
+			}
+
+			resp, body = testutil.Send(t, srv, newRequest(source, "al"))
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("invalid input: status = %d, want 400", resp.StatusCode)
+			}
+			var got struct {
+				Code    string `json:"code"`
+				Details struct {
+					Errors []struct {
+						Field string `json:"field"`
+					} `json:"errors"`
+				} `json:"details"`
+			}
+			if err := jcodec.Unmarshal([]byte(body), &got); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", body, err)
+			}
+			if got.Code != "VALIDATION_FAILED" || len(got.Details.Errors) != 1 || got.Details.Errors[0].Field != "name" {
+				t.Errorf("invalid input: body = %s, want VALIDATION_FAILED with one name error", body)
+			}
+		})
+	}
+
+	t.Run("parse error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/body", strings.NewReader(`{"name":`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, body := testutil.Send(t, srv, req)
+		if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "BAD_REQUEST") {
+			t.Errorf("status = %d, body = %s, want 400 BAD_REQUEST", resp.StatusCode, body)
+		}
+	})
+}
+
+func TestBindBody_Server_DeleteWithBody(t *testing.T) {
+	srv := testutil.NewServer(t)
+	type deleteRequest struct {
+		IDs    []int  `json:"ids"`
+		Reason string `json:"reason"`
+	}
+	var got deleteRequest
+	err := srv.DELETE("/items", func(ctx core.Context) error {
+		req, err := core.BindBody[deleteRequest](ctx, false)
+		if err != nil {
+			return err
+		}
+		got = req
+		return ctx.NoContent()
+	})
+	if err != nil {
+		t.Fatalf("DELETE() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/items", strings.NewReader(`{"ids":[1,2,3],"reason":"cleanup"}`))
+	req.Header.Set(core.HeaderContentType, core.MIMEApplicationJSON)
+	if resp, _ := testutil.Send(t, srv, req); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if len(got.IDs) != 3 || got.IDs[2] != 3 || got.Reason != "cleanup" {
+		t.Errorf("bound body = %+v, want ids [1 2 3] and reason cleanup", got)
+	}
+}
@@ -206,6 +206,32 @@ func TestHandleBindError_ValidationError(t *testing.T) {
 	assert.Equal(t, StatusBadRequest, mockCtx.ResponseStatusCode())
 }
 
+func TestHandleBindError_CollapseValidationErrors(t *testing.T) {
+	errs := validator.ValidationErrors{
+		{Field: "email", Message: "must be at least 5 characters", Rule: "min", Value: "ab"},
+		{Field: "email", Message: "must be a valid email address", Rule: "email", Value: "ab"},
+		{Field: "age", Message: "must be at least 18", Rule: "min", Value: 12},
+	}
+
+	full := NewMockContext()
+	handleBindError(full, errs)
+	resp, ok := full.responseData.(*ErrorResponse)
+	require.True(t, ok)
+	assert.Len(t, resp.Details["errors"], 3, "the full list is kept when the option is off")
+
+	collapsed := NewMockContext()
+	collapsed.collapseValidation = true
+	handleBindError(collapsed, errs)
+	resp, ok = collapsed.responseData.(*ErrorResponse)
+	require.True(t, ok)
+	entries, ok := resp.Details["errors"].([]map[string]any)
+	require.True(t, ok)
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]any{"field": "email", "message": "must be at least 5 characters", "rule": "min", "value": "ab"}, entries[0])
+	assert.Equal(t, "min", entries[1]["rule"])
+	assert.Equal(t, 12, entries[1]["value"])
+}
+
 func TestHandleBindError_GenericError(t *testing.T) {
 	mockCtx := NewMockContext()
 
@@ -213,3 +239,79 @@ func TestHandleBindError_GenericError(t *testing.T) {
 
 	assert.Equal(t, StatusBadRequest, mockCtx.ResponseStatusCode())
 }
+
+// BindPatch Tests
+
+func TestBindPatch_TracksPresentFields(t *testing.T) {
+	mockCtx := NewMockContext()
+	mockCtx.SetBodyJSON(map[string]any{"name": "x"})
+
+	type patchRequest struct {
+		Name  string `json:"name" validate:"required"`
+		Email string `json:"email" validate:"required,email"`
+	}
+	result, fields, err := BindPatch[patchRequest](mockCtx)
+	require.NoError(t, err, "omitted required fields must not fail a patch")
+	assert.Equal(t, "x", result.Name)
+	assert.True(t, fields.Has("name"))
+	assert.False(t, fields.Has("email"))
+	assert.Equal(t, []string{"name"}, fields.Keys())
+}
+
+func TestBindPatch_ValidatesPresentFields(t *testing.T) {
+	mockCtx := NewMockContext()
+	mockCtx.SetBodyJSON(map[string]any{"email": "not-an-email"})
+
+	_, fields, err := BindPatch[BindTestRequest](mockCtx)
+	require.Error(t, err)
+	assert.True(t, fields.Has("email"))
+
+	var validationErrs validator.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	require.Len(t, validationErrs, 1)
+	assert.Equal(t, "email", validationErrs[0].Field)
+}
+
+func TestBindPatch_RejectsNonObject(t *testing.T) {
+	mockCtx := NewMockContext()
+	mockCtx.SetBodyJSON([]string{"name"})
+
+	_, _, err := BindPatch[BindTestRequest](mockCtx)
+	require.Error(t, err)
+}
+
+// BindValidated Tests
+
+func TestBindValidated_AlwaysValidates(t *testing.T) {
+	mockCtx := NewMockContext()
+	mockCtx.AddQuery("name", "Jo")
+	mockCtx.AddQuery("email", "john@example.com")
+
+	_, err := BindValidated[BindTestRequest](mockCtx, BindOptions{Source: BindSourceQuery, Validate: false})
+	require.Error(t, err)
+	var resp *ErrorResponse
+	require.ErrorAs(t, err, &resp)
+	assert.Equal(t, "VALIDATION_FAILED", resp.Code)
+	assert.Equal(t, StatusBadRequest, resp.HTTPStatus)
+}
+
+func TestBindValidated_ParseError(t *testing.T) {
+	mockCtx := NewMockContext()
+	mockCtx.SetBodyParseError("invalid JSON")
+
+	_, err := BindValidated[BindTestRequest](mockCtx)
+	var resp *ErrorResponse
+	require.ErrorAs(t, err, &resp)
+	assert.Equal(t, "BAD_REQUEST", resp.Code)
+	assert.Nil(t, mockCtx.responseData, "nothing is sent until the caller handles the error")
+}
+
+func TestBindValidated_Success(t *testing.T) {
+	mockCtx := NewMockContext()
+	mockCtx.AddParam("name", "John Doe")
+	mockCtx.AddParam("email", "john@example.com")
+
+	result, err := BindValidated[BindTestRequest](mockCtx, BindOptions{Source: BindSourceParams})
+	require.NoError(t, err)
+	assert.Equal(t, "John Doe", result.Name)
+}
@@ -7,41 +7,74 @@ import "net/http"
 // HTTP Status Codes -- only codes used by the framework are aliased here.
 // For other status codes, use net/http directly (e.g., http.StatusTeapot).
 const (
-	StatusOK                  = http.StatusOK
-	StatusCreated             = http.StatusCreated
-	StatusAccepted            = http.StatusAccepted
-	StatusNoContent           = http.StatusNoContent
-	StatusBadRequest          = http.StatusBadRequest
-	StatusUnauthorized        = http.StatusUnauthorized
-	StatusForbidden           = http.StatusForbidden
-	StatusNotFound            = http.StatusNotFound
-	StatusConflict            = http.StatusConflict
-	StatusUnprocessableEntity = http.StatusUnprocessableEntity
-	StatusTooManyRequests     = http.StatusTooManyRequests
-	StatusInternalServerError = http.StatusInternalServerError
-	StatusServiceUnavailable  = http.StatusServiceUnavailable
-	StatusGatewayTimeout      = http.StatusGatewayTimeout
+	StatusOK                    = http.StatusOK
+	StatusCreated               = http.StatusCreated
+	StatusAccepted              = http.StatusAccepted
+	StatusNoContent             = http.StatusNoContent
+	StatusPartialContent        = http.StatusPartialContent
+	StatusMultiStatus           = http.StatusMultiStatus
+	StatusMovedPermanently      = http.StatusMovedPermanently
+	StatusFound                 = http.StatusFound
+	StatusSeeOther              = http.StatusSeeOther
+	StatusTemporaryRedirect     = http.StatusTemporaryRedirect
+	StatusPermanentRedirect     = http.StatusPermanentRedirect
+	StatusBadRequest            = http.StatusBadRequest
+	StatusUnauthorized          = http.StatusUnauthorized
+	StatusForbidden             = http.StatusForbidden
+	StatusNotFound              = http.StatusNotFound
+	StatusConflict              = http.StatusConflict
+	StatusRequestEntityTooLarge = http.StatusRequestEntityTooLarge
+	StatusUnprocessableEntity   = http.StatusUnprocessableEntity
+	StatusTooManyRequests       = http.StatusTooManyRequests
+	StatusInternalServerError   = http.StatusInternalServerError
+	StatusServiceUnavailable    = http.StatusServiceUnavailable
+	StatusGatewayTimeout        = http.StatusGatewayTimeout
 )
 
 // HTTP Headers
 const (
-	HeaderRequestID       = "X-Request-ID"
-	HeaderTraceID         = "X-Trace-ID"
-	HeaderCorrelationID   = "X-Correlation-ID"
-	HeaderUserAgent       = "User-Agent"
-	HeaderContentType     = "Content-Type"
-	HeaderContentLength   = "Content-Length"
-	HeaderAuthorization   = "Authorization"
-	HeaderAccept          = "Accept"
-	HeaderAcceptEncoding  = "Accept-Encoding"
-	HeaderAcceptLanguage  = "Accept-Language"
-	HeaderCacheControl    = "Cache-Control"
-	HeaderXForwardedFor   = "X-Forwarded-For"
-	HeaderXForwardedProto = "X-Forwarded-Proto"
-	HeaderXForwardedHost  = "X-Forwarded-Host"
-	HeaderXRealIP         = "X-Real-IP"
-	HeaderXB3TraceID      = "X-B3-TraceId"
-	HeaderTraceparent     = "traceparent"
+	HeaderRequestID           = "X-Request-ID"
+	HeaderTraceID             = "X-Trace-ID"
+	HeaderCorrelationID       = "X-Correlation-ID"
+	HeaderUserAgent           = "User-Agent"
+	HeaderContentType         = "Content-Type"
+	HeaderContentLength       = "Content-Length"
+	HeaderContentDisposition  = "Content-Disposition"
+	HeaderAuthorization       = "Authorization"
+	HeaderAccept              = "Accept"
+	HeaderAcceptEncoding      = "Accept-Encoding"
+	HeaderAcceptLanguage      = "Accept-Language"
+	HeaderAcceptVersion       = "Accept-Version"
+	HeaderVary                = "Vary"
+	HeaderCacheControl        = "Cache-Control"
+	HeaderXForwardedFor       = "X-Forwarded-For"
+	HeaderXForwardedProto     = "X-Forwarded-Proto"
+	HeaderXForwardedHost      = "X-Forwarded-Host"
+	HeaderXRealIP             = "X-Real-IP"
+	HeaderXB3TraceID          = "X-B3-TraceId"
+	HeaderTraceparent         = "traceparent"
+	HeaderXHTTPMethodOverride = "X-HTTP-Method-Override"
+	HeaderXRequestTimeout     = "X-Request-Timeout"
+	HeaderXAccelBuffering     = "X-Accel-Buffering"
+	HeaderRetryAfter          = "Retry-After"
+	HeaderSetCookie           = "Set-Cookie"
+)
+
+// Content Types
+const (
+	MIMEApplicationJSON  = "application/json"
+	MIMEApplicationForm  = "application/x-www-form-urlencoded"
+	MIMEApplicationXML   = "application/xml"
+	MIMETextPlain        = "text/plain"
+	MIMETextPlainUTF8    = "text/plain; charset=utf-8"
+	MIMETextHTML         = "text/html"
+	MIMETextHTMLUTF8     = "text/html; charset=utf-8"
+	MIMEApplicationYAML  = "application/yaml"
+	MIMEApplicationXYAML = "application/x-yaml"
+	MIMETextYAML         = "text/yaml"
+	MIMETextXYAML        = "text/x-yaml"
+	MIMEOctetStream      = "application/octet-stream"
+	MIMETextEventStream  = "text/event-stream"
 )
 
 // Response Messages
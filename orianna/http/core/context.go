@@ -25,6 +25,9 @@ type RequestInfo interface {
 	Protocol() string
 	Hostname() string
 	IP() string
+	// IPInfo returns the socket peer, the X-Forwarded-For chain and the client
+	// IP resolved through Config.TrustedProxies.
+	IPInfo() IPInfo
 	Secure() bool
 }
 
@@ -54,6 +57,10 @@ type QueryGetter interface {
 type BodyReader interface {
 	Body() []byte
 	BodyParser(out any) error
+	SaveUploadedFile(field, dstPath string, progress ...UploadProgressFunc) (int64, error)
+	// NDJSON decodes a newline-delimited JSON body one record at a time.
+	// fn drives the loop by calling decode until it returns io.EOF.
+	NDJSON(fn func(decode func(v any) error) error) error
 }
 
 // CookieManager handles cookies
@@ -78,6 +85,21 @@ type ResponseWriter interface {
 	// SendFile transfers a file from the filesystem as the response.
 	// Content-Type is automatically detected from the file extension.
 	SendFile(file string) error
+	// Attachment sends data as a file download named filename
+	// (Content-Disposition: attachment). An empty contentType defaults to application/octet-stream.
+	Attachment(filename, contentType string, data []byte) error
+	// AttachmentStream streams a file download produced by fn without buffering it in memory.
+	// fn runs after the handler returns; an error from fn truncates the body.
+	AttachmentStream(filename, contentType string, fn func(w io.Writer) error) error
+}
+
+// ResponseReader provides read access to the response written so far
+type ResponseReader interface {
+	// ResponseBody returns the buffered response body (nil for streamed bodies).
+	// The slice is only valid until the request completes; copy it to retain it.
+	ResponseBody() []byte
+	// ResponseHeaders returns the response headers set so far.
+	ResponseHeaders() map[string][]string
 }
 
 // ContentNegotiator handles content negotiation
@@ -105,6 +127,11 @@ type LocalsStorage interface {
 type ShorthandResponder interface {
 	OK(data any) error
 	Created(data any) error
+	Accepted(data any) error
+	PartialContent(data any) error
+	// JSONStatus sends data in the success envelope with any status.
+	// Statuses of 400 and above follow UseProperHTTPStatus (see SendStatus).
+	JSONStatus(status int, data any) error
 	NoContent() error
 	BadRequestMsg(message string) error
 	UnauthorizedMsg(message string) error
@@ -126,6 +153,7 @@ type Context interface {
 	BodyReader
 	CookieManager
 	ResponseWriter
+	ResponseReader
 	ContentNegotiator
 	RequestState
 	LocalsStorage
@@ -146,8 +174,20 @@ type Context interface {
 
 	// Configuration access
 	UseProperHTTPStatus() bool
+	CollapseValidationErrors() bool
 }
 
+// BaseContext is Context under another name, for embedding in wrappers such as
+// those returned by a server.WithContextFactory factory. A struct embedding
+// Context gets a field named Context that hides the Context() method; one
+// embedding BaseContext promotes every method, so it only overrides the ones
+// it declares.
+//
+//	type appContext struct{ core.BaseContext }
+//
+//	func (c appContext) UserID() string { return c.Get("X-User-ID") }
+type BaseContext = Context
+
 // Validation Helpers
 
 // ValidateAndRespond validates the given value and sends an error response if invalid.
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestContext_Server_JSONStatus(t *testing.T) {
+	for _, proper := range []bool{false, true} {
+		conf := &configuration.Config{ServiceName: "test", UseProperHTTPStatus: proper}
+		srv, err := server.NewServer(conf, server.WithMiddlewareConfig(&configuration.MiddlewareConfig{DisableCache: true}))
+		if err != nil {
+			t.Fatalf("NewServer() error = %v", err)
+		}
+		routes := map[string]core.Handler{
+			"/accepted": func(ctx core.Context) error { return ctx.Accepted(core.Map{"job_id": "j-1"}) },
+			"/partial":  func(ctx core.Context) error { return ctx.PartialContent(core.Map{"page": 1}) },
+			"/custom":   func(ctx core.Context) error { return ctx.JSONStatus(http.StatusAlreadyReported, core.Map{"n": 2}) },
+			"/conflict": func(ctx core.Context) error { return ctx.JSONStatus(http.StatusConflict, core.Map{"id": 7}) },
+		}
+		for path, handler := range routes {
+			if err := srv.GET(path, handler); err != nil {
+				t.Fatalf("GET(%s) error = %v", path, err)
+			}
+		}
+
+		tests := []struct {
+			path       string
+			wantStatus int
+			wantBody   int
+			wantKey    string
+		}{
+			{"/accepted", http.StatusAccepted, http.StatusAccepted, "job_id"},
+			{"/partial", http.StatusPartialContent, http.StatusPartialContent, "page"},
+			{"/custom", http.StatusAlreadyReported, http.StatusAlreadyReported, "n"},
+			// 4xx follows UseProperHTTPStatus like error responses
+			{"/conflict", map[bool]int{false: http.StatusOK, true: http.StatusConflict}[proper], http.StatusConflict, "id"},
+		}
+		for _, tt := range tests {
+			resp, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			var out map[string]any
+			if err := jcodec.Unmarshal([]byte(body), &out); err != nil {
+				t.Fatalf("decode %s: %v", tt.path, err)
+			}
+			data, _ := out["data"].(map[string]any)
+			if resp.StatusCode != tt.wantStatus || out["http_status"] != float64(tt.wantBody) || data[tt.wantKey] == nil {
+				t.Errorf("proper=%v %s: status %d, body %v; want status %d, http_status %d with %s",
+					proper, tt.path, resp.StatusCode, out, tt.wantStatus, tt.wantBody, tt.wantKey)
+			}
+			if out["message"] != http.StatusText(tt.wantBody) {
+				t.Errorf("%s: message = %v, want %q", tt.path, out["message"], http.StatusText(tt.wantBody))
+			}
+		}
+	}
+}
+
+func TestContext_Server_IPInfo(t *testing.T) {
+	newServer := func(trusted []string) (*server.Server, *core.IPInfo) {
+		conf := &configuration.Config{ServiceName: "test", TrustedProxies: trusted}
+		srv, err := server.NewServer(conf, server.WithMiddlewareConfig(&configuration.MiddlewareConfig{DisableCache: true}))
+		if err != nil {
+			t.Fatalf("NewServer() error = %v", err)
+		}
+		var info core.IPInfo
+		if err := srv.GET("/ip", func(ctx core.Context) error {
+			info = ctx.IPInfo()
+			return ctx.SendString(info.ClientIP)
+		}); err != nil {
+			t.Fatalf("GET() error = %v", err)
+		}
+		return srv, &info
+	}
+	// In-memory test requests come from 0.0.0.0
+	trusting, trustedInfo := newServer([]string{"0.0.0.0", "10.0.0.0/8"})
+	untrusting, untrustedInfo := newServer(nil)
+
+	tests := []struct {
+		name   string
+		server *server.Server
+		info   *core.IPInfo
+		xff    []string
+		chain  []string
+		client string
+	}{
+		{
+			name:   "multi-hop chain through trusted proxies",
+			server: trusting,
+			info:   trustedInfo,
+			xff:    []string{"203.0.113.7, 198.51.100.9", "10.0.0.5"},
+			chain:  []string{"203.0.113.7", "198.51.100.9", "10.0.0.5"},
+			client: "198.51.100.9",
+		},
+		{
+			name:   "every hop trusted",
+			server: trusting,
+			info:   trustedInfo,
+			xff:    []string{"10.1.2.3:4711, 10.0.0.5"},
+			chain:  []string{"10.1.2.3:4711", "10.0.0.5"},
+			client: "10.1.2.3",
+		},
+		{
+			name:   "entry that is not an IP",
+			server: trusting,
+			info:   trustedInfo,
+			xff:    []string{"unknown, 10.0.0.5"},
+			chain:  []string{"unknown", "10.0.0.5"},
+			client: "10.0.0.5",
+		},
+		{
+			name:   "no header",
+			server: trusting,
+			info:   trustedInfo,
+			client: "0.0.0.0",
+		},
+		{
+			name:   "untrusted peer",
+			server: untrusting,
+			info:   untrustedInfo,
+			xff:    []string{"203.0.113.7, 10.0.0.5"},
+			chain:  []string{"203.0.113.7", "10.0.0.5"},
+			client: "0.0.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			for _, xff := range tt.xff {
+				req.Header.Add("X-Forwarded-For", xff)
+			}
+			_, body := testutil.Send(t, tt.server, req)
+
+			if body != tt.client || tt.info.ClientIP != tt.client {
+				t.Errorf("ClientIP = %q, want %q", tt.info.ClientIP, tt.client)
+			}
+			if tt.info.PeerIP != "0.0.0.0" {
+				t.Errorf("PeerIP = %q, want 0.0.0.0", tt.info.PeerIP)
+			}
+			if fmt.Sprint(tt.info.ForwardedFor) != fmt.Sprint(tt.chain) || (tt.chain == nil) != (tt.info.ForwardedFor == nil) {
+				t.Errorf("ForwardedFor = %q, want %q", tt.info.ForwardedFor, tt.chain)
+			}
+		})
+	}
+}
@@ -2,21 +2,25 @@ package core
 
 import (
 	"errors"
+	"io"
+	"net"
+	"syscall"
 
 	oerrors "github.com/anthanhphan/gosdk/orianna/shared/errors"
 )
 
 // HTTP-specific sentinel errors.
 var (
-	ErrRouteNotFound  = errors.New("route not found")
-	ErrEmptyRoutePath = errors.New("route path cannot be empty")
-	ErrDuplicateRoute = errors.New("duplicate route")
-	ErrInvalidMethod  = errors.New("invalid HTTP method")
-	ErrEmptyPrefix    = errors.New("prefix cannot be empty")
-	ErrNoRoutes       = errors.New("group must have at least one route")
-	ErrNilValidator   = errors.New("validator cannot be nil")
-	ErrTimeout        = errors.New("request timeout")
-	ErrRateLimited    = errors.New("rate limit exceeded")
+	ErrRouteNotFound     = errors.New("route not found")
+	ErrEmptyRoutePath    = errors.New("route path cannot be empty")
+	ErrDuplicateRoute    = errors.New("duplicate route")
+	ErrInvalidMethod     = errors.New("invalid HTTP method")
+	ErrEmptyPrefix       = errors.New("prefix cannot be empty")
+	ErrNoRoutes          = errors.New("group must have at least one route")
+	ErrNilValidator      = errors.New("validator cannot be nil")
+	ErrTimeout           = errors.New("request timeout")
+	ErrRateLimited       = errors.New("rate limit exceeded")
+	ErrInvalidUploadPath = errors.New("upload path must be relative to the upload directory")
 )
 
 // Re-export shared sentinel errors for convenience.
@@ -37,11 +41,38 @@ func IsRouteError(err error) bool {
 		errors.Is(err, ErrEmptyRoutePath)
 }
 
+// IsClientDisconnect reports whether err means the client went away while the
+// request was being handled or the response written (broken pipe, connection
+// reset or aborted, closed connection). There is nobody left to send an error
+// response to, so such errors are not reported as server failures.
+func IsClientDisconnect(err error) bool {
+	return errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, io.ErrClosedPipe)
+}
+
 // IsValidationRelatedError checks if an error is validation-related.
 func IsValidationRelatedError(err error) bool {
 	return errors.Is(err, ErrNilValidator)
 }
 
+// PanicErrorResponse returns the *ErrorResponse carried by a recovered panic
+// value, if the value is an error that is or wraps one. Panic recovery uses it
+// to answer panic(core.NewErrorResponse(...)) with that error's status and body.
+func PanicErrorResponse(recovered any) (*ErrorResponse, bool) {
+	err, ok := recovered.(error)
+	if !ok {
+		return nil, false
+	}
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp != nil {
+		return errResp, true
+	}
+	return nil, false
+}
+
 // Re-export shared error checkers for convenience.
 var (
 	IsConfigError = oerrors.IsConfigError
@@ -4,6 +4,10 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -72,6 +76,16 @@ func TestIsValidationRelatedError_Nil(t *testing.T) {
 	assert.False(t, IsValidationRelatedError(nil))
 }
 
+func TestIsClientDisconnect(t *testing.T) {
+	pipeErr := &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}
+	assert.True(t, IsClientDisconnect(pipeErr))
+	assert.True(t, IsClientDisconnect(fmt.Errorf("flush response: %w", syscall.ECONNRESET)))
+	assert.True(t, IsClientDisconnect(net.ErrClosed))
+	assert.False(t, IsClientDisconnect(errors.New("broken pipe")))
+	assert.False(t, IsClientDisconnect(ErrTimeout))
+	assert.False(t, IsClientDisconnect(nil))
+}
+
 // Error Wrapping Tests
 
 func TestWrapError_Unwrap(t *testing.T) {
@@ -88,3 +102,22 @@ func TestWrapErrorf_Unwrap(t *testing.T) {
 	assert.True(t, errors.Is(wrapped, original))
 	assert.Contains(t, wrapped.Error(), "formatting")
 }
+
+func TestPanicErrorResponse(t *testing.T) {
+	errResp := NewErrorResponse("NOT_FOUND", StatusNotFound, "not found")
+
+	got, ok := PanicErrorResponse(errResp)
+	assert.True(t, ok)
+	assert.Same(t, errResp, got)
+
+	got, ok = PanicErrorResponse(fmt.Errorf("lookup: %w", errResp))
+	assert.True(t, ok)
+	assert.Same(t, errResp, got)
+
+	_, ok = PanicErrorResponse(errors.New("boom"))
+	assert.False(t, ok)
+	_, ok = PanicErrorResponse("boom")
+	assert.False(t, ok)
+	_, ok = PanicErrorResponse((*ErrorResponse)(nil))
+	assert.False(t, ok)
+}
@@ -39,13 +39,48 @@ func TypedHandler[Req, Resp any](status int, fn func(Context, Req) (Resp, error)
 		}
 
 		// Use pool-based response to avoid heap allocation per request
-		successResp := AcquireSuccessResponse(status, "", resp)
+		successResp := AcquireSuccessResponse(status, "", filterResponse(ctx, resp))
 		sendErr := SendSuccess(ctx, successResp)
 		ReleaseSuccessResponse(successResp)
 		return sendErr
 	}
 }
 
+// ResponseFilter lets a TypedHandler response shape itself for the caller,
+// e.g., to hide admin-only fields from regular users without defining a
+// separate DTO per role. TypedHandler calls FilterFor before serialization and
+// sends its result instead of the response value. Both value and pointer
+// receivers are supported.
+//
+// Example:
+//
+//	type UserResponse struct {
+//	    ID       int    `json:"id"`
+//	    Name     string `json:"name"`
+//	    Internal string `json:"internal,omitempty"` // admins only
+//	}
+//
+//	func (r UserResponse) FilterFor(ctx core.Context) any {
+//	    if role, _ := ctx.Locals("role").(string); role != "admin" {
+//	        r.Internal = ""
+//	    }
+//	    return r
+//	}
+type ResponseFilter interface {
+	FilterFor(ctx Context) any
+}
+
+// filterResponse applies the response's ResponseFilter hook, if any.
+func filterResponse[Resp any](ctx Context, resp Resp) any {
+	if f, ok := any(resp).(ResponseFilter); ok {
+		return f.FilterFor(ctx)
+	}
+	if f, ok := any(&resp).(ResponseFilter); ok {
+		return f.FilterFor(ctx)
+	}
+	return resp
+}
+
 // SimpleHandler creates a handler with full control over the response.
 //
 // Input:
@@ -3,7 +3,9 @@
 package core
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/anthanhphan/gosdk/validator"
@@ -103,6 +105,61 @@ func TestTypedHandler_ValidationError(t *testing.T) {
 	assert.Equal(t, StatusBadRequest, mockCtx.ResponseStatusCode())
 }
 
+type filteredUserResponse struct {
+	ID       int    `json:"id"`
+	Internal string `json:"internal,omitempty"`
+}
+
+func (r filteredUserResponse) FilterFor(ctx Context) any {
+	if role, _ := ctx.Locals("role").(string); role != "admin" {
+		r.Internal = ""
+	}
+	return r
+}
+
+// jsonCaptureContext records the JSON body at send time, before pooled
+// responses are released.
+type jsonCaptureContext struct {
+	*MockContext
+	body []byte
+}
+
+func (c *jsonCaptureContext) Status(status int) Context {
+	c.MockContext.Status(status)
+	return c
+}
+
+func (c *jsonCaptureContext) JSON(data any) error {
+	var err error
+	c.body, err = json.Marshal(data)
+	return err
+}
+
+func TestTypedHandler_ResponseFilter(t *testing.T) {
+	handler := TypedHandler(StatusOK, func(ctx Context, req HandlerTestRequest) (filteredUserResponse, error) {
+		return filteredUserResponse{ID: 7, Internal: "ledger-42"}, nil
+	})
+
+	tests := []struct {
+		role         string
+		wantInternal bool
+	}{
+		{role: "admin", wantInternal: true},
+		{role: "user", wantInternal: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			ctx := &jsonCaptureContext{MockContext: NewMockContext()}
+			ctx.SetBodyJSON(map[string]any{"name": "John Doe"})
+			ctx.Locals("role", tt.role)
+
+			require.NoError(t, handler(ctx))
+			assert.Contains(t, string(ctx.body), `"id":7`)
+			assert.Equal(t, tt.wantInternal, strings.Contains(string(ctx.body), "ledger-42"))
+		})
+	}
+}
+
 // SimpleHandler Tests
 
 func TestSimpleHandler_Success(t *testing.T) {
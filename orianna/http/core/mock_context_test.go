@@ -11,17 +11,18 @@ import (
 
 // MockContext is a simple mock implementation of Context for testing
 type MockContext struct {
-	params          map[string]string
-	queries         map[string]string
-	headers         map[string]string
-	bodyData        []byte
-	bodyParseError  string
-	locals          map[string]any
-	statusCode      int
-	responseData    any
-	method          string
-	path            string
-	useProperStatus bool
+	params             map[string]string
+	queries            map[string]string
+	headers            map[string]string
+	bodyData           []byte
+	bodyParseError     string
+	locals             map[string]any
+	statusCode         int
+	responseData       any
+	method             string
+	path               string
+	useProperStatus    bool
+	collapseValidation bool
 }
 
 func NewMockContext() *MockContext {
@@ -61,6 +62,7 @@ func (m *MockContext) BaseURL() string     { return "http://localhost" }
 func (m *MockContext) Protocol() string    { return "http" }
 func (m *MockContext) Hostname() string    { return "localhost" }
 func (m *MockContext) IP() string          { return "127.0.0.1" }
+func (m *MockContext) IPInfo() IPInfo      { return IPInfo{PeerIP: "127.0.0.1", ClientIP: "127.0.0.1"} }
 func (m *MockContext) Secure() bool        { return false }
 
 // HeaderManager implementation
@@ -141,6 +143,14 @@ func (m *MockContext) BodyParser(out any) error {
 	return json.Unmarshal(m.bodyData, out)
 }
 
+func (m *MockContext) SaveUploadedFile(field, dstPath string, progress ...UploadProgressFunc) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockContext) NDJSON(fn func(decode func(v any) error) error) error {
+	return nil
+}
+
 // CookieManager implementation
 func (m *MockContext) Cookies(key string, defaultValue ...string) string {
 	if len(defaultValue) > 0 {
@@ -193,6 +203,37 @@ func (m *MockContext) SendFile(file string) error {
 	return nil
 }
 
+func (m *MockContext) Attachment(filename, _ string, data []byte) error {
+	m.headers[HeaderContentDisposition] = ContentDisposition(filename)
+	m.responseData = data
+	return nil
+}
+
+func (m *MockContext) AttachmentStream(filename, _ string, fn func(io.Writer) error) error {
+	m.headers[HeaderContentDisposition] = ContentDisposition(filename)
+	m.responseData = fn
+	return nil
+}
+
+func (m *MockContext) ResponseBody() []byte {
+	switch v := m.responseData.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	default:
+		return nil
+	}
+}
+
+func (m *MockContext) ResponseHeaders() map[string][]string {
+	result := make(map[string][]string, len(m.headers))
+	for k, v := range m.headers {
+		result[k] = []string{v}
+	}
+	return result
+}
+
 func (m *MockContext) Redirect(location string, status ...int) error {
 	if len(status) > 0 {
 		m.statusCode = status[0]
@@ -260,6 +301,20 @@ func (m *MockContext) Created(data any) error {
 	return nil
 }
 
+func (m *MockContext) Accepted(data any) error {
+	return m.JSONStatus(StatusAccepted, data)
+}
+
+func (m *MockContext) PartialContent(data any) error {
+	return m.JSONStatus(StatusPartialContent, data)
+}
+
+func (m *MockContext) JSONStatus(status int, data any) error {
+	m.statusCode = status
+	m.responseData = data
+	return nil
+}
+
 func (m *MockContext) NoContent() error {
 	m.statusCode = StatusNoContent
 	return nil
@@ -319,3 +374,7 @@ func (m *MockContext) RequestID() string {
 func (m *MockContext) UseProperHTTPStatus() bool {
 	return m.useProperStatus
 }
+
+func (m *MockContext) CollapseValidationErrors() bool {
+	return m.collapseValidation
+}
@@ -84,6 +84,20 @@ func (mr *MockRequestInfoMockRecorder) IP() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IP", reflect.TypeOf((*MockRequestInfo)(nil).IP))
 }
 
+// IPInfo mocks base method.
+func (m *MockRequestInfo) IPInfo() core.IPInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPInfo")
+	ret0, _ := ret[0].(core.IPInfo)
+	return ret0
+}
+
+// IPInfo indicates an expected call of IPInfo.
+func (mr *MockRequestInfoMockRecorder) IPInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPInfo", reflect.TypeOf((*MockRequestInfo)(nil).IPInfo))
+}
+
 // Method mocks base method.
 func (m *MockRequestInfo) Method() string {
 	m.ctrl.T.Helper()
@@ -448,6 +462,40 @@ func (mr *MockBodyReaderMockRecorder) BodyParser(out any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BodyParser", reflect.TypeOf((*MockBodyReader)(nil).BodyParser), out)
 }
 
+// NDJSON mocks base method.
+func (m *MockBodyReader) NDJSON(fn func(func(any) error) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NDJSON", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NDJSON indicates an expected call of NDJSON.
+func (mr *MockBodyReaderMockRecorder) NDJSON(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NDJSON", reflect.TypeOf((*MockBodyReader)(nil).NDJSON), fn)
+}
+
+// SaveUploadedFile mocks base method.
+func (m *MockBodyReader) SaveUploadedFile(field, dstPath string, progress ...core.UploadProgressFunc) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{field, dstPath}
+	for _, a := range progress {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveUploadedFile", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveUploadedFile indicates an expected call of SaveUploadedFile.
+func (mr *MockBodyReaderMockRecorder) SaveUploadedFile(field, dstPath any, progress ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{field, dstPath}, progress...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUploadedFile", reflect.TypeOf((*MockBodyReader)(nil).SaveUploadedFile), varargs...)
+}
+
 // MockCookieManager is a mock of CookieManager interface.
 type MockCookieManager struct {
 	ctrl     *gomock.Controller
@@ -543,6 +591,34 @@ func (m *MockResponseWriter) EXPECT() *MockResponseWriterMockRecorder {
 	return m.recorder
 }
 
+// Attachment mocks base method.
+func (m *MockResponseWriter) Attachment(filename, contentType string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attachment", filename, contentType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attachment indicates an expected call of Attachment.
+func (mr *MockResponseWriterMockRecorder) Attachment(filename, contentType, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attachment", reflect.TypeOf((*MockResponseWriter)(nil).Attachment), filename, contentType, data)
+}
+
+// AttachmentStream mocks base method.
+func (m *MockResponseWriter) AttachmentStream(filename, contentType string, fn func(io.Writer) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachmentStream", filename, contentType, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachmentStream indicates an expected call of AttachmentStream.
+func (mr *MockResponseWriterMockRecorder) AttachmentStream(filename, contentType, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachmentStream", reflect.TypeOf((*MockResponseWriter)(nil).AttachmentStream), filename, contentType, fn)
+}
+
 // JSON mocks base method.
 func (m *MockResponseWriter) JSON(data any) error {
 	m.ctrl.T.Helper()
@@ -679,6 +755,58 @@ func (mr *MockResponseWriterMockRecorder) XML(data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XML", reflect.TypeOf((*MockResponseWriter)(nil).XML), data)
 }
 
+// MockResponseReader is a mock of ResponseReader interface.
+type MockResponseReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockResponseReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockResponseReaderMockRecorder is the mock recorder for MockResponseReader.
+type MockResponseReaderMockRecorder struct {
+	mock *MockResponseReader
+}
+
+// NewMockResponseReader creates a new mock instance.
+func NewMockResponseReader(ctrl *gomock.Controller) *MockResponseReader {
+	mock := &MockResponseReader{ctrl: ctrl}
+	mock.recorder = &MockResponseReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResponseReader) EXPECT() *MockResponseReaderMockRecorder {
+	return m.recorder
+}
+
+// ResponseBody mocks base method.
+func (m *MockResponseReader) ResponseBody() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResponseBody")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// ResponseBody indicates an expected call of ResponseBody.
+func (mr *MockResponseReaderMockRecorder) ResponseBody() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseBody", reflect.TypeOf((*MockResponseReader)(nil).ResponseBody))
+}
+
+// ResponseHeaders mocks base method.
+func (m *MockResponseReader) ResponseHeaders() map[string][]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResponseHeaders")
+	ret0, _ := ret[0].(map[string][]string)
+	return ret0
+}
+
+// ResponseHeaders indicates an expected call of ResponseHeaders.
+func (mr *MockResponseReaderMockRecorder) ResponseHeaders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseHeaders", reflect.TypeOf((*MockResponseReader)(nil).ResponseHeaders))
+}
+
 // MockContentNegotiator is a mock of ContentNegotiator interface.
 type MockContentNegotiator struct {
 	ctrl     *gomock.Controller
@@ -1058,6 +1186,20 @@ func (m *MockContext) EXPECT() *MockContextMockRecorder {
 	return m.recorder
 }
 
+// Accepted mocks base method.
+func (m *MockContext) Accepted(data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accepted", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accepted indicates an expected call of Accepted.
+func (mr *MockContextMockRecorder) Accepted(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accepted", reflect.TypeOf((*MockContext)(nil).Accepted), data)
+}
+
 // Accepts mocks base method.
 func (m *MockContext) Accepts(offers ...string) string {
 	m.ctrl.T.Helper()
@@ -1175,6 +1317,34 @@ func (mr *MockContextMockRecorder) Append(field any, values ...any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockContext)(nil).Append), varargs...)
 }
 
+// Attachment mocks base method.
+func (m *MockContext) Attachment(filename, contentType string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attachment", filename, contentType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attachment indicates an expected call of Attachment.
+func (mr *MockContextMockRecorder) Attachment(filename, contentType, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attachment", reflect.TypeOf((*MockContext)(nil).Attachment), filename, contentType, data)
+}
+
+// AttachmentStream mocks base method.
+func (m *MockContext) AttachmentStream(filename, contentType string, fn func(io.Writer) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachmentStream", filename, contentType, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachmentStream indicates an expected call of AttachmentStream.
+func (mr *MockContextMockRecorder) AttachmentStream(filename, contentType, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachmentStream", reflect.TypeOf((*MockContext)(nil).AttachmentStream), filename, contentType, fn)
+}
+
 // BadRequestMsg mocks base method.
 func (m *MockContext) BadRequestMsg(message string) error {
 	m.ctrl.T.Helper()
@@ -1247,6 +1417,20 @@ func (mr *MockContextMockRecorder) ClearCookie(key ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCookie", reflect.TypeOf((*MockContext)(nil).ClearCookie), key...)
 }
 
+// CollapseValidationErrors mocks base method.
+func (m *MockContext) CollapseValidationErrors() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollapseValidationErrors")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CollapseValidationErrors indicates an expected call of CollapseValidationErrors.
+func (mr *MockContextMockRecorder) CollapseValidationErrors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollapseValidationErrors", reflect.TypeOf((*MockContext)(nil).CollapseValidationErrors))
+}
+
 // Context mocks base method.
 func (m *MockContext) Context() context.Context {
 	m.ctrl.T.Helper()
@@ -1409,6 +1593,20 @@ func (mr *MockContextMockRecorder) IP() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IP", reflect.TypeOf((*MockContext)(nil).IP))
 }
 
+// IPInfo mocks base method.
+func (m *MockContext) IPInfo() core.IPInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPInfo")
+	ret0, _ := ret[0].(core.IPInfo)
+	return ret0
+}
+
+// IPInfo indicates an expected call of IPInfo.
+func (mr *MockContextMockRecorder) IPInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPInfo", reflect.TypeOf((*MockContext)(nil).IPInfo))
+}
+
 // InternalErrorMsg mocks base method.
 func (m *MockContext) InternalErrorMsg(message string) error {
 	m.ctrl.T.Helper()
@@ -1451,6 +1649,20 @@ func (mr *MockContextMockRecorder) JSON(data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JSON", reflect.TypeOf((*MockContext)(nil).JSON), data)
 }
 
+// JSONStatus mocks base method.
+func (m *MockContext) JSONStatus(status int, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JSONStatus", status, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// JSONStatus indicates an expected call of JSONStatus.
+func (mr *MockContextMockRecorder) JSONStatus(status, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JSONStatus", reflect.TypeOf((*MockContext)(nil).JSONStatus), status, data)
+}
+
 // Locals mocks base method.
 func (m *MockContext) Locals(key string, value ...any) any {
 	m.ctrl.T.Helper()
@@ -1484,6 +1696,20 @@ func (mr *MockContextMockRecorder) Method() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Method", reflect.TypeOf((*MockContext)(nil).Method))
 }
 
+// NDJSON mocks base method.
+func (m *MockContext) NDJSON(fn func(func(any) error) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NDJSON", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NDJSON indicates an expected call of NDJSON.
+func (mr *MockContextMockRecorder) NDJSON(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NDJSON", reflect.TypeOf((*MockContext)(nil).NDJSON), fn)
+}
+
 // Next mocks base method.
 func (m *MockContext) Next() error {
 	m.ctrl.T.Helper()
@@ -1587,6 +1813,20 @@ func (mr *MockContextMockRecorder) ParamsParser(out any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParamsParser", reflect.TypeOf((*MockContext)(nil).ParamsParser), out)
 }
 
+// PartialContent mocks base method.
+func (m *MockContext) PartialContent(data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartialContent", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PartialContent indicates an expected call of PartialContent.
+func (mr *MockContextMockRecorder) PartialContent(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialContent", reflect.TypeOf((*MockContext)(nil).PartialContent), data)
+}
+
 // Path mocks base method.
 func (m *MockContext) Path() string {
 	m.ctrl.T.Helper()
@@ -1681,6 +1921,34 @@ func (mr *MockContextMockRecorder) RequestID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestID", reflect.TypeOf((*MockContext)(nil).RequestID))
 }
 
+// ResponseBody mocks base method.
+func (m *MockContext) ResponseBody() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResponseBody")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// ResponseBody indicates an expected call of ResponseBody.
+func (mr *MockContextMockRecorder) ResponseBody() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseBody", reflect.TypeOf((*MockContext)(nil).ResponseBody))
+}
+
+// ResponseHeaders mocks base method.
+func (m *MockContext) ResponseHeaders() map[string][]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResponseHeaders")
+	ret0, _ := ret[0].(map[string][]string)
+	return ret0
+}
+
+// ResponseHeaders indicates an expected call of ResponseHeaders.
+func (mr *MockContextMockRecorder) ResponseHeaders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseHeaders", reflect.TypeOf((*MockContext)(nil).ResponseHeaders))
+}
+
 // ResponseStatusCode mocks base method.
 func (m *MockContext) ResponseStatusCode() int {
 	m.ctrl.T.Helper()
@@ -1709,6 +1977,26 @@ func (mr *MockContextMockRecorder) RoutePath() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoutePath", reflect.TypeOf((*MockContext)(nil).RoutePath))
 }
 
+// SaveUploadedFile mocks base method.
+func (m *MockContext) SaveUploadedFile(field, dstPath string, progress ...core.UploadProgressFunc) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{field, dstPath}
+	for _, a := range progress {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveUploadedFile", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveUploadedFile indicates an expected call of SaveUploadedFile.
+func (mr *MockContextMockRecorder) SaveUploadedFile(field, dstPath any, progress ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{field, dstPath}, progress...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUploadedFile", reflect.TypeOf((*MockContext)(nil).SaveUploadedFile), varargs...)
+}
+
 // Secure mocks base method.
 func (m *MockContext) Secure() bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"slices"
+)
+
+// WrapHTTPHandler mounts a net/http handler as a Handler, e.g., to reuse an
+// existing handler or a third-party endpoint. The handler gets a request built
+// from the Context (method, URL, headers, body, the socket peer's "ip:port"
+// as RemoteAddr and ctx.Context()); its status, headers and body become the response, so
+// response hooks and metrics see its status.
+//
+// The response is buffered and sent once the handler returns: streaming
+// responses (server-sent events, http.Flusher) are delivered in one piece and
+// http.Hijacker is not supported.
+//
+// Example:
+//
+//	srv.GET("/legacy/report", core.WrapHTTPHandler(http.HandlerFunc(legacyReport)))
+func WrapHTTPHandler(h http.Handler) Handler {
+	return func(ctx Context) error {
+		req, err := newHTTPRequest(ctx)
+		if err != nil {
+			return err
+		}
+		w := newBufferedResponseWriter()
+		h.ServeHTTP(w, req)
+		return w.writeTo(ctx)
+	}
+}
+
+// WrapHTTPMiddleware mounts net/http middleware, e.g., from gorilla/handlers,
+// as a Middleware. The middleware gets a request built from the Context like
+// WrapHTTPHandler's; when it calls the next handler, the rest of the chain
+// runs on the same Context, so Locals set before it are kept, headers it set
+// on the response so far are applied, and a context it attached with
+// r.WithContext becomes ctx.Context(). The downstream response is then
+// written through the middleware's ResponseWriter, so wrappers that observe
+// or rewrite the status, headers or body see it. If the middleware answers
+// without calling next, its response is sent instead.
+//
+// Other changes to the request (headers, URL, RemoteAddr) are not passed on
+// to the chain. The response is buffered, so streaming responses are
+// delivered in one piece and http.Hijacker is not supported. An error
+// returned downstream skips the write-through and is returned as is.
+//
+// Example:
+//
+//	srv.Use(core.WrapHTTPMiddleware(func(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        w.Header().Set("X-Frame-Options", "DENY")
+//	        next.ServeHTTP(w, r)
+//	    })
+//	}))
+func WrapHTTPMiddleware(mw func(http.Handler) http.Handler) Middleware {
+	return func(ctx Context) error {
+		req, err := newHTTPRequest(ctx)
+		if err != nil {
+			return err
+		}
+
+		var called bool
+		var nextErr error
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			ctx.SetContext(r.Context())
+			for key, values := range w.Header() {
+				setHeader(ctx, key, values)
+			}
+			if nextErr = ctx.Next(); nextErr != nil {
+				return
+			}
+			for key, values := range ctx.ResponseHeaders() {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(ctx.ResponseStatusCode())
+			_, _ = w.Write(ctx.ResponseBody())
+		})
+
+		w := newBufferedResponseWriter()
+		mw(next).ServeHTTP(w, req)
+		if nextErr != nil {
+			return nextErr
+		}
+		if called && w.status == 0 {
+			// Nothing reached w, so ctx keeps the downstream response as is
+			return nil
+		}
+		return w.writeTo(ctx)
+	}
+}
+
+// newHTTPRequest builds the net/http request a wrapped handler receives.
+func newHTTPRequest(ctx Context) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx.Context(), ctx.Method(), ctx.OriginalURL(), bytes.NewReader(ctx.Body()))
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string][]string{}
+	if err := ctx.HeadersParser(&headers); err != nil {
+		return nil, err
+	}
+	for key, values := range headers {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+	req.Host = ctx.Hostname()
+	req.URL.Host = req.Host
+	req.URL.Scheme = "http"
+	if ctx.Secure() {
+		req.URL.Scheme = "https"
+	}
+	req.RemoteAddr = ctx.IPInfo().PeerAddr
+	if req.RemoteAddr == "" {
+		req.RemoteAddr = net.JoinHostPort(ctx.IP(), "0")
+	}
+	return req, nil
+}
+
+// bufferedResponseWriter collects a wrapped handler's response.
+type bufferedResponseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: http.Header{}}
+}
+
+func (w *bufferedResponseWriter) Header() http.Header { return w.header }
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// writeTo sends the buffered response on ctx. Headers ctx already has with
+// the same values are left alone, so Set-Cookie headers written downstream
+// are not added twice.
+func (w *bufferedResponseWriter) writeTo(ctx Context) error {
+	current := ctx.ResponseHeaders()
+	for key, values := range w.header {
+		if !slices.Equal(current[key], values) {
+			setHeader(ctx, key, values)
+		}
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return ctx.Status(w.status).SendBytes(w.body.Bytes())
+}
+
+// setHeader sets a response header from a net/http header's values. Extra
+// values go through ctx.Append, which keeps each Set-Cookie value a header
+// of its own.
+func setHeader(ctx Context, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	ctx.Set(key, values[0])
+	ctx.Append(key, values[1:]...)
+}
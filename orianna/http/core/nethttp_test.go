@@ -0,0 +1,108 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+)
+
+// statusRecorder is net/http middleware's usual way of observing the status.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func TestWrapHTTP_Server(t *testing.T) {
+	srv := testutil.NewServer(t)
+	type ctxKey struct{}
+	srv.Use(func(ctx core.Context) error {
+		ctx.Locals("tenant", "acme")
+		return ctx.Next()
+	})
+	var observedStatus int
+	srv.Use(core.WrapHTTPMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Deny") != "" {
+				http.Error(w, "denied", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("X-Frame-Options", "DENY")
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), ctxKey{}, "from-net-http")))
+			observedStatus = rec.status
+		})
+	}))
+	_ = srv.GET("/native", func(ctx core.Context) error {
+		value, _ := ctx.Context().Value(ctxKey{}).(string)
+		return ctx.Status(http.StatusAccepted).SendString(fmt.Sprintf("%v %s", ctx.Locals("tenant"), value))
+	})
+	_ = srv.GET("/legacy", core.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Legacy", r.URL.Query().Get("q"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-Client"))
+	})))
+	_ = srv.GET("/cookies", core.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark"})
+		w.Header().Add("X-Multi", "a")
+		w.Header().Add("X-Multi", "b")
+		_, _ = io.WriteString(w, r.RemoteAddr)
+	})))
+
+	get := func(path string, header ...string) (*http.Response, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		return testutil.Send(t, srv, req)
+	}
+
+	resp, body := get("/native")
+	if resp.StatusCode != http.StatusAccepted || body != "acme from-net-http" {
+		t.Errorf("/native: status = %d, body = %q, want 202 \"acme from-net-http\"", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("X-Frame-Options"); got != "DENY" {
+		t.Errorf("/native X-Frame-Options = %q, want DENY", got)
+	}
+	if observedStatus != http.StatusAccepted {
+		t.Errorf("middleware observed status %d, want 202", observedStatus)
+	}
+
+	resp, body = get("/legacy?q=1", "X-Client", "cli")
+	if resp.StatusCode != http.StatusCreated || body != "GET /legacy cli" || resp.Header.Get("X-Legacy") != "1" {
+		t.Errorf("/legacy: status = %d, body = %q, X-Legacy = %q, want 201 \"GET /legacy cli\" 1",
+			resp.StatusCode, body, resp.Header.Get("X-Legacy"))
+	}
+
+	resp, body = get("/cookies")
+	if cookies := resp.Cookies(); len(cookies) != 2 || cookies[0].Value != "abc" || cookies[1].Value != "dark" {
+		t.Errorf("/cookies: Set-Cookie = %q, want two separate cookies", resp.Header.Values("Set-Cookie"))
+	}
+	if got := resp.Header.Get("X-Multi"); got != "a, b" {
+		t.Errorf("/cookies: X-Multi = %q, want \"a, b\"", got)
+	}
+	if _, port, err := net.SplitHostPort(body); err != nil || port == "" {
+		t.Errorf("/cookies: RemoteAddr = %q, want ip:port", body)
+	}
+
+	resp, body = get("/native", "X-Deny", "1")
+	if resp.StatusCode != http.StatusForbidden || !strings.Contains(body, "denied") {
+		t.Errorf("denied: status = %d, body = %q, want 403 denied", resp.StatusCode, body)
+	}
+}
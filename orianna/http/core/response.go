@@ -3,8 +3,11 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"html/template"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -248,6 +251,30 @@ func (e *ErrorResponse) WithHTTPStatus(status int) *ErrorResponse {
 // Sets request ID and timestamp if not already set.
 // Fast path: skips content-negotiation when Accept is empty or application/json (99% of API calls).
 func SendSuccess(ctx Context, resp *SuccessResponse) error {
+	return sendSuccess(ctx, resp, resp.HTTPStatus)
+}
+
+// SendStatus sends data in the success envelope with any status, e.g., 202
+// Accepted or 206 Partial Content. The message is the status text. A status of
+// 400 or above follows UseProperHTTPStatus like SendError: the envelope keeps
+// it, but the response is sent as 200 unless proper statuses are enabled.
+//
+// Example:
+//
+//	return core.SendStatus(ctx, core.StatusAccepted, core.Map{"job_id": id})
+func SendStatus(ctx Context, status int, data any) error {
+	wire := status
+	if status >= StatusBadRequest && !ctx.UseProperHTTPStatus() {
+		wire = StatusOK
+	}
+	resp := AcquireSuccessResponse(status, http.StatusText(status), data)
+	err := sendSuccess(ctx, resp, wire)
+	ReleaseSuccessResponse(resp)
+	return err
+}
+
+// sendSuccess sends resp with the given response status.
+func sendSuccess(ctx Context, resp *SuccessResponse, status int) error {
 	if resp.RequestID == "" {
 		resp.RequestID = ctx.RequestID()
 	}
@@ -258,16 +285,17 @@ func SendSuccess(ctx Context, resp *SuccessResponse) error {
 	// JSON fast-path — skip content negotiation for the common case
 	accept := ctx.Get("Accept")
 	if accept == "" || accept == "application/json" {
-		return ctx.Status(resp.HTTPStatus).JSON(resp)
+		return ctx.Status(status).JSON(resp)
 	}
 	if ctx.Accepts("application/json", "application/xml") == "application/xml" {
-		return ctx.Status(resp.HTTPStatus).XML(resp)
+		return ctx.Status(status).XML(resp)
 	}
-	return ctx.Status(resp.HTTPStatus).JSON(resp)
+	return ctx.Status(status).JSON(resp)
 }
 
-// SendError sends an ErrorResponse as JSON or XML based on Accept header.
-// Sets request ID and timestamp if not already set.
+// SendError sends an ErrorResponse negotiated from the Accept header: JSON
+// (default), XML, plain text, or HTML when an ErrorHTMLTemplate is set for the
+// route. Sets request ID and timestamp if not already set.
 // Logs internal details (InternalMessage, Cause) server-side for audit.
 func SendError(ctx Context, err *ErrorResponse) error {
 	if err.RequestID == "" {
@@ -299,12 +327,73 @@ func SendError(ctx Context, err *ErrorResponse) error {
 	if accept == "" || accept == "application/json" {
 		return ctx.Status(status).JSON(err)
 	}
-	if ctx.Accepts("application/json", "application/xml") == "application/xml" {
+
+	// HTML is only offered when a template is set, so browsers keep their
+	// previous response otherwise
+	offers := errorOffers
+	var tmpl *template.Template
+	if strings.Contains(accept, "html") {
+		if tmpl, _ = ctx.Locals(errorTemplateLocalsKey).(*template.Template); tmpl != nil {
+			offers = errorOffersHTML
+		}
+	}
+	switch ctx.Accepts(offers...) {
+	case MIMEApplicationXML:
 		return ctx.Status(status).XML(err)
+	case MIMETextPlain:
+		ctx.Set(HeaderContentType, MIMETextPlainUTF8)
+		return ctx.Status(status).SendString(errorText(err))
+	case MIMETextHTML:
+		var buf bytes.Buffer
+		if execErr := tmpl.Execute(&buf, err); execErr != nil {
+			responseLog.Warnw("error template failed, falling back to JSON",
+				"request_id", err.RequestID,
+				"cause", execErr.Error(),
+			)
+			break
+		}
+		ctx.Set(HeaderContentType, MIMETextHTMLUTF8)
+		return ctx.Status(status).SendBytes(buf.Bytes())
 	}
 	return ctx.Status(status).JSON(err)
 }
 
+// Error body formats SendError negotiates, in order of preference.
+var (
+	errorOffers     = []string{MIMEApplicationJSON, MIMEApplicationXML, MIMETextPlain}
+	errorOffersHTML = []string{MIMEApplicationJSON, MIMEApplicationXML, MIMETextPlain, MIMETextHTML}
+)
+
+// errorTemplateLocalsKey is the Locals key ErrorHTMLTemplate stores its template under.
+const errorTemplateLocalsKey = "error_html_template"
+
+// ErrorHTMLTemplate returns middleware that lets SendError render errors with
+// tmpl for clients that prefer text/html (e.g., browser form posts). The
+// template is executed with the *ErrorResponse; if it fails, the error is sent
+// as JSON. Apply it globally or to the routes that serve browsers.
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("error").Parse(
+//	    `<h1>{{.HTTPStatus}} {{.Message}}</h1><p>Reference: {{.RequestID}}</p>`))
+//	server.GET("/account", accountPage, core.ErrorHTMLTemplate(tmpl))
+func ErrorHTMLTemplate(tmpl *template.Template) Middleware {
+	return func(ctx Context) error {
+		ctx.Locals(errorTemplateLocalsKey, tmpl)
+		return ctx.Next()
+	}
+}
+
+// errorText renders err as a plain-text body: "CODE: message", followed by
+// the request ID when set.
+func errorText(err *ErrorResponse) string {
+	text := err.Code + ": " + err.Message + "\n"
+	if err.RequestID != "" {
+		text += "request_id: " + err.RequestID + "\n"
+	}
+	return text
+}
+
 // errorString safely converts an error to string, returning empty for nil.
 func errorString(err error) string {
 	if err == nil {
@@ -366,3 +455,80 @@ func WrapError(err error, message string) error {
 func WrapErrorf(err error, format string, args ...any) error {
 	return WrapError(err, fmt.Sprintf(format, args...))
 }
+
+// ============================================================================
+// Attachments
+// ============================================================================
+
+// ContentDisposition builds an "attachment" Content-Disposition header value.
+// The filename is reduced to its base name and stripped of control characters,
+// quotes and backslashes so it cannot inject headers. Non-ASCII names get an
+// ASCII fallback plus an RFC 5987 filename* parameter.
+//
+// Example:
+//
+//	core.ContentDisposition("report.csv")  // attachment; filename="report.csv"
+//	core.ContentDisposition("báo cáo.pdf") // attachment; filename="b_o c_o.pdf"; filename*=UTF-8''b%C3%A1o%20c%C3%A1o.pdf
+func ContentDisposition(filename string) string {
+	name := sanitizeFilename(filename)
+	if name == "" {
+		return "attachment"
+	}
+
+	var ascii strings.Builder
+	needsExtended := false
+	for _, r := range name {
+		if r > 0x7e {
+			ascii.WriteByte('_')
+			needsExtended = true
+			continue
+		}
+		ascii.WriteRune(r)
+	}
+
+	value := `attachment; filename="` + ascii.String() + `"`
+	if needsExtended {
+		value += "; filename*=UTF-8''" + encodeRFC5987(name)
+	}
+	return value
+}
+
+// sanitizeFilename drops any directory part and removes characters that are
+// unsafe inside a quoted header parameter.
+func sanitizeFilename(filename string) string {
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, filename))
+}
+
+// encodeRFC5987 percent-encodes s as an RFC 5987 ext-value (UTF-8 bytes, attr-char kept).
+func encodeRFC5987(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+// isAttrChar reports whether c is an RFC 5987 attr-char.
+func isAttrChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
@@ -258,6 +258,28 @@ func TestWrapErrorf(t *testing.T) {
 	}
 }
 
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		want     string
+	}{
+		{name: "ascii", filename: "report.csv", want: `attachment; filename="report.csv"`},
+		{name: "non-ascii", filename: "báo cáo.pdf", want: `attachment; filename="b_o c_o.pdf"; filename*=UTF-8''b%C3%A1o%20c%C3%A1o.pdf`},
+		{name: "header injection", filename: "a.txt\r\nSet-Cookie: x=1", want: `attachment; filename="a.txtSet-Cookie: x=1"`},
+		{name: "quotes and path", filename: `../../etc/"passwd"`, want: `attachment; filename="passwd"`},
+		{name: "empty", filename: "", want: "attachment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContentDisposition(tt.filename); got != tt.want {
+				t.Errorf("ContentDisposition(%q) = %s, want %s", tt.filename, got, tt.want)
+			}
+		})
+	}
+}
+
 // Helpers
 
 func containsString(s, substr string) bool {
@@ -0,0 +1,257 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// Server-Sent Events
+// ============================================================================
+
+// Event is one Server-Sent Event. Data may span several lines; each line is
+// sent as its own "data:" field. Retry, when set, tells the client how long to
+// wait before reconnecting.
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+	Retry time.Duration
+}
+
+// encode renders e in the text/event-stream wire format.
+func (e Event) encode() []byte {
+	var buf bytes.Buffer
+	if e.ID != "" {
+		buf.WriteString("id: " + sanitizeEventField(e.ID) + "\n")
+	}
+	if e.Event != "" {
+		buf.WriteString("event: " + sanitizeEventField(e.Event) + "\n")
+	}
+	if e.Retry > 0 {
+		buf.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+	for line := range strings.Lines(strings.ReplaceAll(e.Data, "\r\n", "\n")) {
+		buf.WriteString("data: " + strings.TrimSuffix(line, "\n") + "\n")
+	}
+	if e.Data == "" {
+		buf.WriteString("data: \n")
+	}
+	buf.WriteString("\n")
+	return buf.Bytes()
+}
+
+// sanitizeEventField strips line breaks, which would end the field early.
+func sanitizeEventField(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// SlowConsumerPolicy decides what a Hub does when a subscriber's buffer is full.
+type SlowConsumerPolicy int
+
+const (
+	// DropNewest discards the new event for that subscriber (default).
+	DropNewest SlowConsumerPolicy = iota
+	// DropOldest discards the subscriber's oldest buffered event to make room.
+	DropOldest
+	// Disconnect closes the subscriber's channel, ending its stream so the
+	// client reconnects and resynchronizes.
+	Disconnect
+)
+
+// Hub defaults
+const (
+	DefaultHubBuffer    = 16
+	DefaultSSEHeartbeat = 15 * time.Second
+)
+
+// HubOptions configures a Hub.
+type HubOptions struct {
+	// Buffer is the number of events queued per subscriber before Policy
+	// applies (default: DefaultHubBuffer).
+	Buffer int
+	// Policy handles subscribers whose buffer is full (default: DropNewest).
+	Policy SlowConsumerPolicy
+	// Heartbeat is how often SSEHandler sends a comment line on idle streams,
+	// which keeps proxies from timing out and detects disconnected clients
+	// (default: DefaultSSEHeartbeat).
+	Heartbeat time.Duration
+}
+
+// Hub fans events out to many subscribers, e.g., a notifications feed served
+// over SSE. Broadcast never blocks on a slow subscriber; see HubOptions.Policy.
+// A Hub is safe for concurrent use.
+//
+// Example:
+//
+//	hub := core.NewHub(core.HubOptions{Buffer: 64, Policy: core.DropOldest})
+//	server.SSEHub("/notifications", hub, nil)
+//	hub.Broadcast(core.Event{Event: "order.created", Data: payload})
+type Hub struct {
+	opts   HubOptions
+	mu     sync.Mutex
+	subs   map[chan Event]struct{}
+	closed bool
+}
+
+// NewHub creates a Hub.
+func NewHub(opts ...HubOptions) *Hub {
+	opt := HubOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Buffer <= 0 {
+		opt.Buffer = DefaultHubBuffer
+	}
+	if opt.Heartbeat <= 0 {
+		opt.Heartbeat = DefaultSSEHeartbeat
+	}
+	return &Hub{opts: opt, subs: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a subscriber. The channel receives every event
+// broadcast until unsubscribe is called, ctx is done or the Hub is closed;
+// it is then closed. unsubscribe is safe to call more than once.
+func (h *Hub) Subscribe(ctx context.Context) (<-chan Event, func()) {
+	ch := make(chan Event, h.opts.Buffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() { h.remove(ch) })
+	}
+	stop := context.AfterFunc(ctx, unsubscribe)
+	return ch, func() {
+		stop()
+		unsubscribe()
+	}
+}
+
+// remove unregisters and closes ch if it is still subscribed.
+func (h *Hub) remove(ch chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[ch]; ok {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// Broadcast sends event to every subscriber without blocking.
+func (h *Hub) Broadcast(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- event:
+			continue
+		default:
+		}
+		switch h.opts.Policy {
+		case DropOldest:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		case Disconnect:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribers returns the number of active subscribers.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Close ends every subscription. Later subscriptions receive a closed channel
+// and later broadcasts are no-ops.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// SSEHandler creates a handler that streams hub events to the client as
+// text/event-stream. authFn, when not nil, runs first; an error from it is
+// sent like a TypedHandler error and no subscription is made. The stream ends
+// when the client disconnects or the Hub is closed.
+//
+// Middleware that reads the response body (response size metrics, verbose
+// logging) sees an empty body for the stream.
+func SSEHandler(hub *Hub, authFn func(Context) error) Handler {
+	return func(ctx Context) error {
+		if authFn != nil {
+			if err := authFn(ctx); err != nil {
+				return handleTypedError(ctx, err)
+			}
+		}
+
+		ctx.Set(HeaderContentType, MIMETextEventStream)
+		ctx.Set(HeaderCacheControl, "no-cache")
+		ctx.Set(HeaderXAccelBuffering, "no")
+
+		// The stream outlives the handler, so it must not hold the request context
+		events, unsubscribe := hub.Subscribe(context.Background())
+		pr, pw := io.Pipe()
+		go func() {
+			defer unsubscribe()
+			pumpEvents(pw, events, hub.opts.Heartbeat)
+		}()
+		return ctx.SendStream(pr)
+	}
+}
+
+// pumpEvents writes events to w until the channel closes or a write fails,
+// which happens once the server closes the reader after the client goes away.
+func pumpEvents(w *io.PipeWriter, events <-chan Event, heartbeat time.Duration) {
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	// Commit the headers right away so clients see the stream open
+	if _, err := w.Write([]byte(": connected\n\n")); err != nil {
+		return
+	}
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				_ = w.Close()
+				return
+			}
+			if _, err := w.Write(event.encode()); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := w.Write([]byte(": ping\n\n")); err != nil {
+				return
+			}
+		}
+	}
+}
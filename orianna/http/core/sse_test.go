@@ -0,0 +1,114 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, ch <-chan Event) (Event, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}, false
+	}
+}
+
+func TestHub_BroadcastToSubscribers(t *testing.T) {
+	hub := NewHub()
+	first, unsubFirst := hub.Subscribe(context.Background())
+	second, unsubSecond := hub.Subscribe(context.Background())
+	defer unsubSecond()
+
+	hub.Broadcast(Event{Event: "notification", Data: "hello"})
+	for _, ch := range []<-chan Event{first, second} {
+		if event, ok := receive(t, ch); !ok || event.Data != "hello" {
+			t.Errorf("received %+v, %v; want hello", event, ok)
+		}
+	}
+
+	unsubFirst()
+	unsubFirst()
+	if _, ok := receive(t, first); ok {
+		t.Error("channel should be closed after unsubscribe")
+	}
+	if got := hub.Subscribers(); got != 1 {
+		t.Errorf("Subscribers() = %d, want 1", got)
+	}
+}
+
+func TestHub_SubscribeContextCancel(t *testing.T) {
+	hub := NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, _ := hub.Subscribe(ctx)
+	cancel()
+	if _, ok := receive(t, ch); ok {
+		t.Error("channel should be closed once ctx is done")
+	}
+	if got := hub.Subscribers(); got != 0 {
+		t.Errorf("Subscribers() = %d, want 0", got)
+	}
+}
+
+func TestHub_SlowConsumerPolicy(t *testing.T) {
+	tests := []struct {
+		policy SlowConsumerPolicy
+		want   []string
+		closed bool
+	}{
+		{DropNewest, []string{"1", "2"}, false},
+		{DropOldest, []string{"2", "3"}, false},
+		{Disconnect, []string{"1", "2"}, true},
+	}
+	for _, tt := range tests {
+		hub := NewHub(HubOptions{Buffer: 2, Policy: tt.policy})
+		ch, unsubscribe := hub.Subscribe(context.Background())
+		for _, data := range []string{"1", "2", "3"} {
+			hub.Broadcast(Event{Data: data})
+		}
+		for _, want := range tt.want {
+			if event, ok := receive(t, ch); !ok || event.Data != want {
+				t.Errorf("policy %d: received %+v, %v; want %s", tt.policy, event, ok, want)
+			}
+		}
+		if tt.closed {
+			if _, ok := receive(t, ch); ok {
+				t.Errorf("policy %d: channel should be closed", tt.policy)
+			}
+		} else if len(ch) != 0 {
+			t.Errorf("policy %d: %d unexpected events left", tt.policy, len(ch))
+		}
+		unsubscribe()
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe(context.Background())
+	hub.Close()
+	unsubscribe()
+	if _, ok := receive(t, ch); ok {
+		t.Error("channel should be closed by Close")
+	}
+	late, _ := hub.Subscribe(context.Background())
+	if _, ok := receive(t, late); ok {
+		t.Error("Subscribe after Close should return a closed channel")
+	}
+	hub.Broadcast(Event{Data: "ignored"})
+}
+
+func TestEvent_Encode(t *testing.T) {
+	event := Event{ID: "7", Event: "update\n", Data: "line1\nline2", Retry: 3 * time.Second}
+	want := "id: 7\nevent: update\nretry: 3000\ndata: line1\ndata: line2\n\n"
+	if got := string(event.encode()); got != want {
+		t.Errorf("encode() = %q, want %q", got, want)
+	}
+	if got := string((Event{}).encode()); got != "data: \n\n" {
+		t.Errorf("encode() of empty event = %q", got)
+	}
+}
@@ -17,6 +17,11 @@ type Handler func(Context) error
 // The distinction is semantic: middleware chains, handlers terminate.
 type Middleware func(Context) error
 
+// UploadProgressFunc reports upload progress: bytes written so far and the
+// total size of the uploaded file, or -1 when it is not known up front, as for
+// a file streamed from the request body.
+type UploadProgressFunc func(written, total int64)
+
 // Method represents HTTP methods as type-safe constants.
 type Method int
 
@@ -69,3 +74,17 @@ type Cookie struct {
 	HTTPOnly bool
 	SameSite string
 }
+
+// IPInfo describes where a request came from, as returned by ctx.IPInfo.
+type IPInfo struct {
+	// PeerIP is the address of the socket peer: the client, or the last proxy
+	PeerIP string
+	// PeerAddr is the socket peer as "ip:port", like http.Request.RemoteAddr
+	PeerAddr string
+	// ForwardedFor lists the X-Forwarded-For entries, client first, as sent.
+	// It is nil when the header is absent.
+	ForwardedFor []string
+	// ClientIP is the client address resolved through the trusted proxies
+	// (Config.TrustedProxies). It is PeerIP when the peer is not trusted.
+	ClientIP string
+}
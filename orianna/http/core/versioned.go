@@ -0,0 +1,62 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// VersionQueryParam is the query parameter VersionedHandler reads the
+// requested version from when the Accept-Version header is absent.
+const VersionQueryParam = "version"
+
+// VersionedHandler serves several versions of one endpoint, e.g., v1 and v2
+// request shapes of POST /users, with one handler per version instead of a
+// switch inside a single handler. The version is taken from the Accept-Version
+// header, then the ?version= query parameter, and defaultVersion is used when
+// neither is set. An unknown version is rejected with a 400
+// UNSUPPORTED_VERSION error (when UseProperHTTPStatus is enabled) listing the
+// supported versions in details.supported. Accept-Version is added to the
+// Vary response header so caches keep the versions apart.
+//
+// Versions are matched exactly, after trimming spaces. VersionedHandler
+// panics if defaultVersion has no handler.
+//
+// Example:
+//
+//	srv.POST("/users", core.VersionedHandler(map[string]core.Handler{
+//	    "1": core.TypedHandler(core.StatusCreated, createUserV1),
+//	    "2": core.TypedHandler(core.StatusCreated, createUserV2),
+//	}, "2"))
+//	// Accept-Version: 1 -> createUserV1; no header or query -> createUserV2
+func VersionedHandler(versions map[string]Handler, defaultVersion string) Handler {
+	handlers := maps.Clone(versions)
+	if handlers[defaultVersion] == nil {
+		panic(fmt.Sprintf("core: VersionedHandler has no handler for default version %q", defaultVersion))
+	}
+	supported := slices.Sorted(maps.Keys(handlers))
+
+	return func(ctx Context) error {
+		ctx.Append(HeaderVary, HeaderAcceptVersion)
+
+		version := strings.TrimSpace(ctx.Get(HeaderAcceptVersion))
+		if version == "" {
+			version = strings.TrimSpace(ctx.Query(VersionQueryParam))
+		}
+		if version == "" {
+			version = defaultVersion
+		}
+		handler, ok := handlers[version]
+		if !ok || handler == nil {
+			errResp := NewErrorResponse("UNSUPPORTED_VERSION", StatusBadRequest,
+				fmt.Sprintf("Unsupported version %q", version)).
+				WithDetails("version", version).
+				WithDetails("supported", supported)
+			return SendError(ctx, errResp)
+		}
+		return handler(ctx)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package core_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+)
+
+func TestVersionedHandler_Server(t *testing.T) {
+	type createUserV1 struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type createUserV2 struct {
+		FirstName string `json:"first_name" validate:"required"`
+		LastName  string `json:"last_name" validate:"required"`
+	}
+	srv := testutil.NewServer(t)
+	err := srv.POST("/users", core.VersionedHandler(map[string]core.Handler{
+		"1": core.TypedHandler(core.StatusCreated, func(_ core.Context, req createUserV1) (string, error) {
+			return "v1:" + req.Name, nil
+		}),
+		"2": core.TypedHandler(core.StatusCreated, func(_ core.Context, req createUserV2) (string, error) {
+			return "v2:" + req.FirstName + " " + req.LastName, nil
+		}),
+	}, "2"))
+	if err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		header     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "v1 header", target: "/users", header: "1", body: `{"name":"Ada Lovelace"}`, wantStatus: http.StatusCreated, wantBody: `"v1:Ada Lovelace"`},
+		{name: "v2 header", target: "/users", header: "2", body: `{"first_name":"Ada","last_name":"Lovelace"}`, wantStatus: http.StatusCreated, wantBody: `"v2:Ada Lovelace"`},
+		{name: "v1 query", target: "/users?version=1", body: `{"name":"Ada"}`, wantStatus: http.StatusCreated, wantBody: `"v1:Ada"`},
+		{name: "header wins over query", target: "/users?version=1", header: "2", body: `{"first_name":"Ada","last_name":"L"}`, wantStatus: http.StatusCreated, wantBody: `"v2:Ada L"`},
+		{name: "default version", target: "/users", body: `{"first_name":"Ada","last_name":"L"}`, wantStatus: http.StatusCreated, wantBody: `"v2:Ada L"`},
+		{name: "v1 body against v2", target: "/users", header: "2", body: `{"name":"Ada"}`, wantStatus: http.StatusBadRequest, wantBody: "VALIDATION_FAILED"},
+		{name: "unknown version", target: "/users", header: "3", body: `{}`, wantStatus: http.StatusBadRequest, wantBody: "UNSUPPORTED_VERSION"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(core.HeaderAcceptVersion, tt.header)
+			}
+			resp, body := testutil.Send(t, srv, req)
+			if resp.StatusCode != tt.wantStatus || !strings.Contains(body, tt.wantBody) {
+				t.Errorf("status = %d, body = %s, want %d containing %s", resp.StatusCode, body, tt.wantStatus, tt.wantBody)
+			}
+			if !strings.Contains(resp.Header.Get("Vary"), core.HeaderAcceptVersion) {
+				t.Errorf("Vary = %q, want it to list %s", resp.Header.Get("Vary"), core.HeaderAcceptVersion)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package engine
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/anthanhphan/gosdk/logger"
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
@@ -31,6 +32,15 @@ type ServerEngine interface {
 	// RegisterStaticFiles serves static files from the filesystem.
 	// Does nothing if config is nil.
 	RegisterStaticFiles(config *configuration.StaticFileConfig)
+	// RegisterFallback serves requests under prefix that match no route with
+	// handler. The longest matching prefix wins.
+	RegisterFallback(prefix string, handler core.Handler)
+	// RegisterAlias serves requests matching aliasPath as if they were sent to
+	// targetPath, filling targetPath's parameters from the request.
+	RegisterAlias(aliasPath, targetPath string)
+	// Test dispatches req through the full middleware chain without binding a port.
+	// A zero timeout disables the deadline.
+	Test(req *http.Request, timeout time.Duration) (*http.Response, error)
 }
 
 // MetricsClient is a minimal interface for metrics clients that provide an HTTP handler
@@ -13,6 +13,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	logger "github.com/anthanhphan/gosdk/logger"
 	configuration "github.com/anthanhphan/gosdk/orianna/http/configuration"
@@ -46,6 +47,30 @@ func (m *MockServerEngine) EXPECT() *MockServerEngineMockRecorder {
 	return m.recorder
 }
 
+// RegisterAlias mocks base method.
+func (m *MockServerEngine) RegisterAlias(aliasPath, targetPath string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterAlias", aliasPath, targetPath)
+}
+
+// RegisterAlias indicates an expected call of RegisterAlias.
+func (mr *MockServerEngineMockRecorder) RegisterAlias(aliasPath, targetPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAlias", reflect.TypeOf((*MockServerEngine)(nil).RegisterAlias), aliasPath, targetPath)
+}
+
+// RegisterFallback mocks base method.
+func (m *MockServerEngine) RegisterFallback(prefix string, handler core.Handler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterFallback", prefix, handler)
+}
+
+// RegisterFallback indicates an expected call of RegisterFallback.
+func (mr *MockServerEngineMockRecorder) RegisterFallback(prefix, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFallback", reflect.TypeOf((*MockServerEngine)(nil).RegisterFallback), prefix, handler)
+}
+
 // RegisterGroup mocks base method.
 func (m *MockServerEngine) RegisterGroup(group routing.RouteGroup) error {
 	m.ctrl.T.Helper()
@@ -154,6 +179,21 @@ func (mr *MockServerEngineMockRecorder) Start() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockServerEngine)(nil).Start))
 }
 
+// Test mocks base method.
+func (m *MockServerEngine) Test(req *http.Request, timeout time.Duration) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Test", req, timeout)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Test indicates an expected call of Test.
+func (mr *MockServerEngineMockRecorder) Test(req, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Test", reflect.TypeOf((*MockServerEngine)(nil).Test), req, timeout)
+}
+
 // Use mocks base method.
 func (m *MockServerEngine) Use(middleware ...core.Middleware) {
 	m.ctrl.T.Helper()
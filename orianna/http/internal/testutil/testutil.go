@@ -0,0 +1,47 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+// Package testutil holds the helpers the orianna/http tests use to run
+// requests through a real server with Server.Test.
+package testutil
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+// NewServer builds a server answering with proper HTTP statuses, with the
+// response cache disabled so every request reaches the handlers.
+func NewServer(t testing.TB, opts ...server.ServerOption) *server.Server {
+	t.Helper()
+	conf := &configuration.Config{
+		ServiceName:         "test",
+		UseProperHTTPStatus: true,
+	}
+	opts = append([]server.ServerOption{
+		server.WithMiddlewareConfig(&configuration.MiddlewareConfig{DisableCache: true}),
+	}, opts...)
+	srv, err := server.NewServer(conf, opts...)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return srv
+}
+
+// Send runs req through srv and returns the response with its body read.
+func Send(t testing.TB, srv *server.Server, req *http.Request) (*http.Response, string) {
+	t.Helper()
+	resp, err := srv.Test(req)
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp, string(body)
+}
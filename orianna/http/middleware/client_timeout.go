@@ -0,0 +1,76 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// ClientTimeout lets clients choose a shorter or longer deadline for their
+// request with the X-Request-Timeout header (e.g., "30s", "1m30s", or bare
+// seconds such as "45"), capped at maxTimeout. The deadline is set on ctx.Context(),
+// so handlers see it like any other deadline; an earlier deadline already on
+// the context (e.g., Config.RequestTimeout) still wins.
+//
+// A missing, invalid or non-positive header leaves the server default in
+// place. When the chosen deadline passes while the request is running, a 504
+// TIMEOUT error is sent (when UseProperHTTPStatus is enabled) if the handler
+// returned an error or wrote nothing; a response the handler already wrote is
+// kept.
+//
+// It panics if maxTimeout is not positive.
+//
+// Example:
+//
+//	srv.GET("/exports/:id", exportHandler, middleware.ClientTimeout(2*time.Minute))
+func ClientTimeout(maxTimeout time.Duration) core.Middleware {
+	if maxTimeout <= 0 {
+		panic("middleware: ClientTimeout maxTimeout must be positive")
+	}
+	return func(ctx core.Context) error {
+		timeout, ok := parseRequestTimeout(ctx.Get(core.HeaderXRequestTimeout))
+		if !ok {
+			return ctx.Next()
+		}
+		timeout = min(timeout, maxTimeout)
+
+		origCtx := ctx.Context()
+		timeoutCtx, cancel := context.WithTimeout(origCtx, timeout)
+		defer cancel()
+		ctx.SetContext(timeoutCtx)
+
+		err := ctx.Next()
+		ctx.SetContext(origCtx)
+
+		if timeoutCtx.Err() == context.DeadlineExceeded && (err != nil || !responseWritten(ctx)) {
+			return core.SendError(ctx, core.NewErrorResponse("TIMEOUT", core.StatusGatewayTimeout, "Request timeout"))
+		}
+		return err
+	}
+}
+
+// responseWritten reports whether the handler has started a response, i.e.
+// set a status other than 200 or buffered a body.
+func responseWritten(ctx core.Context) bool {
+	return ctx.ResponseStatusCode() != core.StatusOK || len(ctx.ResponseBody()) > 0
+}
+
+// parseRequestTimeout parses an X-Request-Timeout value as a Go duration or
+// a whole number of seconds. It reports false for empty, invalid or
+// non-positive values.
+func parseRequestTimeout(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		// Bare number of seconds
+		if d, err = time.ParseDuration(value + "s"); err != nil {
+			return 0, false
+		}
+	}
+	return d, d > 0
+}
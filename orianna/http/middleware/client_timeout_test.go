@@ -0,0 +1,121 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestClientTimeout_Server(t *testing.T) {
+	serverDefault := 30 * time.Second
+	conf := &configuration.Config{ServiceName: "test", UseProperHTTPStatus: true, RequestTimeout: &serverDefault}
+	srv, err := server.NewServer(conf, server.WithMiddlewareConfig(&configuration.MiddlewareConfig{DisableCache: true}))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	var remaining time.Duration
+	err = srv.GET("/exports", func(ctx core.Context) error {
+		deadline, _ := ctx.Context().Deadline()
+		remaining = time.Until(deadline)
+		return ctx.SendString("ok")
+	}, middleware.ClientTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	err = srv.GET("/slow", func(ctx core.Context) error {
+		<-ctx.Context().Done()
+		return nil
+	}, middleware.ClientTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	err = srv.GET("/written", func(ctx core.Context) error {
+		if err := ctx.SendString("done"); err != nil {
+			return err
+		}
+		<-ctx.Context().Done()
+		return nil
+	}, middleware.ClientTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	err = srv.GET("/aborted", func(ctx core.Context) error {
+		if err := ctx.SendString("partial"); err != nil {
+			return err
+		}
+		<-ctx.Context().Done()
+		return ctx.Context().Err()
+	}, middleware.ClientTimeout(10*time.Second))
+	if err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	request := func(path, timeout string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(core.HeaderXRequestTimeout, timeout)
+		return req
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"header shortens deadline", "2s", 2 * time.Second},
+		{"bare seconds", "3", 3 * time.Second},
+		{"over max is capped", "1h", 10 * time.Second},
+		{"invalid header keeps server default", "soon", serverDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.Send(t, srv, request("/exports", tt.header))
+			if remaining > tt.want || remaining < tt.want-time.Second {
+				t.Errorf("remaining deadline = %v, want about %v", remaining, tt.want)
+			}
+		})
+	}
+
+	t.Run("exceeded deadline returns 504", func(t *testing.T) {
+		resp, _ := testutil.Send(t, srv, request("/slow", "50ms"))
+		if resp.StatusCode != http.StatusGatewayTimeout {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusGatewayTimeout)
+		}
+	})
+
+	t.Run("exceeded deadline keeps a written response", func(t *testing.T) {
+		resp, body := testutil.Send(t, srv, request("/written", "50ms"))
+		if resp.StatusCode != http.StatusOK || body != "done" {
+			t.Errorf("status = %d, body = %q, want 200 %q", resp.StatusCode, body, "done")
+		}
+	})
+
+	t.Run("exceeded deadline with an error returns 504", func(t *testing.T) {
+		resp, _ := testutil.Send(t, srv, request("/aborted", "50ms"))
+		if resp.StatusCode != http.StatusGatewayTimeout {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusGatewayTimeout)
+		}
+	})
+}
+
+func TestClientTimeout_InvalidMax(t *testing.T) {
+	for _, maxTimeout := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ClientTimeout(%v) should panic", maxTimeout)
+				}
+			}()
+			middleware.ClientTimeout(maxTimeout)
+		}()
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// MaxConcurrent defaults
+const (
+	DefaultMaxConcurrentQueueTimeout = 5 * time.Second
+	DefaultMaxConcurrentRetryAfter   = time.Second
+)
+
+// MaxConcurrentOptions configures MaxConcurrent.
+type MaxConcurrentOptions struct {
+	// MaxQueue is how many requests may wait for a slot once the limit is
+	// reached; further requests are rejected immediately (default: the limit).
+	// Use a negative value to reject as soon as every slot is busy.
+	MaxQueue int
+	// QueueTimeout bounds how long a queued request waits for a slot before it
+	// is rejected (default: DefaultMaxConcurrentQueueTimeout).
+	QueueTimeout time.Duration
+	// RetryAfter is sent in the Retry-After header of rejected requests,
+	// rounded up to whole seconds (default: DefaultMaxConcurrentRetryAfter).
+	RetryAfter time.Duration
+}
+
+// MaxConcurrent limits how many requests run the downstream handler at once,
+// e.g., to keep an expensive report endpoint from exhausting a database pool.
+// Requests over the limit queue for a slot in arrival order; when the queue is
+// full or the wait exceeds QueueTimeout they are rejected with 503
+// TOO_MANY_CONCURRENT_REQUESTS (when UseProperHTTPStatus is enabled) and a
+// Retry-After header. Each call creates its own limit, so attach it to the
+// routes that should share it.
+//
+// It panics if limit is not positive.
+//
+// Example:
+//
+//	srv.GET("/report", reportHandler, middleware.MaxConcurrent(10))
+func MaxConcurrent(limit int, opts ...MaxConcurrentOptions) core.Middleware {
+	if limit <= 0 {
+		panic("middleware: MaxConcurrent limit must be positive")
+	}
+	opt := MaxConcurrentOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	switch {
+	case opt.MaxQueue == 0:
+		opt.MaxQueue = limit
+	case opt.MaxQueue < 0:
+		opt.MaxQueue = 0
+	}
+	if opt.QueueTimeout <= 0 {
+		opt.QueueTimeout = DefaultMaxConcurrentQueueTimeout
+	}
+	if opt.RetryAfter <= 0 {
+		opt.RetryAfter = DefaultMaxConcurrentRetryAfter
+	}
+	retryAfter := strconv.Itoa(int((opt.RetryAfter + time.Second - 1) / time.Second))
+	sem := newWeightedSemaphore(int64(limit), opt.MaxQueue)
+
+	return func(ctx core.Context) error {
+		if !sem.acquire(1, opt.QueueTimeout, ctx.Context().Done()) {
+			ctx.Set(core.HeaderRetryAfter, retryAfter)
+			return core.SendError(ctx, core.NewErrorResponse("TOO_MANY_CONCURRENT_REQUESTS",
+				core.StatusServiceUnavailable, "Too many concurrent requests, please retry later"))
+		}
+		defer sem.release(1)
+		return ctx.Next()
+	}
+}
+
+// weightedSemaphore is a FIFO weighted semaphore with a bounded wait queue.
+type weightedSemaphore struct {
+	mu       sync.Mutex
+	size     int64
+	cur      int64
+	maxQueue int
+	waiters  []*semWaiter
+}
+
+// semWaiter is one queued acquire; ready is closed once it holds its weight.
+type semWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func newWeightedSemaphore(size int64, maxQueue int) *weightedSemaphore {
+	return &weightedSemaphore{size: size, maxQueue: maxQueue}
+}
+
+// acquire takes n units, waiting up to timeout or until done is closed. It
+// returns false without waiting when the queue is full.
+func (s *weightedSemaphore) acquire(n int64, timeout time.Duration, done <-chan struct{}) bool {
+	s.mu.Lock()
+	if len(s.waiters) == 0 && s.size-s.cur >= n {
+		s.cur += n
+		s.mu.Unlock()
+		return true
+	}
+	if len(s.waiters) >= s.maxQueue {
+		s.mu.Unlock()
+		return false
+	}
+	w := &semWaiter{n: n, ready: make(chan struct{})}
+	s.waiters = append(s.waiters, w)
+	s.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-w.ready:
+		return true
+	case <-timer.C:
+	case <-done:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-w.ready:
+		// Granted while giving up; hand the units back
+		s.cur -= n
+		s.notify()
+	default:
+		for i, q := range s.waiters {
+			if q == w {
+				s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+				break
+			}
+		}
+		// A large waiter at the front may have been blocking smaller ones
+		s.notify()
+	}
+	return false
+}
+
+// release returns n units and wakes the waiters that now fit.
+func (s *weightedSemaphore) release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	s.notify()
+}
+
+// notify grants waiters in FIFO order while they fit. Must hold s.mu.
+func (s *weightedSemaphore) notify() {
+	for len(s.waiters) > 0 {
+		w := s.waiters[0]
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		close(w.ready)
+		s.waiters = s.waiters[1:]
+	}
+}
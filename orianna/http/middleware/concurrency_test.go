@@ -0,0 +1,83 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+)
+
+func TestMaxConcurrent_Server(t *testing.T) {
+	srv := testutil.NewServer(t)
+
+	const limit, requests = 2, 8
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	report := func(ctx core.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		return ctx.SendString("report")
+	}
+	route := routing.NewRoute("/report").GET().Handler(report).MaxConcurrent(limit).Build()
+	if err := srv.RegisterRoutes(*route); err != nil {
+		t.Fatalf("RegisterRoutes() error = %v", err)
+	}
+
+	type result struct {
+		status     int
+		retryAfter string
+	}
+	results := make(chan result, requests)
+	for range requests {
+		go func() {
+			resp, err := srv.Test(httptest.NewRequest(http.MethodGet, "/report", nil), 10*time.Second)
+			if err != nil {
+				results <- result{}
+				return
+			}
+			_ = resp.Body.Close()
+			results <- result{resp.StatusCode, resp.Header.Get("Retry-After")}
+		}()
+	}
+
+	// limit requests run and limit more queue; the rest are rejected right away
+	var rejected int
+	for range requests - 2*limit {
+		r := <-results
+		if r.status != http.StatusServiceUnavailable {
+			t.Fatalf("early response status = %d, want 503", r.status)
+		}
+		if r.retryAfter != "1" {
+			t.Errorf("Retry-After = %q, want 1", r.retryAfter)
+		}
+		rejected++
+	}
+	close(release)
+
+	var succeeded int
+	for range 2 * limit {
+		if r := <-results; r.status == http.StatusOK {
+			succeeded++
+		}
+	}
+	if rejected != requests-2*limit || succeeded != 2*limit {
+		t.Errorf("rejected = %d, succeeded = %d, want %d and %d", rejected, succeeded, requests-2*limit, 2*limit)
+	}
+	if p := peak.Load(); p > limit {
+		t.Errorf("peak concurrency = %d, want <= %d", p, limit)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// csrfLocalsKey is the Locals key CSRF stores the request's token under.
+const csrfLocalsKey = "csrf_token"
+
+// csrfTokenBytes is the amount of randomness in a CSRF token.
+const csrfTokenBytes = 32
+
+// CSRFOptions configures CSRF. Cookie settings mirror configuration.CSRFConfig.
+type CSRFOptions struct {
+	// Secret signs tokens with HMAC-SHA256, so a token cookie planted by a
+	// sibling subdomain or over plain HTTP is rejected. Use the same secret on
+	// every replica; when empty, a random secret is generated and tokens only
+	// stay valid for the life of the process.
+	Secret []byte
+	// SessionID, when set, binds tokens to the session it returns for the
+	// request (e.g., the session cookie's value), so a token issued to one
+	// session is not accepted for another. An empty ID leaves the token unbound.
+	SessionID func(core.Context) string
+	// KeyLookup lists where unsafe requests carry the token, as comma-separated
+	// "source:name" pairs tried in order. Sources: header, form (urlencoded
+	// bodies) and query (default: configuration.DefaultCSRFKeyLookup).
+	KeyLookup string
+	// CookieName is the cookie holding the token
+	// (default: configuration.DefaultCSRFCookieName).
+	CookieName     string
+	CookiePath     string // default configuration.DefaultCSRFCookiePath
+	CookieDomain   string
+	CookieSameSite string // Strict (default), Lax or None
+	CookieSecure   *bool  // nil = configuration.DefaultCSRFCookieSecure
+	// CookieHTTPOnly defaults to false, so browser scripts can read the
+	// cookie and echo it in the default header source. Set it to true when
+	// the token only reaches the client through CSRFToken, e.g., in a form.
+	CookieHTTPOnly *bool
+	// Expiration is the cookie lifetime (default: configuration.DefaultCSRFExpiration).
+	Expiration time.Duration
+	// ExemptPaths are exact request paths that skip validation, e.g. webhook
+	// endpoints authenticated by signature.
+	ExemptPaths []string
+	// Skip, when it returns true, skips validation for the request.
+	Skip func(core.Context) bool
+}
+
+// csrfSource is one parsed KeyLookup entry.
+type csrfSource struct {
+	kind, name string
+}
+
+// CSRF protects against cross-site request forgery with the signed
+// double-submit cookie pattern. Tokens are a random value signed with Secret
+// (and bound to SessionID when set); every request without a token cookie
+// that verifies gets a fresh one. Unsafe requests (anything but GET, HEAD,
+// OPTIONS and TRACE) must echo the cookie's token from a KeyLookup source,
+// compared in constant time, or are rejected with 403 CSRF_TOKEN_INVALID
+// (when UseProperHTTPStatus is enabled). Handlers can read the token with
+// CSRFToken, e.g., to render it into a form.
+//
+// Config.EnableCSRF installs Fiber's CSRF middleware instead, server-wide, with
+// tokens stored in the process's memory. Prefer CSRF when several replicas
+// serve the same clients or only some routes need protection, and do not
+// enable both.
+//
+// It panics if KeyLookup is malformed.
+//
+// Example:
+//
+//	srv.Use(middleware.CSRF(middleware.CSRFOptions{
+//	    KeyLookup:   "header:X-CSRF-Token,form:csrf_token",
+//	    ExemptPaths: []string{"/webhooks/stripe"},
+//	}))
+func CSRF(opts CSRFOptions) core.Middleware {
+	sources := parseCSRFKeyLookup(opts.KeyLookup)
+	if opts.CookieName == "" {
+		opts.CookieName = configuration.DefaultCSRFCookieName
+	}
+	if opts.CookiePath == "" {
+		opts.CookiePath = configuration.DefaultCSRFCookiePath
+	}
+	switch opts.CookieSameSite {
+	case "Strict", "Lax", "None":
+	default:
+		opts.CookieSameSite = configuration.DefaultCSRFSameSite
+	}
+	if opts.Expiration <= 0 {
+		opts.Expiration = configuration.DefaultCSRFExpiration
+	}
+	secret := opts.Secret
+	if len(secret) == 0 {
+		secret = make([]byte, csrfTokenBytes)
+		_, _ = rand.Read(secret) // crypto/rand.Read never fails
+	}
+	secure, httpOnly := configuration.DefaultCSRFCookieSecure, false
+	if opts.CookieSecure != nil {
+		secure = *opts.CookieSecure
+	}
+	if opts.CookieHTTPOnly != nil {
+		httpOnly = *opts.CookieHTTPOnly
+	}
+	exempt := make(map[string]struct{}, len(opts.ExemptPaths))
+	for _, p := range opts.ExemptPaths {
+		exempt[p] = struct{}{}
+	}
+
+	return func(ctx core.Context) error {
+		var session string
+		if opts.SessionID != nil {
+			session = opts.SessionID(ctx)
+		}
+		token := ctx.Cookies(opts.CookieName)
+		if !validCSRFToken(secret, session, token) {
+			token = newCSRFToken(secret, session)
+			ctx.Cookie(&core.Cookie{
+				Name:     opts.CookieName,
+				Value:    token,
+				Path:     opts.CookiePath,
+				Domain:   opts.CookieDomain,
+				MaxAge:   int(opts.Expiration.Seconds()),
+				Secure:   secure,
+				HTTPOnly: httpOnly,
+				SameSite: opts.CookieSameSite,
+			})
+		}
+		ctx.Locals(csrfLocalsKey, token)
+
+		if isSafeMethod(ctx.Method()) {
+			return ctx.Next()
+		}
+		if _, ok := exempt[ctx.Path()]; ok {
+			return ctx.Next()
+		}
+		if opts.Skip != nil && opts.Skip(ctx) {
+			return ctx.Next()
+		}
+
+		submitted := lookupCSRFToken(ctx, sources)
+		if submitted == "" || subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1 {
+			return core.SendError(ctx, core.NewErrorResponse("CSRF_TOKEN_INVALID", core.StatusForbidden,
+				"Missing or invalid CSRF token"))
+		}
+		return ctx.Next()
+	}
+}
+
+// CSRFToken returns the CSRF token for the request, set by CSRF, or "" when
+// the middleware did not run.
+func CSRFToken(ctx core.Context) string {
+	token, _ := ctx.Locals(csrfLocalsKey).(string)
+	return token
+}
+
+// parseCSRFKeyLookup parses KeyLookup, panicking on a malformed entry.
+func parseCSRFKeyLookup(lookup string) []csrfSource {
+	if lookup == "" {
+		lookup = configuration.DefaultCSRFKeyLookup
+	}
+	var sources []csrfSource
+	for entry := range strings.SplitSeq(lookup, ",") {
+		kind, name, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		switch {
+		case name == "":
+			panic(fmt.Sprintf("middleware: CSRF KeyLookup entry %q must be source:name", entry))
+		case kind != "header" && kind != "form" && kind != "query":
+			panic(fmt.Sprintf("middleware: CSRF KeyLookup source %q is not header, form or query", kind))
+		}
+		sources = append(sources, csrfSource{kind: kind, name: name})
+	}
+	return sources
+}
+
+// lookupCSRFToken returns the first non-empty token found in sources.
+func lookupCSRFToken(ctx core.Context, sources []csrfSource) string {
+	var form url.Values
+	for _, src := range sources {
+		var value string
+		switch src.kind {
+		case "header":
+			value = ctx.Get(src.name)
+		case "query":
+			value = ctx.Query(src.name)
+		case "form":
+			if form == nil {
+				form = parseURLEncodedForm(ctx)
+			}
+			value = form.Get(src.name)
+		}
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseURLEncodedForm parses an application/x-www-form-urlencoded body,
+// returning empty values for any other content type.
+func parseURLEncodedForm(ctx core.Context) url.Values {
+	if !strings.HasPrefix(ctx.Get(core.HeaderContentType), core.MIMEApplicationForm) {
+		return url.Values{}
+	}
+	form, err := url.ParseQuery(string(ctx.Body()))
+	if err != nil {
+		return url.Values{}
+	}
+	return form
+}
+
+// isSafeMethod reports whether method is one CSRF does not validate.
+func isSafeMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "TRACE":
+		return true
+	}
+	return false
+}
+
+// newCSRFToken returns a random URL-safe token signed for session, in the
+// form "<nonce>.<signature>".
+func newCSRFToken(secret []byte, session string) string {
+	nonce := make([]byte, csrfTokenBytes)
+	_, _ = rand.Read(nonce) // crypto/rand.Read never fails
+	encoded := base64.RawURLEncoding.EncodeToString(nonce)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signCSRFNonce(secret, session, encoded))
+}
+
+// validCSRFToken reports whether token was issued by newCSRFToken with the
+// same secret and session, so a planted or forged cookie is replaced.
+func validCSRFToken(secret []byte, session, token string) bool {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	nonce, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(nonce) != csrfTokenBytes {
+		return false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	return err == nil && hmac.Equal(mac, signCSRFNonce(secret, session, encoded))
+}
+
+// signCSRFNonce returns the HMAC of the session ID and the encoded nonce. The
+// length prefix keeps the two parts from being shifted into each other.
+func signCSRFNonce(secret []byte, session, encoded string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = fmt.Fprintf(mac, "%d:%s:%s", len(session), session, encoded)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+)
+
+func TestCSRF_Server(t *testing.T) {
+	srv := testutil.NewServer(t)
+	srv.Use(middleware.CSRF(middleware.CSRFOptions{
+		KeyLookup:   "header:X-CSRF-Token,form:csrf_token",
+		ExemptPaths: []string{"/webhooks"},
+	}))
+	ok := func(ctx core.Context) error { return ctx.SendString(middleware.CSRFToken(ctx)) }
+	for _, register := range []func(string, core.Handler, ...core.Middleware) error{srv.GET, srv.POST} {
+		if err := register("/profile", ok); err != nil {
+			t.Fatalf("register error = %v", err)
+		}
+	}
+	if err := srv.POST("/webhooks", ok); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+
+	// Safe method: passes without a token and is issued a cookie
+	resp, token := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/profile", nil))
+	if resp.StatusCode != http.StatusOK || token == "" {
+		t.Fatalf("GET status = %d, token %q", resp.StatusCode, token)
+	}
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == configuration.DefaultCSRFCookieName {
+			cookie = c
+		}
+	}
+	// Not HttpOnly by default: scripts read it to send the X-CSRF-Token header
+	if cookie == nil || cookie.Value != token || cookie.HttpOnly || !cookie.Secure {
+		t.Fatalf("CSRF cookie = %+v, want secure script-readable cookie with token %q", cookie, token)
+	}
+
+	post := func(header, form string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(form))
+		req.AddCookie(cookie)
+		if header != "" {
+			req.Header.Set("X-CSRF-Token", header)
+		}
+		if form != "" {
+			req.Header.Set(core.HeaderContentType, core.MIMEApplicationForm)
+		}
+		return req
+	}
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"missing token", post("", ""), http.StatusForbidden},
+		{"wrong token", post(token[1:]+"A", ""), http.StatusForbidden},
+		{"valid header token", post(token, ""), http.StatusOK},
+		{"valid form token", post("", "csrf_token="+token), http.StatusOK},
+		{"no cookie", httptest.NewRequest(http.MethodPost, "/profile", nil), http.StatusForbidden},
+		{"exempt path", httptest.NewRequest(http.MethodPost, "/webhooks", nil), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := testutil.Send(t, srv, tt.req)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", resp.StatusCode, tt.want, body)
+			}
+			if tt.want == http.StatusForbidden && !strings.Contains(body, "CSRF_TOKEN_INVALID") {
+				t.Errorf("body = %s, want CSRF_TOKEN_INVALID", body)
+			}
+		})
+	}
+}
+
+func TestCSRF_Server_SignedTokens(t *testing.T) {
+	srv := testutil.NewServer(t)
+	srv.Use(middleware.CSRF(middleware.CSRFOptions{
+		Secret:    []byte("csrf-secret"),
+		SessionID: func(ctx core.Context) string { return ctx.Cookies("sid") },
+	}))
+	ok := func(ctx core.Context) error { return ctx.SendString(middleware.CSRFToken(ctx)) }
+	for _, register := range []func(string, core.Handler, ...core.Middleware) error{srv.GET, srv.POST} {
+		if err := register("/profile", ok); err != nil {
+			t.Fatalf("register error = %v", err)
+		}
+	}
+
+	request := func(method, sid, token string) *http.Request {
+		req := httptest.NewRequest(method, "/profile", nil)
+		req.AddCookie(&http.Cookie{Name: "sid", Value: sid})
+		if token != "" {
+			req.AddCookie(&http.Cookie{Name: configuration.DefaultCSRFCookieName, Value: token})
+			req.Header.Set("X-CSRF-Token", token)
+		}
+		return req
+	}
+
+	_, token := testutil.Send(t, srv, request(http.MethodGet, "alice", ""))
+	if resp, _ := testutil.Send(t, srv, request(http.MethodPost, "alice", token)); resp.StatusCode != http.StatusOK {
+		t.Errorf("signed token for its session: status = %d, want 200", resp.StatusCode)
+	}
+	if resp, _ := testutil.Send(t, srv, request(http.MethodPost, "bob", token)); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("token replayed for another session: status = %d, want 403", resp.StatusCode)
+	}
+
+	// A cookie planted by an attacker who cannot sign is replaced, even when
+	// the request echoes it
+	planted := base64.RawURLEncoding.EncodeToString(make([]byte, 32))
+	for _, forged := range []string{planted, planted + ".AAAA"} {
+		resp, body := testutil.Send(t, srv, request(http.MethodPost, "alice", forged))
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("forged token %q: status = %d, want 403", forged, resp.StatusCode)
+		}
+		if !strings.Contains(body, "CSRF_TOKEN_INVALID") {
+			t.Errorf("forged token %q: body = %s, want CSRF_TOKEN_INVALID", forged, body)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"maps"
+	"slices"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// DefaultHeaders sets the given response headers before calling the next
+// handler, so handlers can still override them with ctx.Set. Route groups and
+// routes with Headers(...) use it under the hood.
+//
+// Example:
+//
+//	srv.Use(middleware.DefaultHeaders(map[string]string{
+//	    "X-API-Version": "1",
+//	}))
+func DefaultHeaders(headers map[string]string) core.Middleware {
+	snapshot := maps.Clone(headers)
+	return func(ctx core.Context) error {
+		for name, value := range snapshot {
+			ctx.Set(name, value)
+		}
+		return ctx.Next()
+	}
+}
+
+// RequireHeaders rejects requests missing any of the given request headers
+// with a 400 MISSING_HEADER error (when UseProperHTTPStatus is enabled) naming
+// the first missing header in details.header. Headers are checked in order;
+// an empty value counts as missing.
+//
+// Example:
+//
+//	srv.Use(middleware.RequireHeaders("X-Api-Key", core.HeaderContentType))
+func RequireHeaders(names ...string) core.Middleware {
+	required := slices.Clone(names)
+	return func(ctx core.Context) error {
+		for _, name := range required {
+			if ctx.Get(name) == "" {
+				return sendMissingHeader(ctx, name)
+			}
+		}
+		return ctx.Next()
+	}
+}
+
+// RequireHeaderValue rejects requests whose header name is missing
+// (400 MISSING_HEADER) or differs from expected (400 INVALID_HEADER).
+// The comparison is exact and constant-time, so it is safe for shared secrets
+// such as static API keys.
+//
+// Example:
+//
+//	srv.Use(middleware.RequireHeaderValue("X-Api-Key", os.Getenv("PARTNER_API_KEY")))
+func RequireHeaderValue(name, expected string) core.Middleware {
+	want := []byte(expected)
+	return func(ctx core.Context) error {
+		value := ctx.Get(name)
+		if value == "" {
+			return sendMissingHeader(ctx, name)
+		}
+		if subtle.ConstantTimeCompare([]byte(value), want) != 1 {
+			errResp := core.NewErrorResponse("INVALID_HEADER", core.StatusBadRequest,
+				"Invalid value for header "+name).WithDetails("header", name)
+			return core.SendError(ctx, errResp)
+		}
+		return ctx.Next()
+	}
+}
+
+// sendMissingHeader sends the MISSING_HEADER error for name.
+func sendMissingHeader(ctx core.Context, name string) error {
+	errResp := core.NewErrorResponse("MISSING_HEADER", core.StatusBadRequest,
+		"Missing required header "+name).WithDetails("header", name)
+	return core.SendError(ctx, errResp)
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/shared/ctxkeys"
+)
+
+// JWT defaults.
+const (
+	// DefaultJWKSRefresh is how often the JWKS is refetched when
+	// JWTOptions.JWKSRefresh is not set.
+	DefaultJWKSRefresh = time.Hour
+
+	// jwksMinRefetch limits refetches triggered by an unknown key ID.
+	jwksMinRefetch = time.Minute
+
+	// jwksMaxBytes caps the JWKS response size.
+	jwksMaxBytes = 1 << 20
+
+	// jwksFetchTimeout bounds a JWKS fetch, which is detached from the
+	// request that triggered it.
+	jwksFetchTimeout = 10 * time.Second
+
+	// jwtClaimsKey is the Locals key holding the verified claims.
+	jwtClaimsKey = "jwt_claims"
+)
+
+// JWTOptions configures JWTAuth. At least one of Secret, PublicKey or JWKSURL
+// must be set; the token's alg must match a configured key, so an HS256 token
+// is never checked against an RSA key and "none" is always rejected.
+type JWTOptions struct {
+	// Secret verifies HS256 tokens.
+	Secret []byte
+	// PublicKey verifies RS256 tokens.
+	PublicKey *rsa.PublicKey
+	// JWKSURL is a JSON Web Key Set endpoint whose RSA keys verify RS256
+	// tokens, selected by the token's kid header.
+	JWKSURL string
+	// JWKSRefresh is how often the key set is refetched (default:
+	// DefaultJWKSRefresh). A token with an unknown kid also triggers a
+	// refetch, at most once a minute, so rotated keys are picked up.
+	JWKSRefresh time.Duration
+	// HTTPClient fetches the JWKS (default: a client with a 10s timeout).
+	// Fetches run on their own, shared by concurrent requests, and give up
+	// after 10s whatever the client's timeout.
+	HTTPClient *http.Client
+	// Issuer, when set, must equal the iss claim.
+	Issuer string
+	// Audience, when set, must appear in the aud claim.
+	Audience string
+	// Leeway tolerates clock skew when checking exp and nbf.
+	Leeway time.Duration
+}
+
+// JWTClaims are the verified claims of a token.
+type JWTClaims map[string]any
+
+// Subject returns the sub claim.
+func (c JWTClaims) Subject() string {
+	sub, _ := c["sub"].(string)
+	return sub
+}
+
+// Strings returns a claim holding a list of strings: either a JSON array or a
+// space-separated string such as the OAuth scope claim.
+func (c JWTClaims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// JWTAuth creates a middleware that requires a valid "Authorization: Bearer"
+// JWT. It verifies the HS256 or RS256 signature, exp and nbf, and the issuer
+// and audience when configured, then stores the subject in Locals under
+// user_id and the claims for JWTClaimsFrom. Any failure is answered with 401.
+// It panics when no key is configured.
+//
+// Use it as the server's authentication middleware so Protected() routes
+// require a token, and JWTPermissions as the authorization checker.
+//
+// Example:
+//
+//	srv, _ := server.NewServer(conf,
+//	    server.WithAuthentication(middleware.JWTAuth(middleware.JWTOptions{
+//	        JWKSURL:  "https://auth.example.com/.well-known/jwks.json",
+//	        Issuer:   "https://auth.example.com/",
+//	        Audience: "orders-api",
+//	    })),
+//	    server.WithAuthorization(middleware.JWTPermissions("permissions")),
+//	)
+func JWTAuth(opts JWTOptions) core.Middleware {
+	v := newJWTVerifier(opts)
+	return func(ctx core.Context) error {
+		token, ok := strings.CutPrefix(ctx.Get(core.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" {
+			return sendUnauthorized(ctx, "missing bearer token")
+		}
+		claims, err := v.verify(ctx.Context(), token)
+		if err != nil {
+			return sendUnauthorized(ctx, err.Error())
+		}
+		ctx.Locals(ctxkeys.UserID.Key(), claims.Subject())
+		ctx.Locals(jwtClaimsKey, claims)
+		return ctx.Next()
+	}
+}
+
+// JWTClaimsFrom returns the claims stored by JWTAuth, or nil when the request
+// was not authenticated with it.
+func JWTClaimsFrom(ctx core.Context) JWTClaims {
+	claims, _ := ctx.Locals(jwtClaimsKey).(JWTClaims)
+	return claims
+}
+
+// JWTPermissions returns an authorization checker for server.WithAuthorization
+// that requires every route permission to be listed in the given claim, e.g.,
+// "permissions" (a JSON array) or "scope" (space-separated).
+func JWTPermissions(claim string) func(core.Context, []string) error {
+	return func(ctx core.Context, required []string) error {
+		granted := JWTClaimsFrom(ctx).Strings(claim)
+		for _, perm := range required {
+			if !slices.Contains(granted, perm) {
+				return core.NewErrorResponse("FORBIDDEN", core.StatusForbidden, core.MessageForbidden).
+					WithInternalMsg("missing permission: %s", perm)
+			}
+		}
+		return nil
+	}
+}
+
+// sendUnauthorized answers 401 with a Bearer challenge; reason is only logged.
+func sendUnauthorized(ctx core.Context, reason string) error {
+	ctx.Set("WWW-Authenticate", "Bearer")
+	return core.SendError(ctx, core.NewErrorResponse("UNAUTHORIZED", core.StatusUnauthorized, core.MessageUnauthorized).
+		WithInternalMsg("jwt: %s", reason))
+}
+
+// ============================================================================
+// Verification
+// ============================================================================
+
+// jwtVerifier checks token signatures and registered claims.
+type jwtVerifier struct {
+	opts JWTOptions
+	jwks *jwksCache
+	now  func() time.Time
+}
+
+func newJWTVerifier(opts JWTOptions) *jwtVerifier {
+	if len(opts.Secret) == 0 && opts.PublicKey == nil && opts.JWKSURL == "" {
+		panic("middleware: JWTAuth requires a Secret, PublicKey or JWKSURL")
+	}
+	v := &jwtVerifier{opts: opts, now: time.Now}
+	if opts.JWKSURL != "" {
+		v.jwks = newJWKSCache(opts.JWKSURL, opts.HTTPClient, opts.JWKSRefresh)
+	}
+	return v
+}
+
+// jwtHeader is the JOSE header of a token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// verify checks token and returns its claims.
+func (v *jwtVerifier) verify(ctx context.Context, token string) (JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if err := v.verifySignature(ctx, header, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims JWTClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid claims: %w", err)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifySignature checks sig over signed with the key matching the header.
+func (v *jwtVerifier) verifySignature(ctx context.Context, header jwtHeader, signed string, sig []byte) error {
+	switch header.Alg {
+	case "HS256":
+		if len(v.opts.Secret) == 0 {
+			return errors.New("HS256 is not enabled")
+		}
+		mac := hmac.New(sha256.New, v.opts.Secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case "RS256":
+		key := v.opts.PublicKey
+		if key == nil && v.jwks != nil {
+			var err error
+			if key, err = v.jwks.key(ctx, header.Kid); err != nil {
+				return err
+			}
+		}
+		if key == nil {
+			return errors.New("RS256 is not enabled")
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported alg %q", header.Alg)
+	}
+}
+
+// checkClaims validates exp, nbf, iss and aud.
+func (v *jwtVerifier) checkClaims(claims JWTClaims) error {
+	now := v.now()
+	if exp, ok := numericDate(claims["exp"]); ok && !now.Before(exp.Add(v.opts.Leeway)) {
+		return errors.New("token expired")
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(v.opts.Leeway).Before(nbf) {
+		return errors.New("token not valid yet")
+	}
+	if v.opts.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.opts.Issuer {
+			return fmt.Errorf("unexpected issuer %q", iss)
+		}
+	}
+	if v.opts.Audience != "" {
+		aud := claims.Strings("aud")
+		if s, ok := claims["aud"].(string); ok {
+			aud = []string{s}
+		}
+		if !slices.Contains(aud, v.opts.Audience) {
+			return errors.New("audience mismatch")
+		}
+	}
+	return nil
+}
+
+// numericDate converts a NumericDate claim (seconds since the epoch).
+func numericDate(v any) (time.Time, bool) {
+	f, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(f*float64(time.Second))), true
+}
+
+// decodeSegment decodes a base64url JSON segment into v.
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return jcodec.Unmarshal(data, v)
+}
+
+// ============================================================================
+// JWKS
+// ============================================================================
+
+// jwksCache holds the RSA keys of a JWKS endpoint by key ID.
+type jwksCache struct {
+	url     string
+	client  *http.Client
+	refresh time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	inflight    chan struct{} // closed when the running fetch is done
+}
+
+func newJWKSCache(url string, client *http.Client, refresh time.Duration) *jwksCache {
+	if client == nil {
+		client = &http.Client{Timeout: jwksFetchTimeout}
+	}
+	if refresh <= 0 {
+		refresh = DefaultJWKSRefresh
+	}
+	return &jwksCache{url: url, client: client, refresh: refresh}
+}
+
+// key returns the key for kid, refetching the set when it is stale or the kid
+// is unknown. A known key is returned right away, even while a refresh runs;
+// a request with an unknown kid waits for the refetch, or until ctx is done.
+// Concurrent requests share a single fetch, which runs outside the lock with
+// its own deadline, so a client going away cannot fail it. A failed refetch
+// keeps serving the previous keys.
+func (c *jwksCache) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, found := c.keys[kid]
+	var fetched <-chan struct{}
+	if c.keys == nil || !found || time.Since(c.fetchedAt) >= c.refresh {
+		fetched = c.startFetch()
+	}
+	c.mu.Unlock()
+
+	if !found && fetched != nil {
+		select {
+		case <-fetched:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		c.mu.Lock()
+		key, found = c.keys[kid]
+		c.mu.Unlock()
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+// startFetch starts a refetch unless one is running or the last attempt was
+// less than jwksMinRefetch ago, and returns the channel closed when the
+// running fetch is done, or nil. c.mu must be held.
+func (c *jwksCache) startFetch() <-chan struct{} {
+	if c.inflight != nil {
+		return c.inflight
+	}
+	now := time.Now()
+	if !c.attemptedAt.IsZero() && now.Sub(c.attemptedAt) < jwksMinRefetch {
+		return nil
+	}
+	c.attemptedAt = now
+	done := make(chan struct{})
+	c.inflight = done
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+		defer cancel()
+		keys, err := c.fetch(ctx)
+
+		c.mu.Lock()
+		if err != nil {
+			defaultLog.Warnw("jwks refresh failed", "url", c.url, "error", err.Error())
+		} else {
+			c.keys, c.fetchedAt = keys, time.Now()
+		}
+		c.inflight = nil
+		c.mu.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+// jwk is one entry of a JSON Web Key Set; only RSA fields are read.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetch downloads and parses the key set.
+func (c *jwksCache) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := jcodec.Unmarshal(body, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
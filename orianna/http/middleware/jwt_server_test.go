@@ -0,0 +1,128 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+var jwtSecret = []byte("s3cret")
+
+// hs256Token signs claims with jwtSecret.
+func hs256Token(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	encode := func(v any) string {
+		data, err := jcodec.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + encode(claims)
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newJWTServer(t *testing.T) *server.Server {
+	t.Helper()
+	srv := testutil.NewServer(t,
+		server.WithAuthentication(middleware.JWTAuth(middleware.JWTOptions{Secret: jwtSecret})),
+		server.WithAuthorization(middleware.JWTPermissions("permissions")),
+	)
+	if err := srv.Protected().GET("/me", func(ctx core.Context) error {
+		userID, _ := ctx.Locals("user_id").(string)
+		return ctx.Status(core.StatusOK).JSON(map[string]any{
+			"user_id": userID,
+			"name":    middleware.JWTClaimsFrom(ctx)["name"],
+		})
+	}); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	if err := srv.Protected().WithPermissions("orders:write").POST("/orders", func(ctx core.Context) error {
+		return ctx.Status(core.StatusCreated).JSON(map[string]string{"status": "created"})
+	}); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+	return srv
+}
+
+func TestJWTAuth_Server(t *testing.T) {
+	srv := newJWTServer(t)
+	exp := time.Now().Add(time.Hour).Unix()
+
+	request := func(method, path, token string) *http.Request {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return req
+	}
+
+	t.Run("missing token is challenged", func(t *testing.T) {
+		resp, body := testutil.Send(t, srv, request(http.MethodGet, "/me", ""))
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want 401; body = %s", resp.StatusCode, body)
+		}
+		if got := resp.Header.Get("WWW-Authenticate"); got != "Bearer" {
+			t.Errorf("WWW-Authenticate = %q, want Bearer", got)
+		}
+		if !strings.Contains(body, "UNAUTHORIZED") {
+			t.Errorf("body = %s, want UNAUTHORIZED code", body)
+		}
+	})
+
+	t.Run("bad signature is challenged", func(t *testing.T) {
+		token := hs256Token(t, map[string]any{"sub": "user-42", "exp": exp})
+		resp, _ := testutil.Send(t, srv, request(http.MethodGet, "/me", token[:len(token)-2]+"xx"))
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want 401", resp.StatusCode)
+		}
+		if got := resp.Header.Get("WWW-Authenticate"); got != "Bearer" {
+			t.Errorf("WWW-Authenticate = %q, want Bearer", got)
+		}
+	})
+
+	t.Run("valid token exposes subject and claims", func(t *testing.T) {
+		token := hs256Token(t, map[string]any{"sub": "user-42", "name": "Ada", "exp": exp})
+		resp, body := testutil.Send(t, srv, request(http.MethodGet, "/me", token))
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200; body = %s", resp.StatusCode, body)
+		}
+		if !strings.Contains(body, `"user_id":"user-42"`) || !strings.Contains(body, `"name":"Ada"`) {
+			t.Errorf("body = %s, want user_id and name from the token", body)
+		}
+	})
+
+	t.Run("missing permission is forbidden", func(t *testing.T) {
+		token := hs256Token(t, map[string]any{"sub": "user-42", "exp": exp, "permissions": []string{"orders:read"}})
+		resp, body := testutil.Send(t, srv, request(http.MethodPost, "/orders", token))
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("status = %d, want 403; body = %s", resp.StatusCode, body)
+		}
+		if !strings.Contains(body, "FORBIDDEN") {
+			t.Errorf("body = %s, want FORBIDDEN code", body)
+		}
+	})
+
+	t.Run("granted permission is allowed", func(t *testing.T) {
+		token := hs256Token(t, map[string]any{"sub": "user-42", "exp": exp, "permissions": []string{"orders:read", "orders:write"}})
+		resp, body := testutil.Send(t, srv, request(http.MethodPost, "/orders", token))
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("status = %d, want 201; body = %s", resp.StatusCode, body)
+		}
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+)
+
+// signJWT builds a token; key is a []byte secret for HS256 or an
+// *rsa.PrivateKey for RS256.
+func signJWT(t *testing.T, header, claims map[string]any, key any) string {
+	t.Helper()
+	encode := func(v any) string {
+		data, err := jcodec.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(header) + "." + encode(claims)
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTVerifier_HS256(t *testing.T) {
+	secret := []byte("s3cret")
+	hs := map[string]any{"alg": "HS256", "typ": "JWT"}
+	now := time.Now()
+	v := newJWTVerifier(JWTOptions{Secret: secret, Issuer: "https://auth.example.com/", Audience: "orders-api"})
+	valid := map[string]any{
+		"sub": "user-42",
+		"iss": "https://auth.example.com/",
+		"aud": []any{"orders-api", "billing-api"},
+		"exp": now.Add(time.Hour).Unix(),
+		"nbf": now.Add(-time.Minute).Unix(),
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		claims, err := v.verify(context.Background(), signJWT(t, hs, valid, secret))
+		if err != nil {
+			t.Fatalf("verify() error = %v", err)
+		}
+		if claims.Subject() != "user-42" {
+			t.Errorf("Subject() = %q, want user-42", claims.Subject())
+		}
+	})
+
+	tests := []struct {
+		name   string
+		header map[string]any
+		claims func(map[string]any)
+		key    []byte
+		want   string
+	}{
+		{"expired token", hs, func(c map[string]any) { c["exp"] = now.Add(-time.Minute).Unix() }, secret, "expired"},
+		{"wrong issuer", hs, func(c map[string]any) { c["iss"] = "https://evil.example.com/" }, secret, "issuer"},
+		{"wrong audience", hs, func(c map[string]any) { c["aud"] = "other-api" }, secret, "audience"},
+		{"not valid yet", hs, func(c map[string]any) { c["nbf"] = now.Add(time.Hour).Unix() }, secret, "not valid yet"},
+		{"bad signature", hs, func(map[string]any) {}, []byte("wrong"), "signature"},
+		{"alg none", map[string]any{"alg": "none"}, func(map[string]any) {}, secret, "unsupported alg"},
+		{"RS256 without key", map[string]any{"alg": "RS256"}, func(map[string]any) {}, secret, "not enabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := map[string]any{}
+			for k, val := range valid {
+				claims[k] = val
+			}
+			tt.claims(claims)
+			_, err := v.verify(context.Background(), signJWT(t, tt.header, claims, tt.key))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("verify() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_RS256_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_ = jcodec.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer jwks.Close()
+
+	v := newJWTVerifier(JWTOptions{JWKSURL: jwks.URL})
+	claims := map[string]any{"sub": "svc", "exp": time.Now().Add(time.Hour).Unix()}
+
+	for range 2 {
+		token := signJWT(t, map[string]any{"alg": "RS256", "kid": "key-1"}, claims, key)
+		if _, err := v.verify(context.Background(), token); err != nil {
+			t.Fatalf("verify() error = %v", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1 (cached)", got)
+	}
+
+	unknown := signJWT(t, map[string]any{"alg": "RS256", "kid": "key-2"}, claims, key)
+	if _, err := v.verify(context.Background(), unknown); err == nil {
+		t.Error("verify() with unknown kid should fail")
+	}
+	forged := signJWT(t, map[string]any{"alg": "HS256", "kid": "key-1"}, claims, []byte("guess"))
+	if _, err := v.verify(context.Background(), forged); err == nil {
+		t.Error("verify() of HS256 token without a secret should fail")
+	}
+}
+
+func TestJWKSCache_SharedFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		<-release
+		_ = jcodec.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer jwks.Close()
+
+	v := newJWTVerifier(JWTOptions{JWKSURL: jwks.URL})
+	token := signJWT(t, map[string]any{"alg": "RS256", "kid": "key-1"},
+		map[string]any{"sub": "svc", "exp": time.Now().Add(time.Hour).Unix()}, key)
+
+	// A request that goes away gives up, but the fetch it started carries on
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := v.verify(canceled, token); err == nil {
+		t.Fatal("verify() with a canceled context should fail")
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for range 8 {
+		wg.Go(func() {
+			_, err := v.verify(context.Background(), token)
+			errs <- err
+		})
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("verify() error = %v", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1 (shared)", got)
+	}
+}
+
+func TestJWTClaims_Strings(t *testing.T) {
+	claims := JWTClaims{"scope": "read write", "permissions": []any{"orders:read", 1, "orders:write"}}
+	if got := claims.Strings("scope"); len(got) != 2 || got[1] != "write" {
+		t.Errorf("Strings(scope) = %v", got)
+	}
+	if got := claims.Strings("permissions"); len(got) != 2 || got[1] != "orders:write" {
+		t.Errorf("Strings(permissions) = %v", got)
+	}
+	if got := claims.Strings("missing"); got != nil {
+		t.Errorf("Strings(missing) = %v, want nil", got)
+	}
+}
@@ -3,6 +3,7 @@
 package middleware
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/anthanhphan/gosdk/metrics"
@@ -23,6 +24,31 @@ func statusString(code int) string {
 	return observability.CodeString(statusCodeCache, code)
 }
 
+// MetricsOption configures MetricsMiddleware.
+type MetricsOption func(*metricsOptions)
+
+// metricsOptions holds the settings applied by MetricsOption values.
+type metricsOptions struct {
+	sizeHistograms bool
+	routeNameLabel bool
+}
+
+// WithSizeMetrics enables the request and response body size histograms.
+func WithSizeMetrics() MetricsOption {
+	return func(o *metricsOptions) {
+		o.sizeHistograms = true
+	}
+}
+
+// WithRouteNameLabel adds a route label holding the route name (see
+// RouteBuilder.Name) to every HTTP metric, so dashboards stay stable when a
+// path changes. Unnamed routes get an empty route label.
+func WithRouteNameLabel() MetricsOption {
+	return func(o *metricsOptions) {
+		o.routeNameLabel = true
+	}
+}
+
 // MetricsMiddleware creates a middleware that records HTTP metrics using the provided client.
 // Uses ctx.RoutePath() instead of ctx.Path() to record route patterns (e.g., "/users/:id")
 // rather than actual paths (e.g., "/users/123"), preventing unbounded Prometheus cardinality.
@@ -31,12 +57,32 @@ func statusString(code int) string {
 //   - {subsystem}_requests_total: counter with labels method, path, status, error_class
 //   - {subsystem}_request_duration_seconds: histogram with labels method, path, status, error_class
 //   - {subsystem}_in_flight_requests: gauge tracking concurrent requests
-//   - {subsystem}_response_body_bytes: histogram of response payload sizes
-func MetricsMiddleware(client metrics.Client, subsystem string) core.Middleware {
+//   - {subsystem}_request_size_bytes: histogram of request body sizes with labels method, path
+//   - {subsystem}_response_size_bytes: histogram of response body sizes with labels method, path
+//
+// The size histograms are only recorded with WithSizeMetrics and get
+// metrics.SizeBucketsBytes() when the client is a metrics.BucketsDefaulter,
+// unless metrics.WithBucketsFor gave them other buckets.
+// WithRouteNameLabel adds a route label with the route name to all but the
+// in-flight gauge.
+// Request size is the Content-Length (or the body length when it is absent);
+// response size is the buffered body before compression, 0 for streamed bodies.
+func MetricsMiddleware(client metrics.Client, subsystem string, opts ...MetricsOption) core.Middleware {
+	var options metricsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Optimization: Pre-calculate metric names to avoid concatenation on every request
 	requestsTotalName := subsystem + observability.SuffixRequestsTotal
 	requestDurationName := subsystem + observability.SuffixRequestDurationSeconds
 	inFlightName := subsystem + observability.SuffixInFlightRequests
+	requestSizeName := subsystem + observability.SuffixRequestSizeBytes
+	responseSizeName := subsystem + observability.SuffixResponseSizeBytes
+	if d, ok := client.(metrics.BucketsDefaulter); ok && options.sizeHistograms {
+		d.DefaultBucketsFor(requestSizeName, metrics.SizeBucketsBytes())
+		d.DefaultBucketsFor(responseSizeName, metrics.SizeBucketsBytes())
+	}
 
 	return func(ctx core.Context) error {
 		// Track in-flight requests
@@ -54,22 +100,44 @@ func MetricsMiddleware(client metrics.Client, subsystem string) core.Middleware
 		status := statusString(statusCode)
 		errClass := httputil.ErrorClassFromStatus(statusCode)
 
-		// Record request count
-		client.Inc(ctx.Context(), requestsTotalName,
+		requestTags := []string{
 			"method", method,
 			"path", routePath,
 			"status", status,
 			"error_class", errClass,
-		)
-
-		// Record latency
-		client.Duration(ctx.Context(), requestDurationName, start,
+		}
+		sizeTags := []string{
 			"method", method,
 			"path", routePath,
-			"status", status,
-			"error_class", errClass,
-		)
+		}
+		if options.routeNameLabel {
+			routeName := RouteNameFrom(ctx)
+			requestTags = append(requestTags, "route", routeName)
+			sizeTags = append(sizeTags, "route", routeName)
+		}
+
+		// Record request count
+		client.Inc(ctx.Context(), requestsTotalName, requestTags...)
+
+		// Record latency
+		client.Duration(ctx.Context(), requestDurationName, start, requestTags...)
+
+		if options.sizeHistograms {
+			client.Histogram(ctx.Context(), requestSizeName, float64(requestSize(ctx)), sizeTags...)
+			client.Histogram(ctx.Context(), responseSizeName, float64(len(ctx.ResponseBody())), sizeTags...)
+		}
 
 		return err
 	}
 }
+
+// requestSize returns the request body size from Content-Length, falling back
+// to the body length for requests without one (e.g., chunked uploads).
+func requestSize(ctx core.Context) int {
+	if cl := ctx.Get(core.HeaderContentLength); cl != "" {
+		if n, err := strconv.Atoi(cl); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return len(ctx.Body())
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/anthanhphan/gosdk/metrics"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestMetricsMiddleware_Server_BodySizes(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := metrics.NewClientWithRegistry("app", registry,
+		metrics.WithoutGoCollector(),
+		metrics.WithoutProcessCollector(),
+	)
+	srv := testutil.NewServer(t, server.WithMetrics(client, middleware.WithSizeMetrics()))
+	err := srv.POST("/orders/:id", func(ctx core.Context) error {
+		return ctx.SendString("accepted")
+	})
+	if err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+
+	body := strings.Repeat("x", 1500)
+	testutil.Send(t, srv, httptest.NewRequest(http.MethodPost, "/orders/42", strings.NewReader(body)))
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	var found int
+	for _, mf := range families {
+		name := mf.GetName()
+		if name != "app_test_request_size_bytes" && name != "app_test_response_size_bytes" {
+			continue
+		}
+		h := mf.GetMetric()[0].GetHistogram()
+		want := float64(len(body))
+		if name == "app_test_response_size_bytes" {
+			want = float64(len("accepted"))
+		}
+		if h.GetSampleCount() != 1 || h.GetSampleSum() != want {
+			t.Errorf("%s: count=%d sum=%v, want one observation of %v", name, h.GetSampleCount(), h.GetSampleSum(), want)
+		}
+		// WithSizeMetrics gives the size histograms size buckets by default
+		if len(h.GetBucket()) != len(metrics.SizeBucketsBytes()) {
+			t.Errorf("%s: %d buckets, want %d", name, len(h.GetBucket()), len(metrics.SizeBucketsBytes()))
+		}
+		found++
+	}
+	if found != 2 {
+		t.Errorf("found %d size histograms, want 2", found)
+	}
+}
+
+func TestMetricsMiddleware_Server_RouteNameLabel(t *testing.T) {
+	rec := metrics.NewRecordingClient()
+	srv := testutil.NewServer(t, server.WithMetrics(rec, middleware.WithRouteNameLabel()))
+	route := routing.NewRoute("/users/:id").
+		GET().
+		Name("GetUser").
+		Handler(func(ctx core.Context) error { return ctx.SendString("ok") }).
+		Build()
+	if err := srv.RegisterRoutes(*route); err != nil {
+		t.Fatalf("RegisterRoutes() error = %v", err)
+	}
+
+	testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	var found bool
+	for _, r := range rec.Calls() {
+		if r.Op == metrics.OpInc && strings.HasSuffix(r.Name, "_requests_total") {
+			found = true
+			if r.Labels["route"] != "GetUser" || r.Labels["path"] != "/users/:id" {
+				t.Errorf("requests_total labels = %v, want route=GetUser path=/users/:id", r.Labels)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no requests_total record in %v", rec.Calls())
+	}
+}
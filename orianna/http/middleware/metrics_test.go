@@ -190,6 +190,38 @@ func TestMetricsMiddleware(t *testing.T) {
 	})
 }
 
+func TestMetricsMiddleware_WithSizeMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCtx := coremocks.NewMockContext(ctrl)
+	mockClient := mocks.NewMockClient(ctrl)
+
+	mockCtx.EXPECT().Next().Return(nil)
+	mockCtx.EXPECT().ResponseStatusCode().Return(200)
+	mockCtx.EXPECT().Context().Return(context.Background()).AnyTimes()
+	mockCtx.EXPECT().Method().Return("POST").AnyTimes()
+	mockCtx.EXPECT().RoutePath().Return("/api/users").AnyTimes()
+	mockCtx.EXPECT().Get("Content-Length").Return("42")
+	mockCtx.EXPECT().ResponseBody().Return([]byte(`{"ok":true}`))
+
+	mockClient.EXPECT().GaugeInc(gomock.Any(), "api_in_flight_requests")
+	mockClient.EXPECT().GaugeDec(gomock.Any(), "api_in_flight_requests")
+	mockClient.EXPECT().Inc(gomock.Any(), "api_requests_total", gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	mockClient.EXPECT().Duration(gomock.Any(), "api_request_duration_seconds", gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	// Body sizes are only recorded with WithSizeMetrics
+	mockClient.EXPECT().Histogram(gomock.Any(), "api_request_size_bytes", float64(42),
+		"method", "POST", "path", "/api/users")
+	mockClient.EXPECT().Histogram(gomock.Any(), "api_response_size_bytes", float64(11),
+		"method", "POST", "path", "/api/users")
+
+	mw := MetricsMiddleware(mockClient, "api", WithSizeMetrics())
+	if err := mw(mockCtx); err != nil {
+		t.Errorf("MetricsMiddleware() error = %v, want nil", err)
+	}
+}
+
 func TestErrorClassFromStatus(t *testing.T) {
 	tests := []struct {
 		name       string
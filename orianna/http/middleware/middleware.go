@@ -113,6 +113,31 @@ func SkipForPathPrefixes(middleware core.Middleware, prefixes ...string) core.Mi
 	}
 }
 
+// SkipForPathSegments skips middleware for paths equal to one of the given
+// prefixes or below it: SkipForPathSegments(mw, "/health") skips for /health
+// and /health/ready, but not for /healthcheck. Matching is done on whole path
+// segments by walking up the request path (O(depth) per request).
+func SkipForPathSegments(middleware core.Middleware, prefixes ...string) core.Middleware {
+	prefixSet := make(map[string]struct{}, len(prefixes))
+	for _, prefix := range prefixes {
+		prefixSet[strings.TrimSuffix(prefix, "/")] = struct{}{}
+	}
+
+	return func(ctx core.Context) error {
+		p := ctx.Path()
+		for {
+			if _, ok := prefixSet[p]; ok {
+				return ctx.Next()
+			}
+			i := strings.LastIndexByte(p, '/')
+			if i < 0 {
+				return middleware(ctx)
+			}
+			p = p[:i]
+		}
+	}
+}
+
 // Before executes a function before the middleware
 func Before(middleware core.Middleware, beforeFunc func(core.Context)) core.Middleware {
 	return func(ctx core.Context) error {
@@ -135,6 +160,8 @@ func After(middleware core.Middleware, afterFunc func(core.Context, error)) core
 // The recovered error is returned to upstream middleware (e.g., logging, metrics)
 // so the panic is visible in the middleware chain.
 // Includes request_id and trace_id for incident correlation.
+// A panic with an error that is or wraps a *core.ErrorResponse is answered with
+// that error's status and body; any other value gets INTERNAL_ERROR (500).
 // Accepts an optional *logger.Logger; defaults to package-level logger.
 func Recover(mw core.Middleware, log ...*logger.Logger) core.Middleware {
 	l := defaultLog
@@ -155,9 +182,12 @@ func Recover(mw core.Middleware, log ...*logger.Logger) core.Middleware {
 					"request_id", requestID,
 					"trace_id", traceID,
 				)
-				// Use NewErrorResponse (not pool-based) because errResp escapes
-				// via returnErr and may be inspected by upstream middleware.
-				errResp := core.NewErrorResponse("INTERNAL_ERROR", core.StatusInternalServerError, "Internal server error")
+				errResp, ok := core.PanicErrorResponse(r)
+				if !ok {
+					// Use NewErrorResponse (not pool-based) because errResp escapes
+					// via returnErr and may be inspected by upstream middleware.
+					errResp = core.NewErrorResponse("INTERNAL_ERROR", core.StatusInternalServerError, "Internal server error")
+				}
 				_ = core.SendError(ctx, errResp)
 				returnErr = errResp
 			}
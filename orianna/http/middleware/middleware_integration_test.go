@@ -38,6 +38,45 @@ func TestMiddleware_SkipForPathPrefixes(t *testing.T) {
 	}
 }
 
+func TestMiddleware_SkipForPathSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	baseMiddleware := func(ctx core.Context) error {
+		return errors.New("middleware executed")
+	}
+	skipper := SkipForPathSegments(baseMiddleware, "/health", "/api/public/")
+
+	tests := []struct {
+		path    string
+		skipped bool
+	}{
+		{"/health", true},
+		{"/health/", true},
+		{"/health/ready", true},
+		{"/api/public", true},
+		{"/api/public/docs", true},
+		{"/healthcheck", false},
+		{"/api/publicity", false},
+		{"/api", false},
+		{"/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ctx := mocks.NewMockContext(ctrl)
+			ctx.EXPECT().Path().Return(tt.path).AnyTimes()
+			ctx.EXPECT().Next().Return(nil).AnyTimes()
+			err := skipper(ctx)
+			if tt.skipped && err != nil {
+				t.Errorf("expected middleware skipped, got: %v", err)
+			}
+			if !tt.skipped && (err == nil || err.Error() != "middleware executed") {
+				t.Errorf("expected middleware error, got: %v", err)
+			}
+		})
+	}
+}
+
 func TestMiddleware_Redaction(t *testing.T) {
 	headers := map[string]string{
 		"Authorization": "Bearer secret",
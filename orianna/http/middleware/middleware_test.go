@@ -5,6 +5,8 @@ package middleware
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -383,6 +385,29 @@ func TestRecover(t *testing.T) {
 		}
 	})
 
+	t.Run("panic with error response keeps its status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCtx := mocks.NewMockContext(ctrl)
+		mockCtx.EXPECT().Accepts(gomock.Any(), gomock.Any()).Return("application/json").AnyTimes()
+		mockCtx.EXPECT().Get(gomock.Any()).Return("").AnyTimes()
+		mockCtx.EXPECT().RequestID().Return("test-req-id").AnyTimes()
+		mockCtx.EXPECT().UseProperHTTPStatus().Return(true).AnyTimes()
+		mockCtx.EXPECT().Status(core.StatusNotFound).Return(mockCtx)
+		mockCtx.EXPECT().JSON(gomock.Any()).Return(nil)
+		mockCtx.EXPECT().Path().Return("/orders/7").AnyTimes()
+		mockCtx.EXPECT().Method().Return("GET").AnyTimes()
+		mockCtx.EXPECT().Locals("trace_id").Return(nil).AnyTimes()
+
+		mw := func(_ core.Context) error {
+			panic(core.NewErrorResponse("ORDER_NOT_FOUND", core.StatusNotFound, "Order not found"))
+		}
+		err := Recover(mw)(mockCtx)
+		var errResp *core.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.Code != "ORDER_NOT_FOUND" {
+			t.Fatalf("Recover() error = %v, want the ORDER_NOT_FOUND response", err)
+		}
+	})
+
 	t.Run("passes through normal errors", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockCtx := mocks.NewMockContext(ctrl)
@@ -548,6 +573,7 @@ func TestSlowRequestDetector(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockCtx := mocks.NewMockContext(ctrl)
 		mockCtx.EXPECT().Accepts(gomock.Any(), gomock.Any()).Return("application/json").AnyTimes()
+		mockCtx.EXPECT().Locals(gomock.Any()).Return(nil).AnyTimes()
 
 		mockCtx.EXPECT().Next().Return(nil)
 		// No calls to RequestID, Method, RoutePath, ResponseStatusCode expected
@@ -564,6 +590,7 @@ func TestSlowRequestDetector(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockCtx := mocks.NewMockContext(ctrl)
 		mockCtx.EXPECT().Accepts(gomock.Any(), gomock.Any()).Return("application/json").AnyTimes()
+		mockCtx.EXPECT().Locals(gomock.Any()).Return(nil).AnyTimes()
 
 		// Next() sleeps to exceed threshold
 		mockCtx.EXPECT().Next().DoAndReturn(func() error {
@@ -590,6 +617,7 @@ func TestSlowRequestDetector(t *testing.T) {
 		mockCtx := mocks.NewMockContext(ctrl)
 		mockCtx.EXPECT().Accepts(gomock.Any(), gomock.Any()).Return("application/json").AnyTimes()
 
+		mockCtx.EXPECT().Locals(gomock.Any()).Return(nil).AnyTimes()
 		expectedErr := errors.New("handler failed")
 		mockCtx.EXPECT().Next().Return(expectedErr)
 
@@ -691,3 +719,259 @@ func TestRequestIDMiddleware(t *testing.T) {
 		}
 	})
 }
+
+// SingleFlight Tests
+
+func TestSingleFlight(t *testing.T) {
+	t.Run("coalesces concurrent identical requests", func(t *testing.T) {
+		const n = 10
+		ctrl := gomock.NewController(t)
+
+		var arrived, executions atomic.Int32
+		release := make(chan struct{})
+		keyFn := func(_ core.Context) string {
+			arrived.Add(1)
+			return "/reports/summary"
+		}
+		mw := SingleFlight(keyFn)
+
+		body := []byte(`{"total":42}`)
+		var wg sync.WaitGroup
+		for range n {
+			var ranHandler bool
+			mockCtx := mocks.NewMockContext(ctrl)
+			mockCtx.EXPECT().Method().Return("GET").AnyTimes()
+			mockCtx.EXPECT().Next().DoAndReturn(func() error {
+				ranHandler = true
+				executions.Add(1)
+				<-release
+				return nil
+			}).MaxTimes(1)
+			mockCtx.EXPECT().ResponseStatusCode().Return(200).AnyTimes()
+			mockCtx.EXPECT().ResponseHeaders().DoAndReturn(func() map[string][]string {
+				if !ranHandler {
+					return map[string][]string{"X-Request-Id": {"own"}}
+				}
+				return map[string][]string{
+					"Content-Type": {"application/json"},
+					"Set-Cookie":   {"session=leader"},
+					"X-Request-Id": {"leader"},
+				}
+			}).AnyTimes()
+			mockCtx.EXPECT().ResponseBody().Return(body).AnyTimes()
+			// Followers replay the leader's response, without its cookie or request ID
+			mockCtx.EXPECT().Set("Content-Type", "application/json").MaxTimes(1)
+			mockCtx.EXPECT().Status(200).Return(mockCtx).MaxTimes(1)
+			mockCtx.EXPECT().SendBytes(body).Return(nil).MaxTimes(1)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := mw(mockCtx); err != nil {
+					t.Errorf("SingleFlight() error = %v", err)
+				}
+			}()
+		}
+
+		// Let every request reach the group before the leader finishes
+		for arrived.Load() < n {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := executions.Load(); got != 1 {
+			t.Errorf("handler executed %d times, want 1", got)
+		}
+	})
+
+	t.Run("non-idempotent methods are not coalesced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCtx := mocks.NewMockContext(ctrl)
+		mockCtx.EXPECT().Method().Return("POST")
+		mockCtx.EXPECT().Next().Return(nil)
+
+		called := false
+		mw := SingleFlight(func(_ core.Context) string {
+			called = true
+			return "key"
+		})
+		if err := mw(mockCtx); err != nil {
+			t.Errorf("SingleFlight() error = %v", err)
+		}
+		if called {
+			t.Error("keyFn should not be called for POST")
+		}
+	})
+
+	t.Run("shares the leader error", func(t *testing.T) {
+		group := &flightGroup{calls: make(map[string]*flightCall)}
+		want := errors.New("boom")
+		res, shared := group.do("k", func() *flightResult { return &flightResult{err: want} })
+		if shared || !errors.Is(res.err, want) {
+			t.Errorf("do() = (%v, %v), want (%v, false)", res.err, shared, want)
+		}
+		if len(group.calls) != 0 {
+			t.Error("completed call should be removed from the group")
+		}
+	})
+}
+
+func TestRequireHeaders(t *testing.T) {
+	// newCtx returns a mock context with the given request headers that
+	// captures the error response sent by core.SendError.
+	newCtx := func(t *testing.T, headers map[string]string, sent **core.ErrorResponse) *mocks.MockContext {
+		ctrl := gomock.NewController(t)
+		mockCtx := mocks.NewMockContext(ctrl)
+		mockCtx.EXPECT().Get(gomock.Any()).DoAndReturn(func(key string, _ ...string) string {
+			return headers[key]
+		}).AnyTimes()
+		mockCtx.EXPECT().RequestID().Return("req-1").AnyTimes()
+		mockCtx.EXPECT().UseProperHTTPStatus().Return(true).AnyTimes()
+		mockCtx.EXPECT().Status(core.StatusBadRequest).Return(mockCtx).AnyTimes()
+		mockCtx.EXPECT().JSON(gomock.Any()).DoAndReturn(func(v any) error {
+			*sent, _ = v.(*core.ErrorResponse)
+			return nil
+		}).AnyTimes()
+		return mockCtx
+	}
+
+	t.Run("rejects the first missing header", func(t *testing.T) {
+		var sent *core.ErrorResponse
+		mockCtx := newCtx(t, map[string]string{"X-Api-Key": "secret"}, &sent)
+
+		mw := RequireHeaders("X-Api-Key", core.HeaderContentType, "X-Tenant")
+		if err := mw(mockCtx); err != nil {
+			t.Fatalf("RequireHeaders() error = %v", err)
+		}
+		if sent == nil {
+			t.Fatal("expected an error response")
+		}
+		if sent.Code != "MISSING_HEADER" || sent.HTTPStatus != core.StatusBadRequest {
+			t.Errorf("response = %s/%d, want MISSING_HEADER/400", sent.Code, sent.HTTPStatus)
+		}
+		if got := sent.Details["header"]; got != core.HeaderContentType {
+			t.Errorf("details.header = %v, want %s", got, core.HeaderContentType)
+		}
+	})
+
+	t.Run("passes when all headers are present", func(t *testing.T) {
+		var sent *core.ErrorResponse
+		mockCtx := newCtx(t, map[string]string{"X-Api-Key": "secret", core.HeaderContentType: "application/json"}, &sent)
+		mockCtx.EXPECT().Next().Return(nil)
+
+		if err := RequireHeaders("X-Api-Key", core.HeaderContentType)(mockCtx); err != nil {
+			t.Fatalf("RequireHeaders() error = %v", err)
+		}
+		if sent != nil {
+			t.Errorf("unexpected error response %+v", sent)
+		}
+	})
+
+	t.Run("value variant rejects a wrong value", func(t *testing.T) {
+		var sent *core.ErrorResponse
+		mockCtx := newCtx(t, map[string]string{"X-Api-Key": "wrong"}, &sent)
+
+		if err := RequireHeaderValue("X-Api-Key", "secret")(mockCtx); err != nil {
+			t.Fatalf("RequireHeaderValue() error = %v", err)
+		}
+		if sent == nil || sent.Code != "INVALID_HEADER" || sent.Details["header"] != "X-Api-Key" {
+			t.Errorf("response = %+v, want INVALID_HEADER for X-Api-Key", sent)
+		}
+	})
+
+	t.Run("value variant passes a matching value", func(t *testing.T) {
+		var sent *core.ErrorResponse
+		mockCtx := newCtx(t, map[string]string{"X-Api-Key": "secret"}, &sent)
+		mockCtx.EXPECT().Next().Return(nil)
+
+		if err := RequireHeaderValue("X-Api-Key", "secret")(mockCtx); err != nil {
+			t.Fatalf("RequireHeaderValue() error = %v", err)
+		}
+		if sent != nil {
+			t.Errorf("unexpected error response %+v", sent)
+		}
+	})
+}
+
+func TestWeightedSemaphore(t *testing.T) {
+	t.Run("queue full rejects immediately", func(t *testing.T) {
+		sem := newWeightedSemaphore(1, 0)
+		if !sem.acquire(1, time.Second, nil) {
+			t.Fatal("first acquire should succeed")
+		}
+		start := time.Now()
+		if sem.acquire(1, time.Second, nil) {
+			t.Fatal("acquire with no queue room should fail")
+		}
+		if time.Since(start) > 100*time.Millisecond {
+			t.Error("rejection should not wait")
+		}
+	})
+
+	t.Run("queued waiter times out and leaves the queue", func(t *testing.T) {
+		sem := newWeightedSemaphore(1, 1)
+		sem.acquire(1, time.Second, nil)
+		if sem.acquire(1, 20*time.Millisecond, nil) {
+			t.Fatal("acquire should time out")
+		}
+		if len(sem.waiters) != 0 {
+			t.Errorf("waiters = %d, want 0", len(sem.waiters))
+		}
+		sem.release(1)
+		if !sem.acquire(1, time.Second, nil) {
+			t.Error("acquire after release should succeed")
+		}
+	})
+
+	t.Run("done cancels the wait", func(t *testing.T) {
+		sem := newWeightedSemaphore(1, 1)
+		sem.acquire(1, time.Second, nil)
+		done := make(chan struct{})
+		close(done)
+		if sem.acquire(1, time.Second, done) {
+			t.Error("acquire should fail once done is closed")
+		}
+	})
+
+	t.Run("release grants waiters in order", func(t *testing.T) {
+		sem := newWeightedSemaphore(2, 2)
+		sem.acquire(2, time.Second, nil)
+		granted := make(chan int64, 2)
+		for _, n := range []int64{2, 1} {
+			go func() {
+				if sem.acquire(n, time.Second, nil) {
+					granted <- n
+				}
+			}()
+			// Let the waiter queue before starting the next one
+			for {
+				sem.mu.Lock()
+				queued := len(sem.waiters)
+				sem.mu.Unlock()
+				if queued > 0 && (n == 2 || queued > 1) {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}
+		sem.release(2)
+		if n := <-granted; n != 2 {
+			t.Fatalf("first grant = %d, want 2", n)
+		}
+		sem.release(2)
+		if n := <-granted; n != 1 {
+			t.Errorf("second grant = %d, want 1", n)
+		}
+	})
+}
+
+func TestMaxConcurrent_PanicsOnInvalidLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MaxConcurrent(0) should panic")
+		}
+	}()
+	MaxConcurrent(0)
+}
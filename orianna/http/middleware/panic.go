@@ -0,0 +1,96 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/anthanhphan/gosdk/logger"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/shared/ctxkeys"
+	"github.com/anthanhphan/gosdk/utils"
+)
+
+// PanicResponder writes the response for a panic recovered from a handler.
+// recovered is the value passed to panic and location is where it happened
+// ("file:line", empty if unknown). Return nil once the response is written;
+// a non-nil error is passed on to the framework's error handler.
+type PanicResponder func(ctx core.Context, recovered any, location string) error
+
+// DefaultPanicResponder returns a responder that sends the standard
+// INTERNAL_ERROR response. When includeStack is true the panic location and
+// stack trace are added to the error details, which helps during development.
+// The stack is always suppressed when ENV is production.
+//
+// If the panic value is an error that is or wraps a *core.ErrorResponse
+// (e.g., panic(core.NewErrorResponse("NOT_FOUND", 404, "..."))), that error's
+// status and body are sent instead. Any other panic value, including other
+// error types, gets the INTERNAL_ERROR response.
+func DefaultPanicResponder(includeStack bool) PanicResponder {
+	return func(ctx core.Context, recovered any, location string) error {
+		if errResp, ok := core.PanicErrorResponse(recovered); ok {
+			return core.SendError(ctx, errResp)
+		}
+		errResp := core.NewErrorResponse("INTERNAL_ERROR", core.StatusInternalServerError, "Internal server error")
+		if includeStack && utils.GetEnvironment() != utils.EnvProduction {
+			errResp.WithDetails("location", location).
+				WithDetails("stack", string(debug.Stack()))
+		}
+		return core.SendError(ctx, errResp)
+	}
+}
+
+// RecoverPanics recovers panics from downstream handlers, logs them with their
+// location, and lets responder write the response. If responder itself panics,
+// the standard INTERNAL_ERROR response is sent instead.
+// Accepts an optional *logger.Logger; defaults to package-level logger.
+//
+// Example:
+//
+//	middleware.RecoverPanics(func(ctx core.Context, _ any, _ string) error {
+//	    return ctx.Status(500).JSON(core.Map{"support_ref": ctx.RequestID()})
+//	})
+func RecoverPanics(responder PanicResponder, log ...*logger.Logger) core.Middleware {
+	l := defaultLog
+	if len(log) > 0 && log[0] != nil {
+		l = log[0]
+	}
+	if responder == nil {
+		responder = DefaultPanicResponder(false)
+	}
+	return func(ctx core.Context) (returnErr error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			location, _ := utils.GetPanicLocation()
+			traceID, _ := ctx.Locals(ctxkeys.TraceID.Key()).(string)
+			l.Errorw("panic recovered",
+				"error", fmt.Sprint(r),
+				"location", location,
+				"path", ctx.Path(),
+				"method", ctx.Method(),
+				"request_id", ctx.RequestID(),
+				"trace_id", traceID,
+			)
+			returnErr = respondToPanic(ctx, responder, r, location, l)
+		}()
+		return ctx.Next()
+	}
+}
+
+// respondToPanic runs responder, falling back to the default response if it panics.
+func respondToPanic(ctx core.Context, responder PanicResponder, recovered any, location string, l *logger.Logger) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.Errorw("panic responder panicked",
+				"error", fmt.Sprint(r),
+				"request_id", ctx.RequestID(),
+			)
+			err = DefaultPanicResponder(false)(ctx, recovered, location)
+		}
+	}()
+	return responder(ctx, recovered, location)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/shared/ctxkeys"
+)
+
+// RouteName stores name in Locals under the route_name key so metrics and
+// access logs can identify the route by a stable, human-friendly name. Routes
+// built with RouteBuilder.Name get it automatically.
+func RouteName(name string) core.Middleware {
+	return func(ctx core.Context) error {
+		ctx.Locals(ctxkeys.RouteName.Key(), name)
+		return ctx.Next()
+	}
+}
+
+// RouteNameFrom returns the name set by RouteName, or "" for unnamed routes.
+func RouteNameFrom(ctx core.Context) string {
+	name, _ := ctx.Locals(ctxkeys.RouteName.Key()).(string)
+	return name
+}
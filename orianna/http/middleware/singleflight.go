@@ -0,0 +1,138 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// defaultSingleFlightMethods are the methods coalesced when none are given.
+var defaultSingleFlightMethods = []string{"GET", "HEAD"}
+
+// SingleFlight coalesces concurrent identical requests so that only one of them
+// executes the downstream handler; the others wait and receive a copy of its
+// status, headers and body (or the same error). This protects expensive
+// endpoints from thundering-herd traffic.
+//
+// keyFn identifies "identical" requests. Include anything that changes the
+// response (path, query, tenant, user) -- requests sharing a key share a response.
+// An empty key bypasses coalescing. Only GET and HEAD are coalesced unless
+// methods are given. Headers a waiting request already set (e.g., X-Request-ID)
+// are kept, and Set-Cookie is never copied to waiting requests.
+//
+// Example:
+//
+//	srv.GET("/reports/summary", summaryHandler,
+//	    middleware.SingleFlight(func(ctx core.Context) string {
+//	        return ctx.OriginalURL()
+//	    }),
+//	)
+func SingleFlight(keyFn func(core.Context) string, methods ...string) core.Middleware {
+	if len(methods) == 0 {
+		methods = defaultSingleFlightMethods
+	}
+	allowed := make([]string, len(methods))
+	for i, m := range methods {
+		allowed[i] = strings.ToUpper(m)
+	}
+
+	group := &flightGroup{calls: make(map[string]*flightCall)}
+
+	return func(ctx core.Context) error {
+		if !slices.Contains(allowed, ctx.Method()) {
+			return ctx.Next()
+		}
+		key := keyFn(ctx)
+		if key == "" {
+			return ctx.Next()
+		}
+
+		res, shared := group.do(key, func() *flightResult {
+			err := ctx.Next()
+			return &flightResult{
+				err:     err,
+				status:  ctx.ResponseStatusCode(),
+				headers: ctx.ResponseHeaders(),
+				body:    slices.Clone(ctx.ResponseBody()),
+			}
+		})
+		if !shared {
+			return res.err
+		}
+		if res.err != nil {
+			return res.err
+		}
+
+		// Keep headers this request already set (request ID, rate-limit, etc.)
+		own := ctx.ResponseHeaders()
+		for name, values := range res.headers {
+			if _, exists := own[name]; exists || strings.EqualFold(name, "Set-Cookie") {
+				continue
+			}
+			for i, v := range values {
+				if i == 0 {
+					ctx.Set(name, v)
+				} else {
+					ctx.Append(name, v)
+				}
+			}
+		}
+		return ctx.Status(res.status).SendBytes(res.body)
+	}
+}
+
+// flightResult is the captured outcome of the leading request.
+type flightResult struct {
+	err     error
+	status  int
+	headers map[string][]string
+	body    []byte
+}
+
+// flightCall is an in-flight or completed call for one key.
+type flightCall struct {
+	wg  sync.WaitGroup
+	res *flightResult
+}
+
+// flightGroup deduplicates concurrent calls by key
+// (the same semantics as golang.org/x/sync/singleflight.Group.Do).
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do runs fn once per key among concurrent callers. shared reports whether
+// the caller waited on another caller's result instead of running fn.
+func (g *flightGroup) do(key string, fn func() *flightResult) (res *flightResult, shared bool) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.res, true
+	}
+	c := &flightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		// If fn panicked, waiting callers get an error instead of hanging;
+		// the panic itself continues up the leader's stack.
+		if c.res == nil {
+			c.res = &flightResult{err: fmt.Errorf("singleflight: leading request for %q panicked", key)}
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.res = fn()
+	return c.res, false
+}
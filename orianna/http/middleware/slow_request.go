@@ -6,10 +6,15 @@ import (
 	"time"
 
 	"github.com/anthanhphan/gosdk/logger"
+	"github.com/anthanhphan/gosdk/metrics"
 	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/shared/observability"
 	"github.com/anthanhphan/gosdk/tracing"
 )
 
+// slowRequestThresholdKey is the Locals key holding a per-route threshold.
+const slowRequestThresholdKey = "slow_request_threshold"
+
 // SlowRequestDetector creates a middleware that logs a warning when a request
 // exceeds the given duration threshold. This enables rapid identification of
 // performance degradation in production without requiring external tooling.
@@ -17,30 +22,58 @@ import (
 // The log includes request_id, trace_id, method, route path, actual duration,
 // status, and threshold for immediate incident correlation.
 func SlowRequestDetector(threshold time.Duration, log ...*logger.Logger) core.Middleware {
+	return SlowRequestDetectorWithMetrics(threshold, nil, "", log...)
+}
+
+// SlowRequestDetectorWithMetrics is SlowRequestDetector that also increments
+// {subsystem}_slow_requests_total (labels method, path) for every slow request.
+// A nil client disables the counter.
+//
+// Routes can override the threshold with SlowRequestThreshold
+// (RouteBuilder.SlowRequestThreshold).
+func SlowRequestDetectorWithMetrics(threshold time.Duration, client metrics.Client, subsystem string, log ...*logger.Logger) core.Middleware {
 	l := defaultLog
 	if len(log) > 0 && log[0] != nil {
 		l = log[0]
 	}
-	thresholdMs := threshold.Milliseconds()
+	slowRequestsName := subsystem + observability.SuffixSlowRequestsTotal
 	return func(ctx core.Context) error {
 		start := time.Now()
 
 		err := ctx.Next()
 
+		limit := threshold
+		if override, ok := ctx.Locals(slowRequestThresholdKey).(time.Duration); ok && override > 0 {
+			limit = override
+		}
 		duration := time.Since(start)
-		if duration >= threshold {
+		if duration >= limit {
 			traceID := tracing.TraceIDFromContext(ctx.Context())
+			method, path := ctx.Method(), ctx.RoutePath()
 			l.Warnw("slow request detected",
 				"request_id", ctx.RequestID(),
 				"trace_id", traceID,
-				"method", ctx.Method(),
-				"path", ctx.RoutePath(),
+				"method", method,
+				"path", path,
 				"duration_ms", duration.Milliseconds(),
-				"threshold_ms", thresholdMs,
+				"threshold_ms", limit.Milliseconds(),
 				"status", ctx.ResponseStatusCode(),
 			)
+			if client != nil {
+				client.Inc(ctx.Context(), slowRequestsName, "method", method, "path", path)
+			}
 		}
 
 		return err
 	}
 }
+
+// SlowRequestThreshold overrides the server's slow request threshold for the
+// routes it is attached to, e.g., a longer budget for a report export. It only
+// takes effect when slow request detection is enabled on the server.
+func SlowRequestThreshold(threshold time.Duration) core.Middleware {
+	return func(ctx core.Context) error {
+		ctx.Locals(slowRequestThresholdKey, threshold)
+		return ctx.Next()
+	}
+}
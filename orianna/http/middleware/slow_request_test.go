@@ -0,0 +1,50 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/metrics"
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestSlowRequestThreshold_Server(t *testing.T) {
+	rec := metrics.NewRecordingClient()
+	srv := testutil.NewServer(t, server.WithMetrics(rec), server.WithSlowRequestThreshold(20*time.Millisecond))
+	sleepy := func(ctx core.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		return ctx.SendString("done")
+	}
+	routes := []routing.Route{
+		*routing.NewRoute("/fast").GET().Handler(func(ctx core.Context) error { return ctx.SendString("ok") }).Build(),
+		*routing.NewRoute("/slow").GET().Handler(sleepy).Build(),
+		*routing.NewRoute("/export").GET().SlowRequestThreshold(time.Second).Handler(sleepy).Build(),
+	}
+	if err := srv.RegisterRoutes(routes...); err != nil {
+		t.Fatalf("RegisterRoutes() error = %v", err)
+	}
+
+	for _, path := range []string{"/fast", "/slow", "/export"} {
+		testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	for path, want := range map[string]float64{"/fast": 0, "/slow": 1, "/export": 0} {
+		got := rec.Counter("test_slow_requests_total", map[string]string{"method": "GET", "path": path})
+		if got != want {
+			t.Errorf("slow_requests_total{path=%s} = %v, want %v", path, got, want)
+		}
+	}
+
+	conf := &configuration.Config{ServiceName: "test"}
+	if _, err := server.NewServer(conf, server.WithSlowRequestThreshold(0)); err == nil {
+		t.Error("WithSlowRequestThreshold(0) should fail")
+	}
+}
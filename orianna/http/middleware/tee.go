@@ -0,0 +1,34 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware
+
+import (
+	"bytes"
+	"math/rand/v2"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// TeeBody passes a copy of the request body to sink for a sampled fraction of
+// requests (0 disables it, 1 or more tees every request), e.g., to capture
+// what a flaky client really sends. The body is buffered by the server, which
+// already rejects bodies over MaxBodySize, so reading it here does not consume
+// it: BindBody and ctx.Body() still see the full body downstream.
+//
+// sink runs synchronously before the next handler and owns the slice it is
+// given. Bodies may hold credentials or personal data; redact before storing.
+//
+// Example:
+//
+//	srv.POST("/webhooks", handleWebhook, middleware.TeeBody(func(ctx core.Context, body []byte) {
+//	    logger.Debugw("webhook body", "request_id", ctx.RequestID(), "body", string(body))
+//	}, 0.01))
+func TeeBody(sink func(ctx core.Context, body []byte), fraction float64) core.Middleware {
+	return func(ctx core.Context) error {
+		if sink == nil || fraction <= 0 || (fraction < 1 && rand.Float64() >= fraction) {
+			return ctx.Next()
+		}
+		sink(ctx, bytes.Clone(ctx.Body()))
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+)
+
+func TestTeeBody_Server(t *testing.T) {
+	type payload struct {
+		Name string `json:"name" validate:"required"`
+	}
+	var teed []string
+	srv := testutil.NewServer(t)
+	sink := func(_ core.Context, body []byte) { teed = append(teed, string(body)) }
+	handler := func(ctx core.Context) error {
+		p, err := core.BindBody[payload](ctx, true)
+		if err != nil {
+			return err
+		}
+		return ctx.SendString(p.Name)
+	}
+	if err := srv.POST("/all", handler, middleware.TeeBody(sink, 1)); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+	if err := srv.POST("/none", handler, middleware.TeeBody(sink, 0)); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+
+	for _, path := range []string{"/all", "/none"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"name":"gopher"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if resp, body := testutil.Send(t, srv, req); resp.StatusCode != http.StatusOK || body != "gopher" {
+			t.Errorf("%s: status = %d, body = %q, want 200 gopher", path, resp.StatusCode, body)
+		}
+	}
+	if want := []string{`{"name":"gopher"}`}; fmt.Sprint(teed) != fmt.Sprint(want) {
+		t.Errorf("teed = %v, want %v", teed, want)
+	}
+}
@@ -19,6 +19,8 @@ import (
 //
 // Uses ctx.RoutePath() instead of ctx.Path() to record route patterns (e.g., "/users/:id")
 // rather than actual paths (e.g., "/users/123"), preventing unbounded span name cardinality.
+// A returned error is recorded on the span with http.status_code 500, the
+// status the error handler answers it with.
 func TracingMiddleware(client tracing.Client) core.Middleware {
 	return func(ctx core.Context) error {
 		// Cache method to avoid repeated interface method calls
@@ -65,6 +67,10 @@ func TracingMiddleware(client tracing.Client) core.Middleware {
 		// attributes in a single SetAttributes call to reduce marshaling overhead.
 		routePath := ctx.RoutePath()
 		statusCode := ctx.ResponseStatusCode()
+		if err != nil && statusCode < 400 && !core.IsClientDisconnect(err) {
+			// The status is not set yet: the error handler answers with a 500
+			statusCode = core.StatusInternalServerError
+		}
 		span.SetName(method + " " + routePath)
 		span.SetAttributes(
 			attribute.String("http.route", routePath),
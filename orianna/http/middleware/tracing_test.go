@@ -0,0 +1,132 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+	"github.com/anthanhphan/gosdk/tracing"
+)
+
+// recordingTracer is a tracing.Client recording the spans it starts.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type tracerCtxKey struct{}
+
+func (r *recordingTracer) StartSpan(ctx context.Context, name string, _ ...tracing.SpanOption) (context.Context, tracing.Span) {
+	span := &recordedSpan{name: name, attrs: map[attribute.Key]attribute.Value{}}
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return context.WithValue(ctx, tracerCtxKey{}, span), span
+}
+
+func (r *recordingTracer) Shutdown(context.Context) error { return nil }
+
+func (r *recordingTracer) Tracer() trace.Tracer { return tracing.NewNoopClient().Tracer() }
+
+type recordedSpan struct {
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	err    error
+	ended  bool
+}
+
+func (s *recordedSpan) End() { s.ended = true }
+
+func (s *recordedSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	for _, kv := range attrs {
+		s.attrs[kv.Key] = kv.Value
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+
+func (s *recordedSpan) SetName(name string) { s.name = name }
+
+func (s *recordedSpan) AddEvent(string, ...attribute.KeyValue) {}
+
+func (s *recordedSpan) SpanContext() trace.SpanContext { return trace.SpanContext{} }
+
+func TestTracingMiddleware_Server(t *testing.T) {
+	tracer := &recordingTracer{}
+	srv := testutil.NewServer(t, server.WithTracing(tracer))
+	var handlerSpan any
+	if err := srv.GET("/users/:id", func(ctx core.Context) error {
+		handlerSpan = ctx.Context().Value(tracerCtxKey{})
+		return ctx.SendString("ok")
+	}); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	if err := srv.GET("/orders/:id", func(ctx core.Context) error {
+		return core.NewErrorResponse("NOT_FOUND", http.StatusNotFound, "order not found")
+	}); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	if err := srv.GET("/fail", func(ctx core.Context) error {
+		return errors.New("boom")
+	}); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	for _, path := range []string{"/users/42", "/orders/7", "/fail"} {
+		testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("got %d spans, want one per request", len(tracer.spans))
+	}
+	tests := []struct {
+		name   string
+		status int64
+		code   codes.Code
+		err    string
+	}{
+		{name: "GET /users/:id", status: 200, code: codes.Ok},
+		{name: "GET /orders/:id", status: 500, code: codes.Error, err: "[NOT_FOUND] order not found"},
+		{name: "GET /fail", status: 500, code: codes.Error, err: "boom"},
+	}
+	for i, tt := range tests {
+		span := tracer.spans[i]
+		if span.name != tt.name {
+			t.Errorf("span %d name = %q, want %q", i, span.name, tt.name)
+		}
+		if got := span.attrs["http.route"].AsString(); got != strings.TrimPrefix(tt.name, "GET ") {
+			t.Errorf("%s: http.route = %q", tt.name, got)
+		}
+		if got := span.attrs["http.status_code"].AsInt64(); got != tt.status {
+			t.Errorf("%s: http.status_code = %d, want %d", tt.name, got, tt.status)
+		}
+		if span.status != tt.code {
+			t.Errorf("%s: status = %v, want %v", tt.name, span.status, tt.code)
+		}
+		if (span.err == nil) != (tt.err == "") || (span.err != nil && span.err.Error() != tt.err) {
+			t.Errorf("%s: recorded error = %v, want %q", tt.name, span.err, tt.err)
+		}
+		if !span.ended {
+			t.Errorf("%s: span not ended", tt.name)
+		}
+	}
+	if handlerSpan != tracer.spans[0] {
+		t.Error("handler ctx.Context() does not carry the request span")
+	}
+}
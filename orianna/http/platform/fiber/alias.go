@@ -0,0 +1,46 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+	"github.com/gofiber/fiber/v3"
+)
+
+// aliasRewrittenKey marks a request already rewritten by an alias, so an alias
+// whose target matches another alias cannot loop.
+const aliasRewrittenKey = "__orianna_alias_rewritten"
+
+// aliasRoute rewrites requests matching alias to target.
+type aliasRoute struct {
+	alias  string
+	target string
+}
+
+// RegisterAlias serves requests matching aliasPath as if they were sent to
+// targetPath, filling targetPath's parameters from the request. Aliases are
+// checked in registration order before any route.
+func (s *ServerAdapter) RegisterAlias(aliasPath, targetPath string) {
+	s.aliases = append(s.aliases, aliasRoute{alias: aliasPath, target: targetPath})
+}
+
+// aliasHandler rewrites the path of a request matching an alias and restarts
+// routing so it reaches the target's handler. Like methodOverrideHandler it
+// must be the first handler on the app, so the rest of the chain runs once,
+// on the rewritten path. The query string is kept.
+func (s *ServerAdapter) aliasHandler(c fiber.Ctx) error {
+	if len(s.aliases) == 0 || c.Locals(aliasRewrittenKey) != nil {
+		return c.Next()
+	}
+	path := c.Path()
+	for _, a := range s.aliases {
+		params, ok := routing.MatchPath(a.alias, path)
+		if !ok {
+			continue
+		}
+		c.Locals(aliasRewrittenKey, true)
+		c.Path(routing.ExpandPath(a.target, func(name string) string { return params[name] }))
+		return c.RestartRouting()
+	}
+	return c.Next()
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// bodyLimitHandler enforces limit on request bodies. The server streams
+// request bodies so SaveUploadedFile can copy large uploads to disk without
+// buffering them, which leaves fasthttp's own BodyLimit check to this handler:
+//   - a declared Content-Length over limit is rejected before any handler runs
+//   - a chunked body is read up front and buffered, as it was before
+//     streaming, so Body() stays bounded
+//   - whatever the handler left unread is drained afterwards so the next
+//     request on a keep-alive connection starts at its headers
+func bodyLimitHandler(limit int) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		req := c.Request()
+		if req.Header.ContentLength() > limit {
+			c.RequestCtx().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		if req.IsBodyStream() && req.Header.ContentLength() < 0 {
+			body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+			if err != nil {
+				c.RequestCtx().SetConnectionClose()
+				return fiber.ErrBadRequest
+			}
+			if len(body) > limit {
+				c.RequestCtx().SetConnectionClose()
+				return fiber.ErrRequestEntityTooLarge
+			}
+			req.SetBody(body)
+		}
+
+		err := c.Next()
+		if req.IsBodyStream() {
+			if _, drainErr := io.Copy(io.Discard, req.BodyStream()); drainErr != nil {
+				c.RequestCtx().SetConnectionClose()
+			}
+		}
+		return err
+	}
+}
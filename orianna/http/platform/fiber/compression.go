@@ -0,0 +1,118 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp"
+)
+
+// compressionHandler compresses responses with brotli, gzip or deflate, as
+// the request's Accept-Encoding allows, and adds Accept-Encoding to Vary. It
+// replaces Fiber's compress middleware, which reads the whole body to decide
+// whether to compress, so it would drain a streamed response, and which
+// recomputes a strong ETag from the compressed body, so If-None-Match never
+// matches the tag the ETag middleware computes from the plain body.
+//
+// Streamed bodies are compressed as they are written. A strong ETag set on
+// the plain body is kept in its weak form, which the ETag middleware matches
+// against the plain body on the next request.
+func compressionHandler(level int, conf *configuration.CompressionConfig) fiber.Handler {
+	noop := func(_ *fasthttp.RequestCtx) {}
+	var compress fasthttp.RequestHandler
+	switch level {
+	case 2:
+		compress = fasthttp.CompressHandlerBrotliLevel(noop, fasthttp.CompressBrotliBestCompression, fasthttp.CompressBestCompression)
+	case 1:
+		compress = fasthttp.CompressHandlerBrotliLevel(noop, fasthttp.CompressBrotliBestSpeed, fasthttp.CompressBestSpeed)
+	case 0:
+		compress = fasthttp.CompressHandlerBrotliLevel(noop, fasthttp.CompressBrotliDefaultCompression, fasthttp.CompressDefaultCompression)
+	default:
+		return func(c fiber.Ctx) error { return c.Next() }
+	}
+	if conf == nil {
+		conf = &configuration.CompressionConfig{}
+	}
+	skipTypes := make([]string, len(conf.SkipContentTypes))
+	for i, t := range conf.SkipContentTypes {
+		skipTypes[i] = strings.ToLower(t)
+	}
+
+	return func(c fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		addVary(c, fiber.HeaderAcceptEncoding)
+		if skipCompression(c, conf.MinSize, skipTypes) {
+			return nil
+		}
+
+		etag := c.GetRespHeader(fiber.HeaderETag)
+		compress(c.RequestCtx())
+		if etag != "" && !strings.HasPrefix(etag, "W/") && c.GetRespHeader(fiber.HeaderContentEncoding) != "" {
+			c.Set(fiber.HeaderETag, "W/"+etag)
+		}
+		return nil
+	}
+}
+
+// skipCompression reports whether the response must be sent as is: HEAD
+// requests, bodiless or partial responses, range requests, no-transform,
+// bodies already encoded, skipped content types and bodies under minSize.
+func skipCompression(c fiber.Ctx, minSize int, skipTypes []string) bool {
+	resp := c.Response()
+	status := resp.StatusCode()
+	if c.Method() == fiber.MethodHead ||
+		status < 200 ||
+		status == fiber.StatusNoContent ||
+		status == fiber.StatusResetContent ||
+		status == fiber.StatusNotModified ||
+		status == fiber.StatusPartialContent ||
+		c.Get(fiber.HeaderRange) != "" ||
+		hasHeaderToken(c.Get(fiber.HeaderCacheControl), "no-transform") ||
+		hasHeaderToken(c.GetRespHeader(fiber.HeaderCacheControl), "no-transform") ||
+		c.GetRespHeader(fiber.HeaderContentEncoding) != "" {
+		return true
+	}
+
+	contentType := strings.ToLower(c.GetRespHeader(fiber.HeaderContentType))
+	for _, t := range skipTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+
+	// Reading Body() would drain a stream; its size is unknown anyway
+	if resp.IsBodyStream() {
+		return false
+	}
+	size := len(resp.Body())
+	return size == 0 || size < minSize
+}
+
+// addVary adds field to the response's Vary header unless it is already
+// listed or Vary is "*".
+func addVary(c fiber.Ctx, field string) {
+	vary := c.GetRespHeader(fiber.HeaderVary)
+	switch {
+	case vary == "":
+		c.Set(fiber.HeaderVary, field)
+	case hasHeaderToken(vary, "*"), hasHeaderToken(vary, field):
+	default:
+		c.Set(fiber.HeaderVary, vary+", "+field)
+	}
+}
+
+// hasHeaderToken reports whether the comma-separated header contains token,
+// compared case-insensitively.
+func hasHeaderToken(header, token string) bool {
+	for part := range strings.SplitSeq(header, ",") {
+		if strings.EqualFold(strings.TrimSpace(part), token) {
+			return true
+		}
+	}
+	return false
+}
@@ -28,7 +28,7 @@ func buildCORSConfig(corsConfig *configuration.CORSConfig) cors.Config {
 // Users must explicitly set *bool to false to opt out of security hardening.
 func buildCSRFConfig(csrfConfig *configuration.CSRFConfig) csrf.Config {
 	// Secure defaults: true unless explicitly set to false
-	cookieSecure := true
+	cookieSecure := configuration.DefaultCSRFCookieSecure
 	if csrfConfig.CookieSecure != nil {
 		cookieSecure = *csrfConfig.CookieSecure
 	}
@@ -57,7 +57,7 @@ func buildCSRFConfig(csrfConfig *configuration.CSRFConfig) csrf.Config {
 	case "Strict", "Lax", "None":
 		conf.CookieSameSite = csrfConfig.CookieSameSite
 	default:
-		conf.CookieSameSite = "Strict"
+		conf.CookieSameSite = configuration.DefaultCSRFSameSite
 	}
 
 	// Set idle timeout if provided
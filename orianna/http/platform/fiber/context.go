@@ -3,14 +3,26 @@
 package fiber
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"mime/multipart"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/anthanhphan/gosdk/conflux"
+	"github.com/anthanhphan/gosdk/jcodec"
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
 	"github.com/anthanhphan/gosdk/orianna/http/core"
 	"github.com/anthanhphan/gosdk/orianna/shared/ctxkeys"
 	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp"
 )
 
 var contextPool = sync.Pool{
@@ -30,8 +42,14 @@ type ContextAdapter struct {
 	fiberCtx            fiber.Ctx
 	trackedKeys         map[string]struct{}
 	useProperHTTPStatus bool
+	collapseValidation  bool
+	uploadDir           string
+	maxBodySize         int
+	trustedProxies      []netip.Prefix
 	cachedCtx           context.Context // lazily built, invalidated on Locals write
 	ctxDirty            bool            // true when Locals changed since last Context() call
+	contextFactory      func(core.Context) core.Context
+	wrapped             core.Context // contextFactory's result, built once per request
 }
 
 // AcquireContextAdapter acquires a context adapter from the pool
@@ -57,19 +75,50 @@ func (c *ContextAdapter) Reset(fiberCtx fiber.Ctx, conf *configuration.Config) {
 	c.fiberCtx = fiberCtx
 	if conf != nil {
 		c.useProperHTTPStatus = conf.UseProperHTTPStatus
+		c.collapseValidation = conf.CollapseValidationErrors
+		c.uploadDir = conf.UploadDir
+		c.maxBodySize = conf.MaxBodySize
+		c.trustedProxies = conf.TrustedProxyPrefixes()
+		c.contextFactory = conf.ContextFactory
 	} else {
 		c.useProperHTTPStatus = false
+		c.collapseValidation = false
+		c.uploadDir = ""
+		c.maxBodySize = 0
+		c.trustedProxies = nil
+		c.contextFactory = nil
 	}
 }
 
+// handlerContext returns the Context passed to middlewares and handlers: the
+// adapter itself, or the ContextFactory wrapper, built on first use so every
+// handler of the request shares it.
+func (c *ContextAdapter) handlerContext() core.Context {
+	if c.contextFactory == nil {
+		return c
+	}
+	if c.wrapped == nil {
+		if c.wrapped = c.contextFactory(c); c.wrapped == nil {
+			c.wrapped = c
+		}
+	}
+	return c.wrapped
+}
+
 // reset clears the context adapter state
 func (c *ContextAdapter) reset() {
 	c.fiberCtx = nil
 	// Clear tracked keys for reuse (Go 1.21+)
 	clear(c.trackedKeys)
 	c.useProperHTTPStatus = false
+	c.collapseValidation = false
+	c.uploadDir = ""
+	c.maxBodySize = 0
+	c.trustedProxies = nil
 	c.cachedCtx = nil
 	c.ctxDirty = false
+	c.contextFactory = nil
+	c.wrapped = nil
 }
 
 // Method returns the HTTP method of the request
@@ -133,8 +182,16 @@ func (c *ContextAdapter) Set(key, value string) {
 	c.fiberCtx.Set(key, value)
 }
 
-// Append appends the specified value to the HTTP response header field
+// Append appends the specified values to the HTTP response header field,
+// comma-separated. Set-Cookie values are added as separate headers instead,
+// since cookies cannot share one.
 func (c *ContextAdapter) Append(field string, values ...string) {
+	if strings.EqualFold(field, core.HeaderSetCookie) {
+		for _, value := range values {
+			c.fiberCtx.Response().Header.Add(field, value)
+		}
+		return
+	}
 	c.fiberCtx.Append(field, values...)
 }
 
@@ -176,9 +233,16 @@ func (c *ContextAdapter) AllQueries() map[string]string {
 	return c.fiberCtx.Queries()
 }
 
-// QueryParser binds the query parameters to a struct
+// QueryParser binds the query parameters to a struct (uses `query` struct tags).
+// Besides Fiber's repeated (ids=1&ids=2), bracketed (ids[]=1) and
+// comma-separated (ids=1,2) slices, it binds indexed slices (ids[0]=1) and
+// bracketed maps (filter[status]=active) into map[string]string or
+// map[string][]string fields.
 func (c *ContextAdapter) QueryParser(out any) error {
-	return c.fiberCtx.Bind().Query(out)
+	if err := c.fiberCtx.Bind().Query(out); err != nil {
+		return err
+	}
+	return bindBracketQuery(out, c.fiberCtx.Request().URI().QueryArgs().All())
 }
 
 // Body returns the raw request body as bytes
@@ -186,9 +250,195 @@ func (c *ContextAdapter) Body() []byte {
 	return c.fiberCtx.Body()
 }
 
-// BodyParser binds the request body to a struct
+// BodyParser binds the request body to a struct based on the Content-Type header.
+// JSON, XML and form-encoded bodies are handled by Fiber's binder, YAML bodies
+// are decoded with conflux, and a missing Content-Type falls back to JSON.
 func (c *ContextAdapter) BodyParser(out any) error {
-	return c.fiberCtx.Bind().Body(out)
+	switch bodyContentType(c.fiberCtx.Get(fiber.HeaderContentType)) {
+	case "":
+		return jcodec.Unmarshal(c.fiberCtx.Body(), out)
+	case core.MIMEApplicationYAML, core.MIMEApplicationXYAML, core.MIMETextYAML, core.MIMETextXYAML:
+		return conflux.Unmarshal(c.fiberCtx.Body(), conflux.ExtensionYAML, out)
+	default:
+		return c.fiberCtx.Bind().Body(out)
+	}
+}
+
+// NDJSON decodes a newline-delimited JSON request body one record at a time,
+// so large batches are processed without binding them into a single slice.
+// fn drives the loop: each decode call reads the next record into v and
+// returns io.EOF once the body is exhausted. decode returns the request
+// context's error once it is cancelled or past its deadline, which stops the
+// loop. The body is read from the request stream when Fiber streams it and is
+// otherwise bounded by MaxBodySize. io.EOF returned by fn is treated as success.
+//
+// Example:
+//
+//	err := ctx.NDJSON(func(decode func(v any) error) error {
+//	    for {
+//	        var event Event
+//	        if err := decode(&event); err != nil {
+//	            return err // io.EOF ends the loop successfully
+//	        }
+//	        process(event)
+//	    }
+//	})
+func (c *ContextAdapter) NDJSON(fn func(decode func(v any) error) error) error {
+	var body io.Reader
+	if req := c.fiberCtx.Request(); req.IsBodyStream() {
+		body = req.BodyStream()
+	} else {
+		body = bytes.NewReader(c.fiberCtx.Body())
+	}
+
+	dec := jcodec.NewDecoder(body)
+	reqCtx := c.Context()
+	err := fn(func(v any) error {
+		if err := reqCtx.Err(); err != nil {
+			return err
+		}
+		return dec.Decode(v)
+	})
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+// SaveUploadedFile copies the multipart file in field to dstPath inside the
+// configured UploadDir (the working directory by default) and returns the
+// number of bytes written. dstPath must be relative and cannot escape the
+// upload directory, including through symlinks; missing parent directories
+// are created. The part is read from the request stream as it is written, so
+// the file is never held in memory, and the copy fails once the body exceeds
+// MaxBodySize. An optional progress callback is invoked as data is written.
+func (c *ContextAdapter) SaveUploadedFile(field, dstPath string, progress ...core.UploadProgressFunc) (int64, error) {
+	if !filepath.IsLocal(dstPath) {
+		return 0, fmt.Errorf("%w: %q", core.ErrInvalidUploadPath, dstPath)
+	}
+
+	src, err := c.uploadPart(field)
+	if err != nil {
+		return 0, fmt.Errorf("read form file %q: %w", field, err)
+	}
+	defer func() { _ = src.Close() }()
+
+	dir := c.uploadDir
+	if dir == "" {
+		dir = "."
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return 0, fmt.Errorf("open upload directory: %w", err)
+	}
+	defer func() { _ = root.Close() }()
+
+	if parent := filepath.Dir(dstPath); parent != "." {
+		if err := root.MkdirAll(parent, 0o750); err != nil {
+			return 0, fmt.Errorf("create upload directory: %w", err)
+		}
+	}
+	dst, err := root.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("create upload file: %w", err)
+	}
+
+	var w io.Writer = dst
+	if len(progress) > 0 && progress[0] != nil {
+		w = &progressWriter{w: dst, total: -1, fn: progress[0]}
+	}
+	written, copyErr := io.Copy(w, src)
+	closeErr := dst.Close()
+	if err := errors.Join(copyErr, closeErr); err != nil {
+		_ = root.Remove(dstPath)
+		return written, fmt.Errorf("save upload file: %w", err)
+	}
+	return written, nil
+}
+
+// uploadPart returns the first file part named field, reading the multipart
+// body from the request stream when Fiber streams it. Reads past MaxBodySize
+// fail with fiber.ErrRequestEntityTooLarge.
+func (c *ContextAdapter) uploadPart(field string) (*multipart.Part, error) {
+	req := c.fiberCtx.Request()
+	boundary := string(req.Header.MultipartFormBoundary())
+	if boundary == "" {
+		return nil, fasthttp.ErrNoMultipartForm
+	}
+
+	var body io.Reader
+	if req.IsBodyStream() {
+		body = req.BodyStream()
+	} else {
+		body = bytes.NewReader(c.fiberCtx.Body())
+	}
+	limit := c.maxBodySize
+	if limit <= 0 {
+		limit = configuration.DefaultMaxBodySize
+	}
+
+	mr := multipart.NewReader(&limitedReader{r: body, n: int64(limit)}, boundary)
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, fasthttp.ErrMissingFile
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == field && part.FileName() != "" {
+			return part, nil
+		}
+		_ = part.Close()
+	}
+}
+
+// limitedReader reads at most n bytes from r, then fails with
+// fiber.ErrRequestEntityTooLarge instead of reporting a truncated body.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// A body of exactly n bytes still ends cleanly
+		var probe [1]byte
+		if n, err := l.r.Read(probe[:]); n == 0 {
+			return 0, err
+		}
+		return 0, fiber.ErrRequestEntityTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// progressWriter reports cumulative bytes written to fn.
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	fn      core.UploadProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.fn(p.written, p.total)
+	return n, err
+}
+
+// bodyContentType returns the lowercased media type without parameters
+// (e.g., "application/yaml; charset=utf-8" -> "application/yaml").
+func bodyContentType(contentType string) string {
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
 }
 
 // Cookies returns the cookie value by key
@@ -228,6 +478,12 @@ func (c *ContextAdapter) UseProperHTTPStatus() bool {
 	return c.useProperHTTPStatus
 }
 
+// CollapseValidationErrors returns whether validation error responses report
+// one detailed error per field
+func (c *ContextAdapter) CollapseValidationErrors() bool {
+	return c.collapseValidation
+}
+
 // ResponseStatusCode returns the HTTP status code of the response
 func (c *ContextAdapter) ResponseStatusCode() int {
 	return c.fiberCtx.Response().StatusCode()
@@ -265,12 +521,54 @@ func (c *ContextAdapter) SendFile(file string) error {
 	return c.fiberCtx.SendFile(file)
 }
 
+// Attachment sends data as a file download named filename.
+// An empty contentType defaults to application/octet-stream.
+func (c *ContextAdapter) Attachment(filename, contentType string, data []byte) error {
+	c.setAttachmentHeaders(filename, contentType)
+	return c.fiberCtx.Send(data)
+}
+
+// AttachmentStream streams a file download produced by fn.
+// fn runs after the handler returns, once headers are committed;
+// an error from fn stops the stream and truncates the body.
+func (c *ContextAdapter) AttachmentStream(filename, contentType string, fn func(w io.Writer) error) error {
+	c.setAttachmentHeaders(filename, contentType)
+	return c.fiberCtx.SendStreamWriter(func(w *bufio.Writer) {
+		// Headers are already committed; an error can only end the stream early.
+		_ = fn(w)
+	})
+}
+
+func (c *ContextAdapter) setAttachmentHeaders(filename, contentType string) {
+	if contentType == "" {
+		contentType = core.MIMEOctetStream
+	}
+	c.fiberCtx.Set(core.HeaderContentType, contentType)
+	c.fiberCtx.Set(core.HeaderContentDisposition, core.ContentDisposition(filename))
+}
+
 // Redirect redirects the client to the specified URL
 func (c *ContextAdapter) Redirect(location string, status ...int) error {
+	redirect := c.fiberCtx.Redirect()
 	if len(status) > 0 {
-		c.fiberCtx.Status(status[0])
+		redirect = redirect.Status(status[0])
+	}
+	return redirect.To(location)
+}
+
+// ResponseBody returns the buffered response body, or nil for a streamed body
+// (reading it would drain the stream). The slice is reused by Fiber after the
+// request completes.
+func (c *ContextAdapter) ResponseBody() []byte {
+	if c.fiberCtx.Response().IsBodyStream() {
+		return nil
 	}
-	return c.fiberCtx.Redirect().To(location)
+	return c.fiberCtx.Response().Body()
+}
+
+// ResponseHeaders returns the response headers set so far
+func (c *ContextAdapter) ResponseHeaders() map[string][]string {
+	return c.fiberCtx.GetRespHeaders()
 }
 
 // Accepts checks if the specified content types are acceptable by the client
@@ -429,6 +727,22 @@ func (c *ContextAdapter) Created(data any) error {
 	return err
 }
 
+// Accepted sends a 202 Accepted response with data, e.g., for queued work.
+func (c *ContextAdapter) Accepted(data any) error {
+	return core.SendStatus(c, core.StatusAccepted, data)
+}
+
+// PartialContent sends a 206 Partial Content response with data.
+func (c *ContextAdapter) PartialContent(data any) error {
+	return core.SendStatus(c, core.StatusPartialContent, data)
+}
+
+// JSONStatus sends data in the success envelope with the given status.
+// Statuses of 400 and above are sent as 200 unless UseProperHTTPStatus is enabled.
+func (c *ContextAdapter) JSONStatus(status int, data any) error {
+	return core.SendStatus(c, status, data)
+}
+
 // NoContent sends a 204 No Content response
 func (c *ContextAdapter) NoContent() error {
 	return c.Status(core.StatusNoContent).SendString("")
@@ -487,6 +801,8 @@ func getTypedContextKey(key string) any {
 		return ctxkeys.TenantID
 	case ctxkeys.CorrelationID.Key():
 		return ctxkeys.CorrelationID
+	case ctxkeys.RouteName.Key():
+		return ctxkeys.RouteName
 	default:
 		return key
 	}
@@ -3,11 +3,23 @@
 package fiber
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -145,6 +157,112 @@ func TestContextAdapter_QueryParams(t *testing.T) {
 	}
 }
 
+func TestContextAdapter_QueryParserBrackets(t *testing.T) {
+	type listRequest struct {
+		Filter map[string]string   `query:"filter"`
+		Sort   map[string][]string `query:"sort"`
+		IDs    []int               `query:"ids"`
+		Tags   []string            `query:"tags"`
+		Page   int                 `query:"page"`
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		check func(t *testing.T, got listRequest)
+	}{
+		{
+			name:  "bracketed map",
+			query: "filter[status]=active&filter[role]=admin&page=2",
+			check: func(t *testing.T, got listRequest) {
+				if got.Filter["status"] != "active" || got.Filter["role"] != "admin" || len(got.Filter) != 2 {
+					t.Errorf("Filter = %v, want status=active role=admin", got.Filter)
+				}
+				if got.Page != 2 {
+					t.Errorf("Page = %d, want 2", got.Page)
+				}
+			},
+		},
+		{
+			name:  "bracketed multi-value map",
+			query: "sort[asc]=name&sort[asc]=age&sort[desc]=created_at",
+			check: func(t *testing.T, got listRequest) {
+				if len(got.Sort["asc"]) != 2 || got.Sort["asc"][1] != "age" || got.Sort["desc"][0] != "created_at" {
+					t.Errorf("Sort = %v, want asc=[name age] desc=[created_at]", got.Sort)
+				}
+			},
+		},
+		{
+			name:  "bracketed array",
+			query: "ids[]=1&ids[]=2&ids[]=3",
+			check: func(t *testing.T, got listRequest) {
+				if len(got.IDs) != 3 || got.IDs[0] != 1 || got.IDs[2] != 3 {
+					t.Errorf("IDs = %v, want [1 2 3]", got.IDs)
+				}
+			},
+		},
+		{
+			name:  "repeated array",
+			query: "ids=4&ids=5",
+			check: func(t *testing.T, got listRequest) {
+				if len(got.IDs) != 2 || got.IDs[1] != 5 {
+					t.Errorf("IDs = %v, want [4 5]", got.IDs)
+				}
+			},
+		},
+		{
+			name:  "indexed array",
+			query: "tags[1]=b&tags[0]=a&tags[7]=c",
+			check: func(t *testing.T, got listRequest) {
+				if strings.Join(got.Tags, ",") != "a,b,c" {
+					t.Errorf("Tags = %v, want [a b c]", got.Tags)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/test", func(c fiber.Ctx) error {
+				ctx := AcquireContextAdapter(c, newTestConf())
+				defer ReleaseContextAdapter(ctx)
+
+				var got listRequest
+				if err := ctx.QueryParser(&got); err != nil {
+					t.Fatalf("QueryParser() error = %v", err)
+				}
+				tt.check(t, got)
+				return c.SendString("ok")
+			})
+
+			if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/test?"+tt.query, nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestContextAdapter_QueryParserIndexedInvalid(t *testing.T) {
+	app := fiber.New()
+	app.Get("/test", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, newTestConf())
+		defer ReleaseContextAdapter(ctx)
+
+		var got struct {
+			IDs []int `query:"ids"`
+		}
+		if err := ctx.QueryParser(&got); err == nil {
+			t.Errorf("QueryParser() error = nil, want error for non-numeric ids[0]")
+		}
+		return c.SendString("ok")
+	})
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/test?ids[0]=abc", nil)); err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+}
+
 func TestContextAdapter_SetContext(t *testing.T) {
 	app := fiber.New()
 	conf := newTestConf()
@@ -229,3 +347,342 @@ func TestWithContextAdapter_Reuse(t *testing.T) {
 		t.Errorf("adapter invocations = %d, want 2", adapterCount)
 	}
 }
+
+type bodyBindRequest struct {
+	Name  string `json:"name" yaml:"name" form:"name" validate:"required,min=3"`
+	Email string `json:"email" yaml:"email" form:"email" validate:"required,email"`
+	Age   int    `json:"age" yaml:"age" form:"age"`
+}
+
+func TestContextAdapter_BodyParser_ContentTypes(t *testing.T) {
+	want := bodyBindRequest{Name: "John Doe", Email: "john@example.com", Age: 30}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"json", "application/json", `{"name":"John Doe","email":"john@example.com","age":30}`},
+		{"json without content type", "", `{"name":"John Doe","email":"john@example.com","age":30}`},
+		{"yaml", "application/yaml", "name: John Doe\nemail: john@example.com\nage: 30\n"},
+		{"x-yaml with charset", "application/x-yaml; charset=utf-8", "name: John Doe\nemail: john@example.com\nage: 30\n"},
+		{"form", "application/x-www-form-urlencoded", "name=John+Doe&email=john%40example.com&age=30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			conf := newTestConf()
+
+			var got bodyBindRequest
+			app.Post("/test", func(c fiber.Ctx) error {
+				ctx := AcquireContextAdapter(c, conf)
+				defer ReleaseContextAdapter(ctx)
+
+				req, err := core.BindBody[bodyBindRequest](ctx, true)
+				if err != nil {
+					t.Errorf("BindBody() error = %v", err)
+					return c.SendStatus(http.StatusBadRequest)
+				}
+				got = req
+				return c.SendString("ok")
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			if resp.StatusCode != 200 {
+				t.Fatalf("StatusCode = %v, want 200", resp.StatusCode)
+			}
+			if got != want {
+				t.Errorf("bound = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestContextAdapter_BodyParser_YAMLValidation(t *testing.T) {
+	app := fiber.New()
+	conf := newTestConf()
+
+	app.Post("/test", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, conf)
+		defer ReleaseContextAdapter(ctx)
+
+		if _, err := core.BindBody[bodyBindRequest](ctx, true); err == nil {
+			t.Error("BindBody() expected validation error for invalid YAML payload")
+		}
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("name: Jo\nemail: invalid\n"))
+	req.Header.Set("Content-Type", "text/yaml")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+}
+
+func TestContextAdapter_Attachment(t *testing.T) {
+	app := fiber.New()
+	conf := newTestConf()
+
+	app.Get("/report", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, conf)
+		defer ReleaseContextAdapter(ctx)
+
+		return ctx.Attachment("report.csv", "text/csv", []byte("id,name\n1,alice\n"))
+	})
+	app.Get("/stream", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, conf)
+		defer ReleaseContextAdapter(ctx)
+
+		return ctx.AttachmentStream("résumé.txt", "", func(w io.Writer) error {
+			_, err := io.WriteString(w, "streamed body")
+			return err
+		})
+	})
+
+	tests := []struct {
+		name            string
+		path            string
+		wantType        string
+		wantDisposition string
+		wantBody        string
+	}{
+		{
+			name:            "buffered",
+			path:            "/report",
+			wantType:        "text/csv",
+			wantDisposition: `attachment; filename="report.csv"`,
+			wantBody:        "id,name\n1,alice\n",
+		},
+		{
+			name:            "streamed non-ascii",
+			path:            "/stream",
+			wantType:        core.MIMEOctetStream,
+			wantDisposition: `attachment; filename="r_sum_.txt"; filename*=UTF-8''r%C3%A9sum%C3%A9.txt`,
+			wantBody:        "streamed body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if got := resp.Header.Get(core.HeaderContentDisposition); got != tt.wantDisposition {
+				t.Errorf("Content-Disposition = %s, want %s", got, tt.wantDisposition)
+			}
+			if got := resp.Header.Get(core.HeaderContentType); got != tt.wantType {
+				t.Errorf("Content-Type = %s, want %s", got, tt.wantType)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestContextAdapter_NDJSON(t *testing.T) {
+	type record struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	app := fiber.New()
+	var got []record
+	app.Post("/ingest", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, newTestConf())
+		defer ReleaseContextAdapter(ctx)
+
+		return ctx.NDJSON(func(decode func(v any) error) error {
+			for {
+				var r record
+				if err := decode(&r); err != nil {
+					return err
+				}
+				got = append(got, r)
+			}
+		})
+	})
+
+	body := "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n{\"id\":3,\"name\":\"c\"}\n"
+	req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if len(got) != 3 {
+		t.Fatalf("processed %d records, want 3", len(got))
+	}
+	for i, r := range got {
+		if r.ID != i+1 {
+			t.Errorf("record %d ID = %d, want %d", i, r.ID, i+1)
+		}
+	}
+}
+
+func TestContextAdapter_NDJSON_Cancelled(t *testing.T) {
+	app := fiber.New()
+	app.Post("/ingest", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, newTestConf())
+		defer ReleaseContextAdapter(ctx)
+
+		cancelled, cancel := context.WithCancel(ctx.Context())
+		cancel()
+		ctx.SetContext(cancelled)
+
+		decoded := 0
+		err := ctx.NDJSON(func(decode func(v any) error) error {
+			for {
+				var v map[string]any
+				if err := decode(&v); err != nil {
+					return err
+				}
+				decoded++
+			}
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("NDJSON() error = %v, want context.Canceled", err)
+		}
+		if decoded != 0 {
+			t.Errorf("decoded %d records after cancellation, want 0", decoded)
+		}
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader("{\"id\":1}\n{\"id\":2}\n"))
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+}
+
+func TestContextAdapter_SaveUploadedFile(t *testing.T) {
+	uploadDir := t.TempDir()
+	conf := newTestConf()
+	conf.UploadDir = uploadDir
+
+	var progressCalls, lastWritten, lastTotal int64
+	var allocated uint64
+	var streamed bool
+	// The server streams request bodies and drains what handlers leave
+	// unread, see NewServerAdapter
+	app := fiber.New(fiber.Config{BodyLimit: 64 << 20, StreamRequestBody: true, DisablePreParseMultipartForm: true})
+	app.Use(bodyLimitHandler(64 << 20))
+	app.Post("/upload", func(c fiber.Ctx) error {
+		ctx := AcquireContextAdapter(c, conf)
+		defer ReleaseContextAdapter(ctx)
+
+		streamed = c.Request().IsBodyStream()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		n, err := ctx.SaveUploadedFile("file", ctx.Query("dst"), func(written, total int64) {
+			progressCalls++
+			lastWritten, lastTotal = written, total
+		})
+		runtime.ReadMemStats(&after)
+		allocated = after.TotalAlloc - before.TotalAlloc
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).SendString(err.Error())
+		}
+		return ctx.SendString(strconv.FormatInt(n, 10))
+	})
+
+	// 12MB of non-repeating data, over the 4MB default MaxBodySize
+	conf.MaxBodySize = 16 << 20
+	payload := make([]byte, 12<<20)
+	for i := range payload {
+		payload[i] = byte(i * 31 % 251)
+	}
+
+	upload := func(dst string) *http.Response {
+		t.Helper()
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, err := mw.CreateFormFile("file", "data.bin")
+		if err != nil {
+			t.Fatalf("CreateFormFile() error = %v", err)
+		}
+		_, _ = part.Write(payload)
+		_ = mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/upload?dst="+url.QueryEscape(dst), &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		resp, err := app.Test(req, fiber.TestConfig{Timeout: 0})
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		return resp
+	}
+
+	t.Run("writes file inside upload dir", func(t *testing.T) {
+		resp := upload("nested/data.bin")
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", resp.StatusCode, body)
+		}
+		if string(body) != strconv.Itoa(len(payload)) {
+			t.Errorf("bytes written = %s, want %d", body, len(payload))
+		}
+
+		saved, err := os.ReadFile(filepath.Join(uploadDir, "nested", "data.bin"))
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if !bytes.Equal(saved, payload) {
+			t.Error("saved file content does not match upload")
+		}
+		if progressCalls < 2 {
+			t.Errorf("progress called %d times, want several", progressCalls)
+		}
+		if lastWritten != int64(len(payload)) || lastTotal != -1 {
+			t.Errorf("last progress = %d/%d, want %d/-1", lastWritten, lastTotal, len(payload))
+		}
+		if !streamed || allocated > 1<<20 {
+			t.Errorf("streamed = %v, allocated %d bytes for a %d byte file, want the body streamed to disk",
+				streamed, allocated, len(payload))
+		}
+	})
+
+	t.Run("stops at MaxBodySize", func(t *testing.T) {
+		conf.MaxBodySize = 4 << 20
+		defer func() { conf.MaxBodySize = 16 << 20 }()
+		resp := upload("large.bin")
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), fiber.ErrRequestEntityTooLarge.Error()) {
+			t.Errorf("status = %d, body = %s, want 400 with %v", resp.StatusCode, body, fiber.ErrRequestEntityTooLarge)
+		}
+		if _, err := os.Stat(filepath.Join(uploadDir, "large.bin")); !os.IsNotExist(err) {
+			t.Errorf("Stat(large.bin) error = %v, want the partial file removed", err)
+		}
+	})
+
+	for _, dst := range []string{"../escape.bin", "/tmp/escape.bin", ""} {
+		t.Run("rejects "+dst, func(t *testing.T) {
+			resp := upload(dst)
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+			if !strings.Contains(string(body), core.ErrInvalidUploadPath.Error()) {
+				t.Errorf("body = %s, want ErrInvalidUploadPath", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"bytes"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/etag"
+)
+
+// etagHandler sets a strong ETag on 200 responses and answers a matching
+// If-None-Match with 304 Not Modified. It replaces Fiber's etag middleware,
+// whose skip hook runs before the handler, so it cannot tell a streamed
+// response (server-sent events, downloads) from a buffered one; reading the
+// body of an endless stream to hash it would block forever.
+func etagHandler(c fiber.Ctx) error {
+	if err := c.Next(); err != nil {
+		return err
+	}
+
+	resp := c.Response()
+	if resp.StatusCode() != fiber.StatusOK || resp.IsBodyStream() || len(resp.Header.Peek(fiber.HeaderETag)) > 0 {
+		return nil
+	}
+	body := resp.Body()
+	if len(body) == 0 {
+		return nil
+	}
+	tag := etag.Generate(body)
+	if tag == nil {
+		return nil
+	}
+
+	// A weak If-None-Match matches the strong tag (weak comparison)
+	clientTag := bytes.TrimPrefix(c.Request().Header.Peek(fiber.HeaderIfNoneMatch), []byte("W/"))
+	if bytes.Contains(clientTag, tag) {
+		c.RequestCtx().ResetBody()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	resp.Header.SetBytesV(fiber.HeaderETag, tag)
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/gofiber/fiber/v3"
+)
+
+// fallbackRoute is a handler for unmatched requests under a path prefix.
+type fallbackRoute struct {
+	prefix  string // normalized: no trailing slash, "" for the root
+	handler core.Handler
+}
+
+// matches reports whether path is the prefix itself or lies below it.
+func (f fallbackRoute) matches(path string) bool {
+	return f.prefix == "" || path == f.prefix || strings.HasPrefix(path, f.prefix+"/")
+}
+
+// RegisterFallback serves requests under prefix that match no route with
+// handler. The longest matching prefix wins, whatever the registration order.
+func (s *ServerAdapter) RegisterFallback(prefix string, handler core.Handler) {
+	if len(s.fallbacks) == 0 {
+		s.app.Use(s.dispatchFallback)
+	}
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		prefix = ""
+	}
+	s.fallbacks = append(s.fallbacks, fallbackRoute{prefix: prefix, handler: handler})
+	slices.SortStableFunc(s.fallbacks, func(a, b fallbackRoute) int {
+		return len(b.prefix) - len(a.prefix)
+	})
+}
+
+// dispatchFallback runs the rest of the chain and, when no route matched the
+// request, hands it to the fallback with the longest matching prefix.
+// A path that exists for another method (ErrMethodNotAllowed) is left alone.
+func (s *ServerAdapter) dispatchFallback(c fiber.Ctx) error {
+	err := c.Next()
+	if !errors.Is(err, fiber.ErrNotFound) {
+		return err
+	}
+	if s.config.RedirectTrailingSlash && trailingSlashTarget(c) != "" {
+		// Leave it to redirectTrailingSlashHandler
+		return err
+	}
+	path := c.Path()
+	for _, f := range s.fallbacks {
+		if f.matches(path) {
+			return withContextAdapter(c, s.config, func(ctx *ContextAdapter) error {
+				return f.handler(ctx.handlerContext())
+			})
+		}
+	}
+	return err
+}
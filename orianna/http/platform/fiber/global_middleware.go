@@ -4,19 +4,17 @@ package fiber
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/anthanhphan/gosdk/logger"
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
 	"github.com/anthanhphan/gosdk/orianna/http/core"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cache"
-	"github.com/gofiber/fiber/v3/middleware/compress"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"github.com/gofiber/fiber/v3/middleware/csrf"
-	"github.com/gofiber/fiber/v3/middleware/etag"
 	"github.com/gofiber/fiber/v3/middleware/helmet"
 	"github.com/gofiber/fiber/v3/middleware/limiter"
-	"github.com/gofiber/fiber/v3/middleware/recover"
 )
 
 // SetupGlobalMiddlewares sets up global middlewares on the server
@@ -64,20 +62,19 @@ func (s *ServerAdapter) setupTrafficMiddlewares(middlewareConfig *configuration.
 		}
 	}
 
-	// Add compression middleware
-	if middlewareConfig == nil || !middlewareConfig.DisableCompression {
+	// Add compression middleware; registered before ETag so tags hash the
+	// uncompressed body
+	if s.config.Compression != nil || middlewareConfig == nil || !middlewareConfig.DisableCompression {
 		level := configuration.DefaultCompressionLevel
 		if s.config.CompressionLevel != nil {
 			level = *s.config.CompressionLevel
 		}
-		s.app.Use(compress.New(compress.Config{
-			Level: compress.Level(level),
-		}))
+		s.app.Use(compressionHandler(level, s.config.Compression))
 	}
 
 	// Add ETag middleware
 	if middlewareConfig == nil || !middlewareConfig.DisableETag {
-		s.app.Use(etag.New())
+		s.app.Use(etagHandler)
 	}
 
 	// Add Cache middleware
@@ -89,6 +86,10 @@ func (s *ServerAdapter) setupTrafficMiddlewares(middlewareConfig *configuration.
 		s.app.Use(cache.New(cache.Config{
 			Expiration:  expiration,
 			CacheHeader: "X-Cache",
+			// Caching reads the whole body, which would block on an endless stream
+			Next: func(c fiber.Ctx) bool {
+				return c.Response().IsBodyStream()
+			},
 		}))
 	}
 
@@ -108,6 +109,34 @@ func (s *ServerAdapter) setupTrafficMiddlewares(middlewareConfig *configuration.
 	}
 }
 
+// defaultRecover is the panic recovery used when no custom one is configured.
+// A panic with an error that is or wraps a *core.ErrorResponse is answered with
+// that error; any other panic is returned to errorHandler as a 500, like
+// Fiber's recover middleware does.
+func defaultRecover(conf *configuration.Config) fiber.Handler {
+	return func(c fiber.Ctx) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			errResp, ok := core.PanicErrorResponse(r)
+			if !ok {
+				if panicErr, isErr := r.(error); isErr {
+					err = panicErr
+				} else {
+					err = fmt.Errorf("%v", r)
+				}
+				return
+			}
+			err = withContextAdapter(c, conf, func(ctx *ContextAdapter) error {
+				return core.SendError(ctx, errResp)
+			})
+		}()
+		return c.Next()
+	}
+}
+
 func (s *ServerAdapter) setupObservabilityMiddlewares(
 	middlewareConfig *configuration.MiddlewareConfig,
 	panicRecover core.Middleware,
@@ -118,7 +147,7 @@ func (s *ServerAdapter) setupObservabilityMiddlewares(
 		if panicRecover != nil {
 			s.app.Use(convertToFiberMiddlewareWithConfig(panicRecover, s.config))
 		} else {
-			s.app.Use(recover.New())
+			s.app.Use(defaultRecover(s.config))
 		}
 	}
 
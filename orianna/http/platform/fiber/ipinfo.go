@@ -0,0 +1,67 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// IPInfo returns the socket peer, the X-Forwarded-For chain and the client IP.
+// The chain is walked from the right while the hop that added an entry is a
+// trusted proxy: the client IP is the first entry added by a trusted hop that
+// is not itself trusted, or the leftmost entry when every hop is trusted. An
+// entry that is not an IP stops the walk at the hop that added it.
+func (c *ContextAdapter) IPInfo() core.IPInfo {
+	peer := c.fiberCtx.RequestCtx().RemoteIP().String()
+	info := core.IPInfo{PeerIP: peer, PeerAddr: c.fiberCtx.RequestCtx().RemoteAddr().String(), ClientIP: peer}
+
+	for _, header := range c.fiberCtx.Request().Header.PeekAll(core.HeaderXForwardedFor) {
+		for entry := range strings.SplitSeq(string(header), ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				info.ForwardedFor = append(info.ForwardedFor, entry)
+			}
+		}
+	}
+	if len(info.ForwardedFor) == 0 || len(c.trustedProxies) == 0 {
+		return info
+	}
+
+	isTrusted := func(ip string) bool {
+		addr, ok := parseForwardedIP(ip)
+		if !ok {
+			return false
+		}
+		for _, prefix := range c.trustedProxies {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	hop := peer
+	for i := len(info.ForwardedFor) - 1; i >= 0 && isTrusted(hop); i-- {
+		addr, ok := parseForwardedIP(info.ForwardedFor[i])
+		if !ok {
+			break
+		}
+		hop = addr.String()
+	}
+	info.ClientIP = hop
+	return info
+}
+
+// parseForwardedIP parses an X-Forwarded-For entry, an IP with an optional
+// port ("203.0.113.7", "203.0.113.7:4711", "[2001:db8::1]:4711").
+func parseForwardedIP(s string) (netip.Addr, bool) {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return addr.Unmap(), true
+	}
+	if addrPort, err := netip.ParseAddrPort(s); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	return netip.Addr{}, false
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/gofiber/fiber/v3"
+)
+
+// methodOverrideTargets are the methods a POST may be remapped to.
+// GET and HEAD are excluded so a state-changing POST can never be turned into
+// a cacheable read, and CONNECT/TRACE/OPTIONS are never useful targets.
+var methodOverrideTargets = map[string]string{
+	"PUT":    fiber.MethodPut,
+	"PATCH":  fiber.MethodPatch,
+	"DELETE": fiber.MethodDelete,
+}
+
+// methodOverrideHandler remaps a POST carrying X-HTTP-Method-Override to the
+// requested method and restarts routing so the request reaches the handler
+// registered for that method. It must be the first handler on the app: routes
+// are looked up per method, so the remap has to happen before any other
+// middleware has run.
+func methodOverrideHandler(c fiber.Ctx) error {
+	if c.Method() != fiber.MethodPost {
+		return c.Next()
+	}
+	override := c.Get(core.HeaderXHTTPMethodOverride)
+	if override == "" {
+		return c.Next()
+	}
+	method, ok := methodOverrideTargets[strings.ToUpper(strings.TrimSpace(override))]
+	if !ok {
+		return c.Next()
+	}
+	c.Method(method)
+	return c.RestartRouting()
+}
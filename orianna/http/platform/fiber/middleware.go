@@ -102,7 +102,8 @@ func releaseLogFields(fields []any) {
 
 // requestResponseLoggingMiddleware creates middleware that logs request and response information.
 // Supports prefix-based skip paths (e.g., "/health" skips /health, /health/ready, etc.).
-// Uses Warnw for error responses (>= 400) and Infow for success responses.
+// Uses Warnw for error responses (>= 400), Debugw for client disconnects and
+// Infow for success responses.
 func requestResponseLoggingMiddleware(log *logger.Logger, verbose bool, skipPaths []string) fiber.Handler {
 	// Separate exact paths and prefixes for efficient matching
 	exactSkip := make(map[string]struct{})
@@ -152,9 +153,12 @@ func requestResponseLoggingMiddleware(log *logger.Logger, verbose bool, skipPath
 		duration := time.Since(start)
 		respFields := buildResponseLogFields(acquireLogFields(), c, verbose, duration, requestID, traceID, err)
 
-		// Log severity by status code: Warnw for >= 400, Infow for < 400
+		// Log severity by status code: Warnw for >= 400, Infow for < 400.
+		// A client that went away is not a server problem, so it is logged at debug.
 		statusCode := c.Response().StatusCode()
-		if err != nil || statusCode >= 400 {
+		if core.IsClientDisconnect(err) {
+			log.Debugw("client disconnected", respFields...)
+		} else if err != nil || statusCode >= 400 {
 			log.Warnw("request completed", respFields...)
 		} else {
 			log.Infow("request completed", respFields...)
@@ -270,10 +274,17 @@ func buildResponseLogFields(fields []any, c fiber.Ctx, verbose bool, duration ti
 		"http_code", statusCode,
 		"duration_ms", duration.Milliseconds(),
 	)
+	if routeName, ok := c.Locals(ctxkeys.RouteName.Key()).(string); ok && routeName != "" {
+		fields = append(fields, "route_name", routeName)
+	}
 
 	if verbose {
-		// Check raw byte length first to avoid string conversion on empty body
-		rawBody := c.Response().Body()
+		// Check raw byte length first to avoid string conversion on empty body.
+		// Streamed bodies (downloads, SSE) are skipped: reading them drains the stream.
+		var rawBody []byte
+		if !c.Response().IsBodyStream() {
+			rawBody = c.Response().Body()
+		}
 		var responseBody string
 		if len(rawBody) > 0 {
 			responseBody = string(rawBody)
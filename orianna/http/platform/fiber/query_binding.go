@@ -0,0 +1,202 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// bindBracketQuery fills the fields Fiber's query binder leaves empty:
+//   - map[string]string and map[string][]string fields from filter[key]=value
+//   - slice fields from indexed keys such as tags[0]=a&tags[1]=b
+//
+// Fields are matched by their `query` tag (or field name), case-insensitively,
+// like Fiber does. Repeated keys, ids[]=1 and comma-separated values are
+// already bound by Fiber and are left untouched.
+func bindBracketQuery(out any, args iter.Seq2[[]byte, []byte]) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return nil
+	}
+	target = target.Elem()
+	if target.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := queryFields(target)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	indexed := make(map[string]map[int]string)
+	for rawKey, rawValue := range args {
+		name, sub, ok := splitBracketKey(string(rawKey))
+		if !ok || sub == "" {
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			continue
+		}
+		value := string(rawValue)
+
+		switch field.Kind() {
+		case reflect.Map:
+			setMapEntry(field, sub, value)
+		case reflect.Slice:
+			i, err := strconv.Atoi(sub)
+			if err != nil || i < 0 {
+				continue
+			}
+			if indexed[name] == nil {
+				indexed[name] = make(map[int]string)
+			}
+			indexed[name][i] = value
+		}
+	}
+
+	for name, values := range indexed {
+		if err := setIndexedSlice(fields[name], values); err != nil {
+			return fmt.Errorf("query %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// queryFields returns the settable map and slice fields of v keyed by their
+// lower-cased query name.
+func queryFields(v reflect.Value) map[string]reflect.Value {
+	var fields map[string]reflect.Value
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		kind := sf.Type.Kind()
+		if kind == reflect.Map && !isBracketMap(sf.Type) {
+			continue
+		}
+		if kind != reflect.Map && kind != reflect.Slice {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("query"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if fields == nil {
+			fields = make(map[string]reflect.Value)
+		}
+		fields[strings.ToLower(name)] = v.Field(i)
+	}
+	return fields
+}
+
+// isBracketMap reports whether t is map[string]string or map[string][]string.
+func isBracketMap(t reflect.Type) bool {
+	if t.Key().Kind() != reflect.String {
+		return false
+	}
+	elem := t.Elem()
+	return elem.Kind() == reflect.String ||
+		(elem.Kind() == reflect.Slice && elem.Elem().Kind() == reflect.String)
+}
+
+// splitBracketKey splits "filter[status]" into ("filter", "status").
+// Only a single bracket pair at the end of the key is recognized.
+func splitBracketKey(key string) (name, sub string, ok bool) {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return "", "", false
+	}
+	sub = key[open+1 : len(key)-1]
+	if strings.ContainsAny(sub, "[]") {
+		return "", "", false
+	}
+	return strings.ToLower(key[:open]), sub, true
+}
+
+// setMapEntry stores value under key in a map[string]string or
+// map[string][]string field, allocating the map on first use.
+func setMapEntry(field reflect.Value, key, value string) {
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(field.Type()))
+	}
+	k := reflect.ValueOf(key).Convert(field.Type().Key())
+	elemType := field.Type().Elem()
+	if elemType.Kind() == reflect.String {
+		field.SetMapIndex(k, reflect.ValueOf(value).Convert(elemType))
+		return
+	}
+
+	values := field.MapIndex(k)
+	if !values.IsValid() {
+		values = reflect.MakeSlice(elemType, 0, 1)
+	}
+	elem := reflect.New(elemType.Elem()).Elem()
+	elem.SetString(value)
+	field.SetMapIndex(k, reflect.Append(values, elem))
+}
+
+// setIndexedSlice replaces a slice field with the indexed values in index
+// order. Gaps are dropped, so tags[0]=a&tags[5]=b yields [a b].
+func setIndexedSlice(field reflect.Value, values map[int]string) error {
+	indexes := make([]int, 0, len(values))
+	for i := range values {
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+
+	slice := reflect.MakeSlice(field.Type(), len(indexes), len(indexes))
+	for n, i := range indexes {
+		if err := setScalar(slice.Index(n), values[i]); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+// setScalar parses s into v, which must be a string, bool, integer or float.
+func setScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported element type %s", v.Type())
+	}
+	return nil
+}
@@ -4,9 +4,11 @@ package fiber
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/anthanhphan/gosdk/orianna/http/core"
 	"github.com/anthanhphan/gosdk/orianna/http/engine"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
 	"github.com/anthanhphan/gosdk/orianna/http/routing"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/adaptor"
@@ -27,7 +29,7 @@ func (s *ServerAdapter) RegisterRoutes(routes ...routing.Route) error {
 
 // RegisterGroup registers a route group
 func (s *ServerAdapter) RegisterGroup(group routing.RouteGroup) error {
-	return s.registerGroupToRouter(s.router, group)
+	return s.registerGroupToRouter(s.router, s.shadowApp, group)
 }
 
 // RegisterMetricsHandler registers a /metrics endpoint for Prometheus scraping
@@ -60,21 +62,23 @@ func (s *ServerAdapter) RegisterStaticFiles(conf *configuration.StaticFileConfig
 	}))
 }
 
-// registerGroupToRouter registers a group to a specific router
-func (s *ServerAdapter) registerGroupToRouter(router engine.RouterEngine, group routing.RouteGroup) error {
+// registerGroupToRouter registers a group to a specific router. shadowRouter
+// mirrors the group on the shadow app so shadow routes get the same paths.
+func (s *ServerAdapter) registerGroupToRouter(router engine.RouterEngine, shadowRouter fiber.Router, group routing.RouteGroup) error {
 	// Create group router
-	groupRouter := router.Group(group.Prefix, group.Middlewares...)
+	groupRouter := router.Group(group.Prefix, withDefaultHeaders(group.Headers, group.Middlewares)...)
+	shadowGroup := shadowRouter.Group(group.Prefix)
 
 	// Register all routes in the group
 	for _, route := range group.Routes {
-		if err := s.registerRouteToRouter(groupRouter, route); err != nil {
+		if err := s.registerRouteToRouter(groupRouter, shadowGroup, route); err != nil {
 			return err
 		}
 	}
 
 	// Register nested groups
 	for _, subGroup := range group.Groups {
-		if err := s.registerGroupToRouter(groupRouter, subGroup); err != nil {
+		if err := s.registerGroupToRouter(groupRouter, shadowGroup, subGroup); err != nil {
 			return err
 		}
 	}
@@ -84,11 +88,11 @@ func (s *ServerAdapter) registerGroupToRouter(router engine.RouterEngine, group
 
 // registerRoute registers a single route
 func (s *ServerAdapter) registerRoute(route routing.Route) error {
-	return s.registerRouteToRouter(s.router, route)
+	return s.registerRouteToRouter(s.router, s.shadowApp, route)
 }
 
 // registerRouteToRouter registers a route to a specific router
-func (s *ServerAdapter) registerRouteToRouter(router engine.RouterEngine, route routing.Route) error {
+func (s *ServerAdapter) registerRouteToRouter(router engine.RouterEngine, shadowRouter fiber.Router, route routing.Route) error {
 	path := route.Path
 	if path == "" {
 		path = "/"
@@ -97,7 +101,12 @@ func (s *ServerAdapter) registerRouteToRouter(router engine.RouterEngine, route
 	// Build handler chain: route.Middlewares already includes protection middleware
 	// (auth/authz) applied by the RouteRegistry. buildHandlerChain chains these
 	// route-level middlewares with the final handler into an ordered handler slice.
-	handlers := s.buildHandlerChain(route.Middlewares, route.Handler)
+	middlewares := withRouteName(route.Name, withDefaultHeaders(route.Headers, route.Middlewares))
+	if route.Shadow != nil {
+		// Last, so the shadow only sees requests that reach the handler
+		middlewares = append(slices.Clip(middlewares), s.shadowMiddleware(route.Shadow))
+	}
+	handlers := s.buildHandlerChain(middlewares, route.Handler)
 
 	// Helper to register for a single method
 	register := func(method core.Method) error {
@@ -126,6 +135,9 @@ func (s *ServerAdapter) registerRouteToRouter(router engine.RouterEngine, route
 		if err := register(method); err != nil {
 			return err
 		}
+		if route.Shadow != nil {
+			shadowRouter.Add([]string{method.String()}, path, s.shadowHandler(route.Shadow.Handler))
+		}
 	}
 	return nil
 }
@@ -153,7 +165,7 @@ func convertToFiberMiddlewareWithConfig(middleware core.Middleware, conf *config
 	}
 	return func(c fiber.Ctx) error {
 		return withContextAdapter(c, conf, func(ctx *ContextAdapter) error {
-			return middleware(ctx)
+			return middleware(ctx.handlerContext())
 		})
 	}
 }
@@ -173,3 +185,21 @@ func withContextAdapter(c fiber.Ctx, conf *configuration.Config, fn func(*Contex
 	}()
 	return fn(ctx)
 }
+
+// withRouteName prepends a middleware storing the route name in Locals, ahead
+// of every route middleware so it is set even when authentication rejects.
+func withRouteName(name string, middlewares []core.Middleware) []core.Middleware {
+	if name == "" {
+		return middlewares
+	}
+	return append([]core.Middleware{middleware.RouteName(name)}, middlewares...)
+}
+
+// withDefaultHeaders prepends a middleware setting the given default response
+// headers. Group headers run before route headers, so the more specific value wins.
+func withDefaultHeaders(headers map[string]string, middlewares []core.Middleware) []core.Middleware {
+	if len(headers) == 0 {
+		return middlewares
+	}
+	return append([]core.Middleware{middleware.DefaultHeaders(headers)}, middlewares...)
+}
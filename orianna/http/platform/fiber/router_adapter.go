@@ -105,7 +105,7 @@ func (r *routerAdapter) convertHandler(handler core.Handler) fiber.Handler {
 	conf := r.config
 	return func(c fiber.Ctx) error {
 		return withContextAdapter(c, conf, func(ctx *ContextAdapter) error {
-			return handler(ctx)
+			return handler(ctx.handlerContext())
 		})
 	}
 }
@@ -121,7 +121,7 @@ func (r *routerAdapter) convertMiddleware(middleware core.Middleware) fiber.Hand
 	conf := r.config
 	return func(c fiber.Ctx) error {
 		return withContextAdapter(c, conf, func(ctx *ContextAdapter) error {
-			return middleware(ctx)
+			return middleware(ctx.handlerContext())
 		})
 	}
 }
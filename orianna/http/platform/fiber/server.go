@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/anthanhphan/gosdk/jcodec"
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
@@ -23,9 +25,13 @@ const ctxAdapterKey = "__orianna_ctx"
 
 // ServerAdapter implements ServerEngine using Fiber v3
 type ServerAdapter struct {
-	app    *fiber.App
-	config *configuration.Config
-	router engine.RouterEngine
+	app       *fiber.App
+	shadowApp *fiber.App // serves shadow handlers (see routing.ShadowConfig)
+	config    *configuration.Config
+	router    engine.RouterEngine
+
+	fallbacks []fallbackRoute
+	aliases   []aliasRoute
 }
 
 // NewServerAdapter creates a new Fiber server adapter
@@ -55,7 +61,7 @@ func NewServerAdapter(conf *configuration.Config) (*ServerAdapter, error) {
 		concurrency = configuration.DefaultMaxConcurrentConnections
 	}
 
-	app := fiber.New(fiber.Config{
+	fiberConfig := fiber.Config{
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
 		IdleTimeout:  idleTimeout,
@@ -63,21 +69,45 @@ func NewServerAdapter(conf *configuration.Config) (*ServerAdapter, error) {
 		Concurrency:  concurrency,
 		JSONEncoder:  jcodec.Marshal,
 		JSONDecoder:  jcodec.Unmarshal,
-		ErrorHandler: func(c fiber.Ctx, err error) error {
-			errResp := core.NewErrorResponse("INTERNAL_ERROR", core.StatusInternalServerError, err.Error())
-			return c.Status(core.StatusInternalServerError).JSON(errResp)
-		},
-	})
+		ErrorHandler: errorHandler,
+		// A trailing slash is ignored unless routing is strict
+		StrictRouting: conf.StrictSlash || conf.RedirectTrailingSlash,
+		// Bodies are streamed so uploads reach disk without being buffered;
+		// bodyLimitHandler enforces BodyLimit on the stream
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+	}
+	app := fiber.New(fiberConfig)
 
 	adapter := &ServerAdapter{
-		app:    app,
-		config: conf,
+		app:       app,
+		shadowApp: fiber.New(fiberConfig),
+		config:    conf,
+	}
+
+	app.Use(bodyLimitHandler(bodyLimit))
+	if conf.RedirectTrailingSlash {
+		app.Use(redirectTrailingSlashHandler)
+	}
+	app.Use(adapter.aliasHandler)
+	if conf.MethodOverride {
+		app.Use(methodOverrideHandler)
 	}
 
 	adapter.router = newRouterAdapterWithConfig(app, conf)
 	return adapter, nil
 }
 
+// errorHandler turns errors returned by the handler chain into a 500 response.
+// Client disconnect errors get no response: the connection is already gone.
+func errorHandler(c fiber.Ctx, err error) error {
+	if core.IsClientDisconnect(err) {
+		return nil
+	}
+	errResp := core.NewErrorResponse("INTERNAL_ERROR", core.StatusInternalServerError, err.Error())
+	return c.Status(core.StatusInternalServerError).JSON(errResp)
+}
+
 // Start starts the HTTP server on the configured port
 func (s *ServerAdapter) Start() error {
 	addr := fmt.Sprintf(":%d", s.config.Port)
@@ -93,6 +123,11 @@ func (s *ServerAdapter) Shutdown(ctx context.Context) error {
 	return s.app.ShutdownWithContext(ctx)
 }
 
+// Test dispatches req through the Fiber app in-memory, without a listener
+func (s *ServerAdapter) Test(req *http.Request, timeout time.Duration) (*http.Response, error) {
+	return s.app.Test(req, fiber.TestConfig{Timeout: timeout, FailOnTimeout: true})
+}
+
 // Use adds domain-level middleware to the server
 func (s *ServerAdapter) Use(middleware ...core.Middleware) {
 	for _, mw := range middleware {
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+
+	"github.com/anthanhphan/gosdk/logger"
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+	"github.com/gofiber/fiber/v3"
+	"github.com/valyala/fasthttp"
+)
+
+// shadowLog reports shadow handlers that fail or disagree with the real one.
+var shadowLog = logger.NewLoggerWithFields(logger.String("package", "shadow"))
+
+// shadowMiddleware copies a sample of requests before the route handler runs
+// and, once it has, replays the copy through the shadow app in the background.
+// A sample is dropped when MaxInFlight shadows of the route are still running.
+func (s *ServerAdapter) shadowMiddleware(shadow *routing.ShadowConfig) core.Middleware {
+	maxInFlight := shadow.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = configuration.DefaultShadowMaxInFlight
+	}
+	slots := make(chan struct{}, maxInFlight)
+
+	return func(ctx core.Context) error {
+		// ctx may be a ContextFactory wrapper; the adapter is in Locals
+		adapter, ok := ctx.Locals(ctxAdapterKey).(*ContextAdapter)
+		if !ok || rand.Float64() >= shadow.Fraction {
+			return ctx.Next()
+		}
+		select {
+		case slots <- struct{}{}:
+		default:
+			return ctx.Next()
+		}
+
+		// Copy before the handler runs: it may consume the body, and the live
+		// request is recycled once the response is sent
+		clone := cloneRequestCtx(adapter.fiberCtx.RequestCtx())
+		err := ctx.Next()
+
+		status := ctx.ResponseStatusCode()
+		if err != nil {
+			status = core.StatusInternalServerError
+		}
+		method, route := ctx.Method(), ctx.RoutePath()
+		go func() {
+			defer func() { <-slots }()
+			s.runShadow(clone, method, route, status)
+		}()
+		return err
+	}
+}
+
+// runShadow dispatches clone to the shadow app and logs a status that differs
+// from the real response's.
+func (s *ServerAdapter) runShadow(clone *fasthttp.RequestCtx, method, route string, status int) {
+	defer func() {
+		if r := recover(); r != nil {
+			shadowLog.Warnw("shadow request panicked", "method", method, "route", route, "panic", fmt.Sprint(r))
+		}
+	}()
+
+	s.shadowApp.Handler()(clone)
+	if shadowStatus := clone.Response.StatusCode(); shadowStatus != status {
+		shadowLog.Warnw("shadow response status differs",
+			"method", method,
+			"route", route,
+			"status", status,
+			"shadow_status", shadowStatus,
+		)
+	}
+}
+
+// shadowHandler adapts a shadow handler for the shadow app, turning a panic
+// into an error so it cannot escape the background goroutine.
+func (s *ServerAdapter) shadowHandler(handler core.Handler) fiber.Handler {
+	return func(c fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("shadow handler panicked: %v", r)
+			}
+			if err != nil {
+				shadowLog.Warnw("shadow handler failed", "method", c.Method(), "route", c.Route().Path, "error", err.Error())
+			}
+		}()
+		return withContextAdapter(c, s.config, func(ctx *ContextAdapter) error {
+			return handler(ctx.handlerContext())
+		})
+	}
+}
+
+// cloneRequestCtx copies the request, remote address and Locals of src into a
+// standalone RequestCtx that outlives src. Locals values are copied by
+// reference; values implementing io.Closer are left out, since they are
+// closed when src is released.
+func cloneRequestCtx(src *fasthttp.RequestCtx) *fasthttp.RequestCtx {
+	clone := &fasthttp.RequestCtx{}
+	clone.Init(&src.Request, src.RemoteAddr(), nil)
+	src.VisitUserValuesAll(func(key, value any) {
+		// The live ContextAdapter must not be reused by the shadow request
+		if key == ctxAdapterKey {
+			return
+		}
+		if _, ok := value.(io.Closer); ok {
+			return
+		}
+		clone.SetUserValue(key, value)
+	})
+	return clone
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package fiber
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+	"github.com/gofiber/fiber/v3"
+)
+
+// redirectTrailingSlashHandler runs the rest of the chain and, when no route
+// matched the request but one matches its path with the trailing slash added
+// or removed, redirects there with 308 so the method and body are kept. It is
+// registered first so it sees the 404 of the whole chain; dispatchFallback
+// leaves such requests alone. Routing must be strict for both forms to reach
+// this point.
+func redirectTrailingSlashHandler(c fiber.Ctx) error {
+	err := c.Next()
+	if !errors.Is(err, fiber.ErrNotFound) {
+		return err
+	}
+	target := trailingSlashTarget(c)
+	if target == "" {
+		return err
+	}
+	if query := c.Request().URI().QueryString(); len(query) > 0 {
+		target += "?" + string(query)
+	}
+	return c.Redirect().Status(core.StatusPermanentRedirect).To(target)
+}
+
+// trailingSlashTarget returns the request path with its trailing slash toggled
+// when a route for the request's method matches it, or "". Paths starting with
+// "//" or "/\" are never redirected: browsers read such a Location as a
+// scheme-relative URL to another host.
+func trailingSlashTarget(c fiber.Ctx) string {
+	path := c.Path()
+	if len(path) <= 1 || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return ""
+	}
+	target := path + "/"
+	if strings.HasSuffix(path, "/") {
+		target = strings.TrimRight(path, "/")
+		if target == "" {
+			return ""
+		}
+	}
+	wantSlash := strings.HasSuffix(target, "/")
+	for _, route := range c.App().GetRoutes(true) {
+		if route.Method != c.Method() || strings.HasSuffix(route.Path, "/") != wantSlash {
+			continue
+		}
+		if _, ok := routing.MatchPath(route.Path, target); ok {
+			return target
+		}
+	}
+	return ""
+}
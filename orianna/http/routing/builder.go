@@ -3,8 +3,12 @@
 package routing
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
 	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
 )
 
 // Route Builder
@@ -101,6 +105,70 @@ func (rb *RouteBuilder) CORS(corsConfig *configuration.CORSConfig) *RouteBuilder
 	return rb
 }
 
+// Headers sets default response headers for the route. They are set before
+// the handler runs, so the handler can still override them.
+func (rb *RouteBuilder) Headers(headers map[string]string) *RouteBuilder {
+	rb.route.Headers = mergeHeaders(rb.route.Headers, headers)
+	return rb
+}
+
+// Constrain restricts route parameter param to the given values. Other values
+// get a 404 NOT_FOUND before the handler runs.
+//
+//	routing.NewRoute("/users/:status").GET().Handler(listUsers).
+//	    Constrain("status", "active", "inactive", "suspended")
+func (rb *RouteBuilder) Constrain(param string, values ...string) *RouteBuilder {
+	return rb.Middleware(ConstrainParam(param, values...))
+}
+
+// ConstrainPattern restricts route parameter param to values matching pattern.
+// Other values get a 404 NOT_FOUND before the handler runs.
+//
+//	routing.NewRoute("/orders/:id").GET().Handler(getOrder).
+//	    ConstrainPattern("id", regexp.MustCompile(`^[0-9]+$`))
+func (rb *RouteBuilder) ConstrainPattern(param string, pattern *regexp.Regexp) *RouteBuilder {
+	return rb.Middleware(ConstrainParamPattern(param, pattern))
+}
+
+// Name sets a human-friendly route name, e.g., "ListUsers". It is stored in
+// Locals (see middleware.RouteNameFrom), logged as route_name by the access
+// log and used as the route metric label with middleware.WithRouteNameLabel.
+// Names should be unique across the server so series and logs stay distinct.
+func (rb *RouteBuilder) Name(name string) *RouteBuilder {
+	rb.route.Name = name
+	return rb
+}
+
+// SlowRequestThreshold overrides the server's slow request threshold for this
+// route (see server.WithSlowRequestThreshold).
+func (rb *RouteBuilder) SlowRequestThreshold(d time.Duration) *RouteBuilder {
+	return rb.Middleware(middleware.SlowRequestThreshold(d))
+}
+
+// MaxConcurrent limits simultaneous executions of the route's handler to n,
+// queuing up to n more requests and rejecting the rest with 503 and
+// Retry-After (see middleware.MaxConcurrent for tuning the queue).
+func (rb *RouteBuilder) MaxConcurrent(n int) *RouteBuilder {
+	return rb.Middleware(middleware.MaxConcurrent(n))
+}
+
+// Shadow runs handler on a copy of a fraction (0 to 1) of the route's
+// requests, asynchronously and after the route's own handler. The client
+// always gets the real response; the shadow's response is discarded, and a
+// status that differs from the real one, an error or a panic is logged as a
+// warning. The shadow sees the request's Locals (e.g., the authenticated
+// user) but none of the route's middlewares run again. Locals values are
+// shared with the real request, so the shadow must only read them. Samples
+// are dropped while configuration.DefaultShadowMaxInFlight shadows of the
+// route are running (see ShadowConfig.MaxInFlight).
+//
+//	routing.NewRoute("/orders/:id").GET().Handler(getOrder).
+//	    Shadow(0.05, getOrderV2)
+func (rb *RouteBuilder) Shadow(fraction float64, handler core.Handler) *RouteBuilder {
+	rb.route.Shadow = &ShadowConfig{Fraction: fraction, Handler: handler}
+	return rb
+}
+
 // Build returns the constructed route
 func (rb *RouteBuilder) Build() *Route {
 	return rb.route
@@ -137,6 +205,13 @@ func (grb *GroupRouteBuilder) Protected() *GroupRouteBuilder {
 	return grb
 }
 
+// Headers sets default response headers for every route in the group,
+// including nested groups. Route and handler headers take precedence.
+func (grb *GroupRouteBuilder) Headers(headers map[string]string) *GroupRouteBuilder {
+	grb.group.Headers = mergeHeaders(grb.group.Headers, headers)
+	return grb
+}
+
 // Route adds a single route to the group
 func (grb *GroupRouteBuilder) Route(route *Route) *GroupRouteBuilder {
 	if route != nil {
@@ -220,3 +295,17 @@ func (grb *GroupRouteBuilder) addRoute(method core.Method, path string, handler
 	grb.group.Routes = append(grb.group.Routes, route)
 	return grb
 }
+
+// mergeHeaders copies src into dst, allocating dst if needed
+func mergeHeaders(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
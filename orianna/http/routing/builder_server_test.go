@@ -0,0 +1,151 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package routing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+)
+
+func TestGroupRouteBuilder_Headers_Server(t *testing.T) {
+	srv := testutil.NewServer(t)
+	group := routing.NewGroupRoute("/api/v1").
+		Headers(map[string]string{"Cache-Control": "no-store", "X-API-Version": "1"}).
+		GET("/users", func(ctx core.Context) error {
+			return ctx.OK("users")
+		}).
+		GET("/public", func(ctx core.Context) error {
+			ctx.Set("Cache-Control", "public, max-age=60")
+			return ctx.OK("public")
+		}).
+		Build()
+	if err := srv.RegisterGroup(*group); err != nil {
+		t.Fatalf("RegisterGroup() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		wantCache   string
+		wantVersion string
+	}{
+		{name: "default applies", path: "/api/v1/users", wantCache: "no-store", wantVersion: "1"},
+		{name: "handler overrides", path: "/api/v1/public", wantCache: "public, max-age=60", wantVersion: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, _ := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if got := resp.Header.Get("Cache-Control"); got != tt.wantCache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCache)
+			}
+			if got := resp.Header.Get("X-API-Version"); got != tt.wantVersion {
+				t.Errorf("X-API-Version = %q, want %q", got, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestRouteBuilder_Shadow_Server(t *testing.T) {
+	srv := testutil.NewServer(t)
+
+	type shadowCall struct {
+		id, body, user string
+	}
+	calls := make(chan shadowCall, 4)
+	shadow := func(ctx core.Context) error {
+		user, _ := ctx.Locals("user_id").(string)
+		calls <- shadowCall{id: ctx.Params("id"), body: string(ctx.Body()), user: user}
+		ctx.Set("X-Shadow", "1")
+		if ctx.Query("panic") != "" {
+			panic("shadow boom")
+		}
+		return ctx.Status(http.StatusTeapot).SendString("shadow")
+	}
+	live := func(ctx core.Context) error {
+		return ctx.SendString("live " + ctx.Params("id") + " " + string(ctx.Body()))
+	}
+	setUser := func(ctx core.Context) error {
+		ctx.Locals("user_id", "u-1")
+		return ctx.Next()
+	}
+	group := routing.NewGroupRoute("/api").Routes(
+		routing.NewRoute("/orders/:id").POST().Middleware(setUser).Handler(live).Shadow(1, shadow).Build(),
+		routing.NewRoute("/never/:id").POST().Handler(live).Shadow(0, shadow).Build(),
+	).Build()
+	if err := srv.RegisterGroup(*group); err != nil {
+		t.Fatalf("RegisterGroup() error = %v", err)
+	}
+
+	post := func(target string) (*http.Response, string) {
+		t.Helper()
+		return testutil.Send(t, srv, httptest.NewRequest(http.MethodPost, target, strings.NewReader("payload")))
+	}
+
+	for _, target := range []string{"/api/orders/42", "/api/orders/42?panic=1"} {
+		resp, body := post(target)
+		if resp.StatusCode != http.StatusOK || body != "live 42 payload" {
+			t.Errorf("%s: response = %d %q, want 200 %q", target, resp.StatusCode, body, "live 42 payload")
+		}
+		if resp.Header.Get("X-Shadow") != "" {
+			t.Errorf("%s: shadow header leaked into the response", target)
+		}
+		select {
+		case call := <-calls:
+			want := shadowCall{id: "42", body: "payload", user: "u-1"}
+			if call != want {
+				t.Errorf("%s: shadow saw %+v, want %+v", target, call, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s: shadow handler did not run", target)
+		}
+	}
+
+	if resp, body := post("/api/never/7"); resp.StatusCode != http.StatusOK || body != "live 7 payload" {
+		t.Errorf("unsampled response = %d %q", resp.StatusCode, body)
+	}
+	select {
+	case call := <-calls:
+		t.Errorf("shadow ran for a 0 fraction route: %+v", call)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A route runs at most MaxInFlight shadows; samples beyond that are dropped
+	release := make(chan struct{})
+	var slowCalls atomic.Int32
+	slow := routing.NewRoute("/slow/:id").POST().Handler(live).Shadow(1, func(_ core.Context) error {
+		slowCalls.Add(1)
+		<-release
+		return nil
+	}).Build()
+	slow.Shadow.MaxInFlight = 1
+	if err := srv.RegisterRoutes(*slow); err != nil {
+		t.Fatalf("RegisterRoutes() error = %v", err)
+	}
+	for range 3 {
+		if resp, _ := post("/slow/1"); resp.StatusCode != http.StatusOK {
+			t.Errorf("slow shadow route status = %d, want 200", resp.StatusCode)
+		}
+	}
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for slowCalls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := slowCalls.Load(); got != 1 {
+		t.Errorf("slow shadow ran %d times, want 1 (others dropped)", got)
+	}
+
+	invalid := routing.NewRoute("/bad").GET().Handler(live).Shadow(1.5, shadow).Build()
+	if err := srv.RegisterRoutes(*invalid); err == nil {
+		t.Error("RegisterRoutes() with shadow fraction 1.5 should fail")
+	}
+}
@@ -3,6 +3,7 @@
 package routing
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
@@ -43,6 +44,17 @@ func TestRouteBuilder_Middleware(t *testing.T) {
 	}
 }
 
+func TestRouteBuilder_Constrain(t *testing.T) {
+	route := NewRoute("/orders/:status/:id").
+		Constrain("status", "open", "closed").
+		ConstrainPattern("id", regexp.MustCompile(`^[0-9]+$`)).
+		Build()
+
+	if len(route.Middlewares) != 2 {
+		t.Errorf("Constrain() middlewares = %d, want 2", len(route.Middlewares))
+	}
+}
+
 func TestRouteBuilder_Permissions(t *testing.T) {
 	builder := NewRoute("/test")
 	builder.Permissions("read:users", "write:users")
@@ -176,6 +188,20 @@ func TestGroupRouteBuilder_Middleware(t *testing.T) {
 	}
 }
 
+func TestGroupRouteBuilder_Headers(t *testing.T) {
+	group := NewGroupRoute("/api").
+		Headers(map[string]string{"X-API-Version": "1"}).
+		Headers(map[string]string{"Cache-Control": "no-store"}).
+		Build()
+
+	if len(group.Headers) != 2 {
+		t.Errorf("Headers() count = %d, want 2", len(group.Headers))
+	}
+	if got := group.Headers["X-API-Version"]; got != "1" {
+		t.Errorf("Headers()[X-API-Version] = %q, want %q", got, "1")
+	}
+}
+
 func TestGroupRouteBuilder_Protected(t *testing.T) {
 	builder := NewGroupRoute("/api")
 	builder.Protected()
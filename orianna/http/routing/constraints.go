@@ -0,0 +1,52 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package routing
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// ConstrainParam rejects requests whose route parameter name is not one of
+// values with a 404 NOT_FOUND error (when UseProperHTTPStatus is enabled),
+// before the handler runs. Matching is exact and case-sensitive. Listing the
+// allowed values at the route also documents them.
+//
+// Example:
+//
+//	srv.GET("/users/:status", listUsersByStatus,
+//	    routing.ConstrainParam("status", "active", "inactive", "suspended"))
+func ConstrainParam(name string, values ...string) core.Middleware {
+	allowed := slices.Clone(values)
+	return func(ctx core.Context) error {
+		if !slices.Contains(allowed, ctx.Params(name)) {
+			return sendConstraintNotFound(ctx)
+		}
+		return ctx.Next()
+	}
+}
+
+// ConstrainParamPattern rejects requests whose route parameter name does not
+// match pattern with a 404 NOT_FOUND error. Anchor the pattern (^...$) to
+// match the whole segment.
+//
+// Example:
+//
+//	srv.GET("/orders/:id", getOrder,
+//	    routing.ConstrainParamPattern("id", regexp.MustCompile(`^[0-9]+$`)))
+func ConstrainParamPattern(name string, pattern *regexp.Regexp) core.Middleware {
+	return func(ctx core.Context) error {
+		if !pattern.MatchString(ctx.Params(name)) {
+			return sendConstraintNotFound(ctx)
+		}
+		return ctx.Next()
+	}
+}
+
+// sendConstraintNotFound answers a request whose path failed a constraint as
+// if no route matched it.
+func sendConstraintNotFound(ctx core.Context) error {
+	return core.SendError(ctx, core.NewErrorResponse("NOT_FOUND", core.StatusNotFound, "Resource not found"))
+}
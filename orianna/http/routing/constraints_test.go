@@ -0,0 +1,48 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package routing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+)
+
+func TestConstrainParam_Server(t *testing.T) {
+	srv := testutil.NewServer(t)
+	echo := func(ctx core.Context) error {
+		return ctx.SendString(ctx.Path())
+	}
+	if err := srv.GET("/users/:status", echo,
+		routing.ConstrainParam("status", "active", "inactive", "suspended")); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	route := routing.NewRoute("/orders/:id").GET().Handler(echo).
+		ConstrainPattern("id", regexp.MustCompile(`^[0-9]+$`)).Build()
+	if err := srv.RegisterRoutes(*route); err != nil {
+		t.Fatalf("RegisterRoutes() error = %v", err)
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{"/users/active", http.StatusOK},
+		{"/users/deleted", http.StatusNotFound},
+		{"/orders/42", http.StatusOK},
+		{"/orders/abc", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, _ := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GET %s status = %d, want %d", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package routing
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Route Path Patterns
+
+// PathParams returns the names of the parameters in a route path pattern, in
+// order: ":id" and ":id?" yield "id", a "*" segment yields "*".
+func PathParams(pattern string) []string {
+	var names []string
+	for _, seg := range splitPath(pattern) {
+		if name, ok := paramName(seg); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// MissingPathParams returns the parameters used by target that from does not
+// define, i.e., the ones that could not be filled in when expanding target
+// with the parameters of a request matched by from.
+func MissingPathParams(from, target string) []string {
+	defined := make(map[string]struct{})
+	for _, name := range PathParams(from) {
+		defined[name] = struct{}{}
+	}
+	var missing []string
+	for _, name := range PathParams(target) {
+		if _, ok := defined[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// MatchPath matches path against a route path pattern and returns the values
+// of its parameters. Static segments compare case-insensitively and trailing
+// slashes are ignored, like the router; ":name?" matches an absent last
+// segment and a trailing "*" matches the rest of the path.
+func MatchPath(pattern, path string) (map[string]string, bool) {
+	patSegs, pathSegs := splitPath(pattern), splitPath(path)
+	params := make(map[string]string)
+	for i, seg := range patSegs {
+		if seg == "*" && i == len(patSegs)-1 {
+			params["*"] = strings.Join(pathSegs[min(i, len(pathSegs)):], "/")
+			return params, true
+		}
+		if i >= len(pathSegs) {
+			// Only a trailing optional parameter may be absent
+			if strings.HasSuffix(seg, "?") && i == len(patSegs)-1 {
+				return params, true
+			}
+			return nil, false
+		}
+		if name, ok := paramName(seg); ok {
+			params[name] = pathSegs[i]
+			continue
+		}
+		if !strings.EqualFold(seg, pathSegs[i]) {
+			return nil, false
+		}
+	}
+	if len(pathSegs) != len(patSegs) {
+		return nil, false
+	}
+	return params, true
+}
+
+// ExpandPath fills the parameters of a route path pattern with the values
+// returned by param. An optional parameter with no value is dropped. Values
+// are path-escaped segment by segment, and empty segments (leading, trailing
+// or doubled slashes) are dropped, so a value cannot change the path's
+// structure: "/*" expanded with "/evil.com" gives "/evil.com", never the
+// scheme-relative URL "//evil.com".
+func ExpandPath(pattern string, param func(name string) string) string {
+	segs := splitPath(pattern)
+	out := make([]string, 0, len(segs))
+	for _, seg := range segs {
+		name, ok := paramName(seg)
+		if !ok {
+			out = append(out, seg)
+			continue
+		}
+		value := escapePathValue(param(name))
+		if value == "" && (name == "*" || strings.HasSuffix(seg, "?")) {
+			continue
+		}
+		out = append(out, value)
+	}
+	return "/" + strings.Join(out, "/")
+}
+
+// escapePathValue path-escapes each segment of a parameter value, dropping
+// empty ones. Values are taken as they appear in the request path, so they
+// are unescaped first and an escaped "/" stays escaped.
+func escapePathValue(value string) string {
+	var segs []string
+	for seg := range strings.SplitSeq(value, "/") {
+		if seg == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(seg); err == nil {
+			seg = unescaped
+		}
+		segs = append(segs, url.PathEscape(seg))
+	}
+	return strings.Join(segs, "/")
+}
+
+// splitPath splits a path into its non-empty segments.
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// paramName returns the parameter name of a pattern segment.
+func paramName(seg string) (string, bool) {
+	switch {
+	case seg == "*":
+		return "*", true
+	case strings.HasPrefix(seg, ":"):
+		return strings.TrimSuffix(seg[1:], "?"), true
+	}
+	return "", false
+}
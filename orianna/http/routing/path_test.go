@@ -0,0 +1,70 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package routing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    map[string]string
+		wantOK  bool
+	}{
+		{name: "static", pattern: "/users", path: "/users/", want: map[string]string{}, wantOK: true},
+		{name: "static is case-insensitive", pattern: "/Users", path: "/users", want: map[string]string{}, wantOK: true},
+		{name: "param", pattern: "/users/:id", path: "/users/42", want: map[string]string{"id": "42"}, wantOK: true},
+		{name: "missing param", pattern: "/users/:id", path: "/users", wantOK: false},
+		{name: "optional param absent", pattern: "/users/:id?", path: "/users", want: map[string]string{}, wantOK: true},
+		{name: "extra segment", pattern: "/users/:id", path: "/users/42/orders", wantOK: false},
+		{name: "wildcard", pattern: "/files/*", path: "/files/a/b.txt", want: map[string]string{"*": "a/b.txt"}, wantOK: true},
+		{name: "wildcard empty", pattern: "/files/*", path: "/files", want: map[string]string{"*": ""}, wantOK: true},
+		{name: "static mismatch", pattern: "/users/:id", path: "/orders/42", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MatchPath(tt.pattern, tt.path)
+			if ok != tt.wantOK {
+				t.Fatalf("MatchPath(%q, %q) ok = %v, want %v", tt.pattern, tt.path, ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	params := map[string]string{"id": "42", "*": "a/b.txt", "host": "//evil.com/", "name": "a%20b\\c"}
+	lookup := func(name string) string { return params[name] }
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "/v2/users/:id", want: "/v2/users/42"},
+		{pattern: "/v2/files/*", want: "/v2/files/a/b.txt"},
+		{pattern: "/v2/users/:id/:tab?", want: "/v2/users/42"},
+		{pattern: "/", want: "/"},
+		{pattern: "/:host", want: "/evil.com"},
+		{pattern: "/:name", want: "/a%20b%5Cc"},
+	}
+	for _, tt := range tests {
+		if got := ExpandPath(tt.pattern, lookup); got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestMissingPathParams(t *testing.T) {
+	if got := MissingPathParams("/v1/users/:id", "/v2/users/:id"); len(got) != 0 {
+		t.Errorf("MissingPathParams() = %v, want none", got)
+	}
+	if got := MissingPathParams("/v1/users", "/v2/users/:id/*"); !reflect.DeepEqual(got, []string{"id", "*"}) {
+		t.Errorf("MissingPathParams() = %v, want [id *]", got)
+	}
+}
@@ -211,6 +211,15 @@ func validateRoute(route *Route) error {
 		return fmt.Errorf("route handler cannot be nil for path: %s", route.Path)
 	}
 
+	if route.Shadow != nil {
+		if route.Shadow.Handler == nil {
+			return fmt.Errorf("route shadow handler cannot be nil for path: %s", route.Path)
+		}
+		if route.Shadow.Fraction < 0 || route.Shadow.Fraction > 1 {
+			return fmt.Errorf("route shadow fraction must be between 0 and 1 for path: %s", route.Path)
+		}
+	}
+
 	return nil
 }
 
@@ -307,11 +316,17 @@ func (rr *RouteRegistry) applyGroupProtection(group *RouteGroup) {
 	}
 }
 
-// createAuthorizationMiddleware creates an authorization middleware
+// createAuthorizationMiddleware creates an authorization middleware.
+// A checker error that is a *core.ErrorResponse (e.g., 403 FORBIDDEN) is sent
+// as-is; any other error is wrapped and ends up as a 500.
 func (rr *RouteRegistry) createAuthorizationMiddleware(permissions []string) core.Middleware {
 	checker := rr.authzChecker // capture the checker at creation time
 	return func(ctx core.Context) error {
 		if err := checker(ctx, permissions); err != nil {
+			var errResp *core.ErrorResponse
+			if errors.As(err, &errResp) {
+				return core.SendError(ctx, errResp)
+			}
 			return fmt.Errorf("insufficient permissions: %w", err)
 		}
 		return ctx.Next()
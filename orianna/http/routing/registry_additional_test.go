@@ -280,59 +280,76 @@ func TestValidateRoute_NoLeadingSlash(t *testing.T) {
 // simpleContext is a minimal mock for testing authorization middleware
 type simpleContext struct{}
 
-func (c *simpleContext) Next() error                        { return nil }
-func (c *simpleContext) Context() context.Context           { return context.Background() }
-func (c *simpleContext) SetContext(_ context.Context)       {}
-func (c *simpleContext) Method() string                     { return "GET" }
-func (c *simpleContext) Path() string                       { return "/" }
-func (c *simpleContext) RoutePath() string                  { return "/" }
-func (c *simpleContext) OriginalURL() string                { return "/" }
-func (c *simpleContext) BaseURL() string                    { return "" }
-func (c *simpleContext) Protocol() string                   { return "http" }
-func (c *simpleContext) Hostname() string                   { return "localhost" }
-func (c *simpleContext) IP() string                         { return "127.0.0.1" }
-func (c *simpleContext) Secure() bool                       { return false }
-func (c *simpleContext) Get(string, ...string) string       { return "" }
-func (c *simpleContext) Set(string, string)                 {}
-func (c *simpleContext) Append(string, ...string)           {}
-func (c *simpleContext) HeadersParser(any) error            { return nil }
-func (c *simpleContext) Params(string, ...string) string    { return "" }
-func (c *simpleContext) AllParams() map[string]string       { return nil }
-func (c *simpleContext) ParamsParser(any) error             { return nil }
-func (c *simpleContext) Query(string, ...string) string     { return "" }
-func (c *simpleContext) AllQueries() map[string]string      { return nil }
-func (c *simpleContext) QueryParser(any) error              { return nil }
-func (c *simpleContext) Body() []byte                       { return nil }
-func (c *simpleContext) BodyParser(any) error               { return nil }
-func (c *simpleContext) Cookies(string, ...string) string   { return "" }
-func (c *simpleContext) Cookie(*core.Cookie)                {}
-func (c *simpleContext) ClearCookie(...string)              {}
-func (c *simpleContext) Status(int) core.Context            { return c }
-func (c *simpleContext) ResponseStatusCode() int            { return 200 }
-func (c *simpleContext) JSON(any) error                     { return nil }
-func (c *simpleContext) XML(any) error                      { return nil }
-func (c *simpleContext) SendString(string) error            { return nil }
-func (c *simpleContext) SendBytes([]byte) error             { return nil }
-func (c *simpleContext) SendStream(io.Reader, ...int) error { return nil }
-func (c *simpleContext) SendFile(string) error              { return nil }
-func (c *simpleContext) Redirect(string, ...int) error      { return nil }
-func (c *simpleContext) Accepts(...string) string           { return "" }
-func (c *simpleContext) AcceptsCharsets(...string) string   { return "" }
-func (c *simpleContext) AcceptsEncodings(...string) string  { return "" }
-func (c *simpleContext) AcceptsLanguages(...string) string  { return "" }
-func (c *simpleContext) Fresh() bool                        { return false }
-func (c *simpleContext) Stale() bool                        { return false }
-func (c *simpleContext) XHR() bool                          { return false }
-func (c *simpleContext) Locals(string, ...any) any          { return nil }
-func (c *simpleContext) GetAllLocals() map[string]any       { return nil }
-func (c *simpleContext) OK(any) error                       { return nil }
-func (c *simpleContext) Created(any) error                  { return nil }
-func (c *simpleContext) NoContent() error                   { return nil }
-func (c *simpleContext) BadRequestMsg(string) error         { return nil }
-func (c *simpleContext) UnauthorizedMsg(string) error       { return nil }
-func (c *simpleContext) ForbiddenMsg(string) error          { return nil }
-func (c *simpleContext) NotFoundMsg(string) error           { return nil }
-func (c *simpleContext) InternalErrorMsg(string) error      { return nil }
-func (c *simpleContext) IsMethod(string) bool               { return false }
-func (c *simpleContext) RequestID() string                  { return "" }
-func (c *simpleContext) UseProperHTTPStatus() bool          { return false }
+func (c *simpleContext) Next() error                  { return nil }
+func (c *simpleContext) Context() context.Context     { return context.Background() }
+func (c *simpleContext) SetContext(_ context.Context) {}
+func (c *simpleContext) Method() string               { return "GET" }
+func (c *simpleContext) Path() string                 { return "/" }
+func (c *simpleContext) RoutePath() string            { return "/" }
+func (c *simpleContext) OriginalURL() string          { return "/" }
+func (c *simpleContext) BaseURL() string              { return "" }
+func (c *simpleContext) Protocol() string             { return "http" }
+func (c *simpleContext) Hostname() string             { return "localhost" }
+func (c *simpleContext) IP() string                   { return "127.0.0.1" }
+func (c *simpleContext) IPInfo() core.IPInfo {
+	return core.IPInfo{PeerIP: "127.0.0.1", ClientIP: "127.0.0.1"}
+}
+func (c *simpleContext) Secure() bool                    { return false }
+func (c *simpleContext) Get(string, ...string) string    { return "" }
+func (c *simpleContext) Set(string, string)              {}
+func (c *simpleContext) Append(string, ...string)        {}
+func (c *simpleContext) HeadersParser(any) error         { return nil }
+func (c *simpleContext) Params(string, ...string) string { return "" }
+func (c *simpleContext) AllParams() map[string]string    { return nil }
+func (c *simpleContext) ParamsParser(any) error          { return nil }
+func (c *simpleContext) Query(string, ...string) string  { return "" }
+func (c *simpleContext) AllQueries() map[string]string   { return nil }
+func (c *simpleContext) QueryParser(any) error           { return nil }
+func (c *simpleContext) Body() []byte                    { return nil }
+func (c *simpleContext) BodyParser(any) error            { return nil }
+func (c *simpleContext) SaveUploadedFile(string, string, ...core.UploadProgressFunc) (int64, error) {
+	return 0, nil
+}
+func (c *simpleContext) NDJSON(func(func(any) error) error) error { return nil }
+func (c *simpleContext) Cookies(string, ...string) string         { return "" }
+func (c *simpleContext) Cookie(*core.Cookie)                      {}
+func (c *simpleContext) ClearCookie(...string)                    {}
+func (c *simpleContext) Status(int) core.Context                  { return c }
+func (c *simpleContext) ResponseStatusCode() int                  { return 200 }
+func (c *simpleContext) JSON(any) error                           { return nil }
+func (c *simpleContext) XML(any) error                            { return nil }
+func (c *simpleContext) SendString(string) error                  { return nil }
+func (c *simpleContext) SendBytes([]byte) error                   { return nil }
+func (c *simpleContext) SendStream(io.Reader, ...int) error       { return nil }
+func (c *simpleContext) SendFile(string) error                    { return nil }
+func (c *simpleContext) Attachment(string, string, []byte) error  { return nil }
+func (c *simpleContext) AttachmentStream(string, string, func(io.Writer) error) error {
+	return nil
+}
+func (c *simpleContext) ResponseBody() []byte                 { return nil }
+func (c *simpleContext) ResponseHeaders() map[string][]string { return nil }
+func (c *simpleContext) Redirect(string, ...int) error        { return nil }
+func (c *simpleContext) Accepts(...string) string             { return "" }
+func (c *simpleContext) AcceptsCharsets(...string) string     { return "" }
+func (c *simpleContext) AcceptsEncodings(...string) string    { return "" }
+func (c *simpleContext) AcceptsLanguages(...string) string    { return "" }
+func (c *simpleContext) Fresh() bool                          { return false }
+func (c *simpleContext) Stale() bool                          { return false }
+func (c *simpleContext) XHR() bool                            { return false }
+func (c *simpleContext) Locals(string, ...any) any            { return nil }
+func (c *simpleContext) GetAllLocals() map[string]any         { return nil }
+func (c *simpleContext) OK(any) error                         { return nil }
+func (c *simpleContext) Created(any) error                    { return nil }
+func (c *simpleContext) Accepted(any) error                   { return nil }
+func (c *simpleContext) PartialContent(any) error             { return nil }
+func (c *simpleContext) JSONStatus(int, any) error            { return nil }
+func (c *simpleContext) NoContent() error                     { return nil }
+func (c *simpleContext) BadRequestMsg(string) error           { return nil }
+func (c *simpleContext) UnauthorizedMsg(string) error         { return nil }
+func (c *simpleContext) ForbiddenMsg(string) error            { return nil }
+func (c *simpleContext) NotFoundMsg(string) error             { return nil }
+func (c *simpleContext) InternalErrorMsg(string) error        { return nil }
+func (c *simpleContext) IsMethod(string) bool                 { return false }
+func (c *simpleContext) RequestID() string                    { return "" }
+func (c *simpleContext) UseProperHTTPStatus() bool            { return false }
+func (c *simpleContext) CollapseValidationErrors() bool       { return false }
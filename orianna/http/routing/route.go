@@ -9,6 +9,7 @@ import (
 
 // Route represents a single HTTP route configuration.
 type Route struct {
+	Name                string // Optional human-friendly name used in metrics and logs
 	Path                string
 	Methods             []core.Method
 	Handler             core.Handler
@@ -16,6 +17,20 @@ type Route struct {
 	RequiredPermissions []string
 	IsProtected         bool
 	CORS                *configuration.CORSConfig // Optional per-route CORS configuration
+	Headers             map[string]string         // Default response headers set before the handler runs
+	Shadow              *ShadowConfig             // Optional shadow handler receiving a copy of sampled requests
+}
+
+// ShadowConfig duplicates a sample of a route's requests to a second handler,
+// e.g., to validate a new handler version against live traffic. The shadow
+// runs asynchronously on a copy of the request after the route's handler; its
+// response is discarded and only compared with the real one. At most
+// MaxInFlight shadows run at once; a sample taken while they are all busy is
+// dropped, so a slow shadow cannot pile up goroutines.
+type ShadowConfig struct {
+	Fraction    float64      // Share of requests duplicated, from 0 to 1
+	Handler     core.Handler // Shadow handler
+	MaxInFlight int          // Concurrent shadows (default: configuration.DefaultShadowMaxInFlight)
 }
 
 // RouteGroup represents a group of routes with a common prefix
@@ -25,4 +40,5 @@ type RouteGroup struct {
 	Groups      []RouteGroup // Nested groups
 	Middlewares []core.Middleware
 	IsProtected bool
+	Headers     map[string]string // Default response headers for every route in the group
 }
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestServer_MaxBodySize(t *testing.T) {
+	srv, err := server.NewServer(&configuration.Config{ServiceName: "test", MaxBodySize: 1024})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	if err := srv.POST("/echo", func(ctx core.Context) error {
+		return ctx.SendString(string(ctx.Body()))
+	}); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+
+	post := func(body string, chunked bool) (*http.Response, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+		if chunked {
+			req.ContentLength = -1
+			req.TransferEncoding = []string{"chunked"}
+		}
+		return testutil.Send(t, srv, req)
+	}
+
+	for _, chunked := range []bool{false, true} {
+		if resp, body := post(strings.Repeat("a", 1024), chunked); resp.StatusCode != http.StatusOK || len(body) != 1024 {
+			t.Errorf("chunked=%v, body at the limit: status = %d, len(body) = %d, want 200 and 1024", chunked, resp.StatusCode, len(body))
+		}
+		if _, body := post(strings.Repeat("a", 1025), chunked); !strings.Contains(body, "Request Entity Too Large") {
+			t.Errorf("chunked=%v, body over the limit: body = %q, want it rejected", chunked, body)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+)
+
+// MaintenanceOptions configures maintenance mode entered with SetMaintenance.
+type MaintenanceOptions struct {
+	// Message is the error message sent to rejected requests
+	// (default: configuration.DefaultMaintenanceMessage).
+	Message string
+	// RetryAfter is sent in the Retry-After header, rounded up to whole
+	// seconds (default: configuration.DefaultMaintenanceRetryAfter).
+	RetryAfter time.Duration
+	// ExemptPaths are path prefixes that keep working, e.g., "/admin" for
+	// /admin and everything below it (but not /administrators). The health,
+	// liveness, readiness and metrics paths are always exempt.
+	ExemptPaths []string
+}
+
+// maintenanceMode rejects requests while enabled. The rejecting middleware is
+// built once per SetMaintenance call, so requests only pay an atomic load.
+type maintenanceMode struct {
+	reject atomic.Pointer[core.Middleware]
+}
+
+// enable builds the rejecting middleware for opts and switches it on.
+func (m *maintenanceMode) enable(opts MaintenanceOptions) {
+	message := opts.Message
+	if message == "" {
+		message = configuration.DefaultMaintenanceMessage
+	}
+	retryAfter := opts.RetryAfter
+	if retryAfter <= 0 {
+		retryAfter = configuration.DefaultMaintenanceRetryAfter
+	}
+	seconds := strconv.Itoa(int((retryAfter + time.Second - 1) / time.Second))
+
+	reject := func(ctx core.Context) error {
+		ctx.Set(core.HeaderRetryAfter, seconds)
+		return core.SendError(ctx, core.NewErrorResponse("MAINTENANCE", core.StatusServiceUnavailable, message))
+	}
+	exempt := append(append([]string(nil), configuration.DefaultReadinessGateExemptPaths...), opts.ExemptPaths...)
+	mw := middleware.SkipForPathSegments(reject, exempt...)
+	m.reject.Store(&mw)
+}
+
+// middleware passes requests through unless maintenance mode is on.
+func (m *maintenanceMode) middleware() core.Middleware {
+	return func(ctx core.Context) error {
+		if reject := m.reject.Load(); reject != nil {
+			return (*reject)(ctx)
+		}
+		return ctx.Next()
+	}
+}
+
+// SetMaintenance turns maintenance mode on or off at runtime, without a
+// redeploy. While it is on, every request except the exempt paths gets a
+// MAINTENANCE error (503 with UseProperHTTPStatus) and a Retry-After header.
+// opts applies when turning it on; calling it again while on replaces them.
+//
+// Example:
+//
+//	srv.SetMaintenance(true, server.MaintenanceOptions{
+//	    Message:     "Scheduled database migration",
+//	    RetryAfter:  10 * time.Minute,
+//	    ExemptPaths: []string{"/admin"},
+//	})
+//	defer srv.SetMaintenance(false)
+func (s *Server) SetMaintenance(on bool, opts ...MaintenanceOptions) {
+	if !on {
+		s.maintenance.reject.Store(nil)
+		return
+	}
+	var opt MaintenanceOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	s.maintenance.enable(opt)
+}
+
+// InMaintenance reports whether maintenance mode is on.
+func (s *Server) InMaintenance() bool {
+	return s.maintenance.reject.Load() != nil
+}
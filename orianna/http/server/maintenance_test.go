@@ -0,0 +1,65 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestServer_SetMaintenance(t *testing.T) {
+	srv := testutil.NewServer(t)
+	ok := func(ctx core.Context) error { return ctx.SendString("ok") }
+	for _, path := range []string{"/orders", "/admin/flags", "/administrators", "/health", "/healthcheck"} {
+		if err := srv.GET(path, ok); err != nil {
+			t.Fatalf("GET(%s) error = %v", path, err)
+		}
+	}
+
+	get := func(path string) *http.Response {
+		t.Helper()
+		resp, _ := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp
+	}
+
+	if resp := get("/orders"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("before maintenance: status = %d, want 200", resp.StatusCode)
+	}
+
+	srv.SetMaintenance(true, server.MaintenanceOptions{RetryAfter: 90 * time.Second, ExemptPaths: []string{"/admin"}})
+	if !srv.InMaintenance() {
+		t.Error("InMaintenance() = false after SetMaintenance(true)")
+	}
+	resp := get("/orders")
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("in maintenance: status = %d, want 503", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "90" {
+		t.Errorf("Retry-After = %q, want 90", got)
+	}
+	for _, path := range []string{"/admin/flags", "/health"} {
+		if resp := get(path); resp.StatusCode != http.StatusOK {
+			t.Errorf("exempt %s: status = %d, want 200", path, resp.StatusCode)
+		}
+	}
+	// Exempt prefixes match whole path segments only
+	for _, path := range []string{"/administrators", "/healthcheck"} {
+		if resp := get(path); resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("not exempt %s: status = %d, want 503", path, resp.StatusCode)
+		}
+	}
+
+	srv.SetMaintenance(false)
+	if srv.InMaintenance() {
+		t.Error("InMaintenance() = true after SetMaintenance(false)")
+	}
+	if resp := get("/orders"); resp.StatusCode != http.StatusOK {
+		t.Errorf("after maintenance: status = %d, want 200", resp.StatusCode)
+	}
+}
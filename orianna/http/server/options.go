@@ -4,11 +4,14 @@ package server
 
 import (
 	"fmt"
+	"html/template"
+	"time"
 
 	"github.com/anthanhphan/gosdk/metrics"
 	"github.com/anthanhphan/gosdk/orianna/http/configuration"
 	"github.com/anthanhphan/gosdk/orianna/http/core"
 	"github.com/anthanhphan/gosdk/orianna/http/engine"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
 	"github.com/anthanhphan/gosdk/orianna/shared/health"
 	"github.com/anthanhphan/gosdk/tracing"
 )
@@ -32,6 +35,20 @@ func WithPanicRecover(middleware core.Middleware) ServerOption {
 	}
 }
 
+// WithPanicResponder installs panic recovery that delegates the response to
+// responder, e.g. to include a support reference ID. The panic and its location
+// are logged. Use middleware.DefaultPanicResponder(true) to include the stack
+// trace in development. It replaces any middleware set by WithPanicRecover.
+func WithPanicResponder(responder middleware.PanicResponder) ServerOption {
+	return func(s *Server) error {
+		if responder == nil {
+			return fmt.Errorf("panic responder cannot be nil")
+		}
+		s.panicRecover = middleware.RecoverPanics(responder, s.logger)
+		return nil
+	}
+}
+
 // WithAuthentication sets the authentication middleware
 func WithAuthentication(middleware core.Middleware) ServerOption {
 	return func(s *Server) error {
@@ -88,10 +105,13 @@ func WithShutdownManager(manager ShutdownManager) ServerOption {
 	}
 }
 
-// WithMetrics adds metrics middleware to the server
-func WithMetrics(client metrics.Client) ServerOption {
+// WithMetrics adds metrics middleware to the server.
+// opts configure the middleware, e.g., middleware.WithSizeMetrics() to record
+// the request/response body size histograms.
+func WithMetrics(client metrics.Client, opts ...middleware.MetricsOption) ServerOption {
 	return func(s *Server) error {
 		s.metricsClient = client
+		s.metricsOptions = opts
 		return nil
 	}
 }
@@ -130,3 +150,184 @@ func WithHealthChecker(checker health.Checker) ServerOption {
 		return fmt.Errorf("health manager type %T does not support dynamic checker registration", s.healthManager)
 	}
 }
+
+// WithReadinessGate holds back traffic until the given checkers have each
+// reported healthy at least once (e.g., a database pool has warmed up).
+// While the gate is closed every request gets a SERVICE_UNAVAILABLE error
+// (HTTP 503 when UseProperHTTPStatus is enabled), except
+// the health, liveness, readiness and metrics paths
+// (configuration.DefaultReadinessGateExemptPaths, or the list set with
+// WithReadinessGateExemptPaths), which stay reachable. A prefix exempts
+// itself and the paths below it: "/health" covers /health/ready but not
+// /healthcheck. Once open, the gate never closes again; use health checks for ongoing status.
+func WithReadinessGate(checkers ...health.Checker) ServerOption {
+	return func(s *Server) error {
+		if len(checkers) == 0 {
+			return fmt.Errorf("readiness gate requires at least one checker: %w", core.ErrNilChecker)
+		}
+		for _, checker := range checkers {
+			if checker == nil {
+				return fmt.Errorf("readiness gate checker: %w", core.ErrNilChecker)
+			}
+		}
+		s.readinessGate = newReadinessGate(checkers)
+		return nil
+	}
+}
+
+// WithReadinessGateExemptPaths replaces the path prefixes that stay reachable
+// while the readiness gate is closed (default:
+// configuration.DefaultReadinessGateExemptPaths). Each prefix matches whole
+// path segments. Append to the default list to keep the probe endpoints open.
+//
+// Example:
+//
+//	server.WithReadinessGateExemptPaths(append(configuration.DefaultReadinessGateExemptPaths, "/version")...)
+func WithReadinessGateExemptPaths(paths ...string) ServerOption {
+	return func(s *Server) error {
+		s.readinessExempt = append([]string{}, paths...)
+		return nil
+	}
+}
+
+// WithSlowRequestThreshold logs a warning (method, path, duration, request ID)
+// for every request slower than d and, with WithMetrics, counts it in
+// {service}_slow_requests_total. It overrides Config.SlowRequestThreshold;
+// routes can set their own threshold with RouteBuilder.SlowRequestThreshold.
+func WithSlowRequestThreshold(d time.Duration) ServerOption {
+	return func(s *Server) error {
+		if d <= 0 {
+			return fmt.Errorf("slow request threshold must be positive, got %s", d)
+		}
+		s.config.SlowRequestThreshold = d
+		return nil
+	}
+}
+
+// WithErrorHTMLTemplate renders error responses with tmpl for clients that
+// prefer text/html, e.g., browsers posting forms. JSON stays the default and
+// plain text is served to clients that ask for it; see core.ErrorHTMLTemplate.
+func WithErrorHTMLTemplate(tmpl *template.Template) ServerOption {
+	return func(s *Server) error {
+		if tmpl == nil {
+			return fmt.Errorf("error HTML template cannot be nil")
+		}
+		s.globalMiddlewares = append(s.globalMiddlewares, core.ErrorHTMLTemplate(tmpl))
+		return nil
+	}
+}
+
+// WithMethodOverride routes POST requests carrying an X-HTTP-Method-Override
+// header of PUT, PATCH or DELETE to the handler registered for that method,
+// for clients and proxies that can only send GET and POST.
+// Any other method or override value is left untouched.
+//
+// Security: the override lets a plain HTML form or a cross-site POST reach
+// PUT/PATCH/DELETE handlers, and it can bypass proxy or WAF rules keyed on the
+// request method. Only enable it when those routes are authenticated and
+// CSRF-protected. Has no effect with a custom engine set via WithServerEngine.
+func WithMethodOverride() ServerOption {
+	return func(s *Server) error {
+		s.config.MethodOverride = true
+		return nil
+	}
+}
+
+// WithStrictSlash makes "/users" and "/users/" distinct paths when strict is
+// true: a request only reaches the route registered with exactly its path. By
+// default a trailing slash is ignored, so both forms reach the same handler.
+// Has no effect with a custom engine set via WithServerEngine.
+func WithStrictSlash(strict bool) ServerOption {
+	return func(s *Server) error {
+		s.config.StrictSlash = strict
+		return nil
+	}
+}
+
+// WithRedirectTrailingSlash, when redirect is true, answers a request that
+// matches no route, but would with its trailing slash added or removed, with a
+// 308 redirect to that path, keeping the query string. Routing becomes strict
+// (see WithStrictSlash), so explicitly registered paths always win: with both
+// "/users" and "/users/" registered, neither redirects. The redirect is tried
+// before fallback handlers. Has no effect with a custom engine set via
+// WithServerEngine.
+func WithRedirectTrailingSlash(redirect bool) ServerOption {
+	return func(s *Server) error {
+		s.config.RedirectTrailingSlash = redirect
+		return nil
+	}
+}
+
+// WithCompression compresses responses with brotli, gzip or deflate, as the
+// client's Accept-Encoding allows, skipping bodies smaller than opts.MinSize
+// and the content types in opts.SkipContentTypes. It sets Content-Encoding and
+// adds Accept-Encoding to Vary. Streamed responses are compressed as they are
+// written, and ETags are computed on the uncompressed body. It overrides
+// Config.Compression and enables compression even when
+// MiddlewareConfig.DisableCompression is set. Has no effect with a custom
+// engine set via WithServerEngine.
+//
+// Example:
+//
+//	srv, _ := server.NewServer(cfg, server.WithCompression(configuration.CompressionConfig{
+//	    MinSize:          1024,
+//	    SkipContentTypes: []string{"application/zip"},
+//	}))
+func WithCompression(opts configuration.CompressionConfig) ServerOption {
+	return func(s *Server) error {
+		if opts.MinSize < 0 {
+			return fmt.Errorf("compression min size cannot be negative, got %d", opts.MinSize)
+		}
+		s.config.Compression = &opts
+		return nil
+	}
+}
+
+// WithContextFactory wraps the Context of every request with factory, e.g., to
+// add helper methods, record calls in tests, or start a span per request.
+// factory runs once per request, on first use, and its result is passed to
+// every middleware and handler of the request, including fallbacks. The
+// wrapper usually embeds the Context it is given as a core.BaseContext, and
+// must delegate Next to it so the chain continues. Has no effect with a custom
+// engine set via WithServerEngine.
+//
+// Example:
+//
+//	type appContext struct{ core.BaseContext }
+//
+//	func (c appContext) UserID() string { return c.Get("X-User-ID") }
+//
+//	srv, _ := server.NewServer(cfg, server.WithContextFactory(func(ctx core.Context) core.Context {
+//	    return appContext{ctx}
+//	}))
+//	srv.GET("/me", func(ctx core.Context) error {
+//	    return ctx.SendString(ctx.(appContext).UserID())
+//	})
+func WithContextFactory(factory func(core.Context) core.Context) ServerOption {
+	return func(s *Server) error {
+		if factory == nil {
+			return fmt.Errorf("context factory cannot be nil")
+		}
+		s.config.ContextFactory = factory
+		return nil
+	}
+}
+
+// WithPprof mounts the net/http/pprof endpoints under /debug/pprof
+// (index, cmdline, profile, symbol, trace and named profiles such as heap or
+// goroutine). They are disabled unless this option is set.
+//
+// Security: profiles expose the command line, memory contents, goroutine stacks
+// and symbol names, and a CPU profile or trace keeps a request busy for its
+// whole duration, so the endpoints are never public. With opts.Token set they
+// require "Authorization: Bearer <token>"; otherwise they are protected by the
+// WithAuthentication middleware and NewServer fails if none is configured.
+// Keep the token out of source control, and consider exposing the endpoints
+// only on an internal network. A RequestTimeout shorter than the requested
+// profile duration cuts the profile short.
+func WithPprof(opts PprofOptions) ServerOption {
+	return func(s *Server) error {
+		s.pprof = &opts
+		return nil
+	}
+}
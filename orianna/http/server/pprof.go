@@ -0,0 +1,103 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+)
+
+// PprofPathPrefix is the path the pprof endpoints are mounted under.
+const PprofPathPrefix = "/debug/pprof"
+
+// PprofOptions configures the pprof endpoints enabled by WithPprof.
+type PprofOptions struct {
+	// Token, when set, is required as "Authorization: Bearer <token>" on every
+	// pprof request instead of the server's authentication middleware.
+	// When empty, the endpoints are protected by the middleware set with
+	// WithAuthentication, which is then required.
+	Token string
+}
+
+// pprofHandlers maps the fixed pprof endpoints to their net/http handlers.
+// Any other name under PprofPathPrefix is served as a named runtime profile
+// (heap, goroutine, allocs, block, mutex, threadcreate).
+var pprofHandlers = map[string]http.HandlerFunc{
+	"cmdline": pprof.Cmdline,
+	"profile": pprof.Profile,
+	"symbol":  pprof.Symbol,
+	"trace":   pprof.Trace,
+}
+
+// registerPprof mounts the pprof endpoints under PprofPathPrefix.
+func (s *Server) registerPprof(opts PprofOptions) error {
+	var guard core.Middleware
+	if opts.Token != "" {
+		guard = pprofTokenAuth(opts.Token)
+	} else if s.authMiddleware == nil {
+		return fmt.Errorf("pprof requires a token or an authentication middleware")
+	}
+
+	route := func(path string, handler core.Handler, methods ...core.Method) routing.Route {
+		r := routing.Route{
+			Path:        path,
+			Methods:     methods,
+			Handler:     handler,
+			IsProtected: guard == nil,
+		}
+		if guard != nil {
+			r.Middlewares = []core.Middleware{guard}
+		}
+		return r
+	}
+
+	routes := []routing.Route{
+		route(PprofPathPrefix+"/", pprofHandler(pprof.Index, "/"), core.GET),
+	}
+	for name, h := range pprofHandlers {
+		methods := []core.Method{core.GET}
+		if name == "symbol" {
+			methods = append(methods, core.POST)
+		}
+		routes = append(routes, route(PprofPathPrefix+"/"+name, pprofHandler(h, "/"+name), methods...))
+	}
+	routes = append(routes, route(PprofPathPrefix+"/:name", pprofProfileHandler, core.GET))
+
+	return s.RegisterRoutes(routes...)
+}
+
+// pprofTokenAuth rejects requests that do not carry the bearer token.
+func pprofTokenAuth(token string) core.Middleware {
+	return func(ctx core.Context) error {
+		got, ok := strings.CutPrefix(ctx.Get(core.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return core.SendError(ctx, core.NewErrorResponse("UNAUTHORIZED", core.StatusUnauthorized, core.MessageUnauthorized))
+		}
+		return ctx.Next()
+	}
+}
+
+// pprofProfileHandler serves a named runtime profile such as heap or goroutine.
+func pprofProfileHandler(ctx core.Context) error {
+	name := ctx.Params("name")
+	return pprofHandler(pprof.Handler(name).ServeHTTP, "/"+name)(ctx)
+}
+
+// pprofHandler adapts a net/http pprof handler to core.Handler. pprof.Index
+// reads the profile name from the URL path, so the request path is replaced
+// with the canonical /debug/pprof one. Responses are marked no-store so the
+// cache middleware never serves a stale profile.
+func pprofHandler(h http.HandlerFunc, subPath string) core.Handler {
+	return core.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.URL.Path = PprofPathPrefix + subPath
+		r.URL.RawPath = ""
+		w.Header().Set(core.HeaderCacheControl, "no-store")
+		h(w, r)
+	}))
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestServer_Pprof(t *testing.T) {
+	get := func(t *testing.T, srv *server.Server, path, authorization string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorization != "" {
+			req.Header.Set(core.HeaderAuthorization, authorization)
+		}
+		resp, _ := testutil.Send(t, srv, req)
+		return resp
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		srv := testutil.NewServer(t)
+		// Unmatched routes surface through the error handler rather than 200
+		if resp := get(t, srv, "/debug/pprof/heap", ""); resp.StatusCode == http.StatusOK {
+			t.Error("pprof endpoint should not be mounted")
+		}
+	})
+
+	t.Run("token", func(t *testing.T) {
+		srv := testutil.NewServer(t, server.WithPprof(server.PprofOptions{Token: "s3cret"}))
+		if resp := get(t, srv, "/debug/pprof/heap", ""); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("no token: status = %d, want 401", resp.StatusCode)
+		}
+		if resp := get(t, srv, "/debug/pprof/heap", "Bearer wrong"); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("wrong token: status = %d, want 401", resp.StatusCode)
+		}
+		for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap?debug=1"} {
+			resp := get(t, srv, path, "Bearer s3cret")
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("%s: status = %d, want 200", path, resp.StatusCode)
+			}
+			if cc := resp.Header.Get(core.HeaderCacheControl); cc != "no-store" {
+				t.Errorf("%s: Cache-Control = %q, want no-store", path, cc)
+			}
+		}
+	})
+
+	t.Run("server authentication", func(t *testing.T) {
+		auth := func(ctx core.Context) error {
+			if ctx.Get(core.HeaderAuthorization) != "Bearer admin" {
+				return ctx.UnauthorizedMsg("unauthorized")
+			}
+			return ctx.Next()
+		}
+		srv := testutil.NewServer(t, server.WithPprof(server.PprofOptions{}), server.WithAuthentication(auth))
+		if resp := get(t, srv, "/debug/pprof/goroutine", ""); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("status = %d, want 401", resp.StatusCode)
+		}
+		if resp := get(t, srv, "/debug/pprof/goroutine", "Bearer admin"); resp.StatusCode != http.StatusOK {
+			t.Errorf("status = %d, want 200", resp.StatusCode)
+		}
+	})
+
+	t.Run("requires protection", func(t *testing.T) {
+		conf := &configuration.Config{ServiceName: "test", Port: 0}
+		if _, err := server.NewServer(conf, server.WithPprof(server.PprofOptions{})); err == nil {
+			t.Error("NewServer() should fail without a token or authentication middleware")
+		}
+	})
+}
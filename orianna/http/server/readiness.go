@@ -0,0 +1,78 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+	"github.com/anthanhphan/gosdk/orianna/shared/health"
+)
+
+// readinessGate rejects traffic until every gated checker has reported
+// healthy at least once. After that it stays open for the life of the server.
+type readinessGate struct {
+	open    atomic.Bool
+	mu      sync.Mutex
+	pending []health.Checker
+}
+
+// newReadinessGate creates a closed gate over the given checkers.
+func newReadinessGate(checkers []health.Checker) *readinessGate {
+	return &readinessGate{pending: checkers}
+}
+
+// ready reports whether the gate is open, re-running the checkers that have
+// not passed yet. Only one caller evaluates at a time; concurrent callers
+// see the gate as closed instead of piling onto slow dependencies.
+func (g *readinessGate) ready(ctx context.Context) bool {
+	if g.open.Load() {
+		return true
+	}
+	if !g.mu.TryLock() {
+		return false
+	}
+	defer g.mu.Unlock()
+	if g.open.Load() {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, configuration.DefaultHealthCheckTimeout)
+	defer cancel()
+
+	manager := health.NewManager()
+	for _, checker := range g.pending {
+		manager.Register(checker)
+	}
+	report := manager.Check(ctx)
+
+	remaining := g.pending[:0]
+	for _, checker := range g.pending {
+		if check, ok := report.Checks[checker.Name()]; !ok || check.Status != health.StatusHealthy {
+			remaining = append(remaining, checker)
+		}
+	}
+	g.pending = remaining
+
+	if len(g.pending) == 0 {
+		g.open.Store(true)
+	}
+	return g.open.Load()
+}
+
+// middleware rejects every request with SERVICE_UNAVAILABLE (503) while the
+// gate is closed, except for paths at or below one of the exempt prefixes.
+func (g *readinessGate) middleware(exempt []string) core.Middleware {
+	gate := func(ctx core.Context) error {
+		if g.ready(ctx.Context()) {
+			return ctx.Next()
+		}
+		ctx.Set(core.HeaderRetryAfter, "1")
+		return core.SendError(ctx, core.NewErrorResponse("SERVICE_UNAVAILABLE", core.StatusServiceUnavailable, "Service is not ready"))
+	}
+	return middleware.SkipForPathSegments(gate, exempt...)
+}
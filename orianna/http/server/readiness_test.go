@@ -0,0 +1,92 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+	"github.com/anthanhphan/gosdk/orianna/shared/health"
+)
+
+func TestServer_WithReadinessGate(t *testing.T) {
+	var dbReady atomic.Bool
+	dbChecker := health.NewCustomChecker("database", func(_ context.Context) health.HealthCheck {
+		if dbReady.Load() {
+			return health.HealthCheck{Status: health.StatusHealthy}
+		}
+		return health.HealthCheck{Status: health.StatusUnhealthy, Message: "pool warming up"}
+	})
+
+	srv := testutil.NewServer(t, server.WithReadinessGate(dbChecker))
+	ok := func(ctx core.Context) error { return ctx.OK(core.Map{"ok": true}) }
+	for _, path := range []string{"/users", "/health", "/healthcheck"} {
+		if err := srv.GET(path, ok); err != nil {
+			t.Fatalf("GET() error = %v", err)
+		}
+	}
+
+	status := func(path string) int {
+		t.Helper()
+		resp, _ := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp.StatusCode
+	}
+
+	if got := status("/users"); got != http.StatusServiceUnavailable {
+		t.Errorf("gated /users status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+	if got := status("/health"); got != http.StatusOK {
+		t.Errorf("gated /health status = %d, want %d", got, http.StatusOK)
+	}
+	// Exempt prefixes match whole path segments only
+	if got := status("/healthcheck"); got != http.StatusServiceUnavailable {
+		t.Errorf("gated /healthcheck status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+
+	dbReady.Store(true)
+	if got := status("/users"); got != http.StatusOK {
+		t.Errorf("ready /users status = %d, want %d", got, http.StatusOK)
+	}
+
+	// The gate stays open even if the dependency flaps afterwards
+	dbReady.Store(false)
+	if got := status("/users"); got != http.StatusOK {
+		t.Errorf("opened /users status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestServer_WithReadinessGateExemptPaths(t *testing.T) {
+	never := health.NewCustomChecker("database", func(_ context.Context) health.HealthCheck {
+		return health.HealthCheck{Status: health.StatusUnhealthy}
+	})
+	srv := testutil.NewServer(t, server.WithReadinessGate(never), server.WithReadinessGateExemptPaths("/version"))
+	ok := func(ctx core.Context) error { return ctx.OK(core.Map{"ok": true}) }
+	for _, path := range []string{"/version", "/health"} {
+		if err := srv.GET(path, ok); err != nil {
+			t.Fatalf("GET() error = %v", err)
+		}
+	}
+
+	for path, want := range map[string]int{
+		"/version": http.StatusOK,
+		"/health":  http.StatusServiceUnavailable, // the default list was replaced
+	} {
+		if resp, _ := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, path, nil)); resp.StatusCode != want {
+			t.Errorf("gated %s status = %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestWithReadinessGate_NoCheckers(t *testing.T) {
+	_, err := server.NewServer(&configuration.Config{ServiceName: "test", Port: 0}, server.WithReadinessGate())
+	if err == nil {
+		t.Error("NewServer() with an empty readiness gate should fail")
+	}
+}
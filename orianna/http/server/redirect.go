@@ -0,0 +1,108 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/routing"
+)
+
+// Redirects and Aliases
+
+// redirectMethods are the methods Redirect answers. OPTIONS is left out so
+// CORS preflights, which browsers never redirect, are not broken.
+var redirectMethods = []core.Method{core.GET, core.HEAD, core.POST, core.PUT, core.PATCH, core.DELETE}
+
+// Redirect answers requests to oldPath with a redirect to newPath, e.g., after
+// renaming an endpoint. Path parameters of oldPath fill the parameters of the
+// same name in newPath, and the query string is kept. Use 308 (or 307) for
+// endpoints called with a body: unlike 301 and 302, clients repeat the method
+// and body on the new location.
+//
+// Input:
+//   - oldPath: The route path to redirect from
+//   - newPath: The path to redirect to; may only use oldPath's parameters
+//   - status: One of 301, 302, 303, 307 or 308
+//
+// Output:
+//   - error: Returns an error if status is not a redirect status, newPath uses
+//     a parameter oldPath lacks, or oldPath is already registered
+//
+// Example:
+//
+//	srv.Redirect("/v1/users/:id", "/v2/users/:id", http.StatusPermanentRedirect)
+//	// GET /v1/users/42?fields=name -> 308 Location: /v2/users/42?fields=name
+func (s *Server) Redirect(oldPath, newPath string, status int) error {
+	switch status {
+	case core.StatusMovedPermanently, core.StatusFound, core.StatusSeeOther,
+		core.StatusTemporaryRedirect, core.StatusPermanentRedirect:
+	default:
+		return fmt.Errorf("redirect %q: status %d is not a redirect status", oldPath, status)
+	}
+	if err := checkPathParams(oldPath, newPath); err != nil {
+		return fmt.Errorf("redirect %q: %w", oldPath, err)
+	}
+
+	route := routing.NewRoute(oldPath).
+		Methods(redirectMethods...).
+		Handler(func(ctx core.Context) error {
+			location := routing.ExpandPath(newPath, func(name string) string { return ctx.Params(name) })
+			if isOffSiteLocation(location) {
+				return ctx.BadRequestMsg("Invalid redirect path")
+			}
+			if _, query, ok := strings.Cut(ctx.OriginalURL(), "?"); ok && query != "" {
+				location += "?" + query
+			}
+			return ctx.Redirect(location, status)
+		}).
+		Build()
+	return s.RegisterRoutes(*route)
+}
+
+// isOffSiteLocation reports whether location would leave the site: browsers
+// read "//host" and "/\host" as scheme-relative URLs to another host.
+func isOffSiteLocation(location string) bool {
+	return strings.HasPrefix(location, "//") || strings.HasPrefix(location, "/\\")
+}
+
+// Alias serves requests matching aliasPath with the handler of targetPath, as
+// an internal rewrite: the client sees no redirect, and the request runs
+// through the middleware chain once, as a request to targetPath. Path
+// parameters of aliasPath fill the parameters of the same name in targetPath,
+// and the query string is kept. Aliases are matched before routes, for every
+// method, and are not chained.
+//
+// Input:
+//   - aliasPath: The path pattern to serve
+//   - targetPath: The registered route path to serve it with; may only use
+//     aliasPath's parameters
+//
+// Output:
+//   - error: Returns an error if a path is empty or targetPath uses a
+//     parameter aliasPath lacks
+//
+// Example:
+//
+//	srv.GET("/v2/users/:id", getUser)
+//	srv.Alias("/users/:id", "/v2/users/:id")
+func (s *Server) Alias(aliasPath, targetPath string) error {
+	if aliasPath == "" || targetPath == "" {
+		return fmt.Errorf("alias %q: %w", aliasPath, core.ErrEmptyRoutePath)
+	}
+	if err := checkPathParams(aliasPath, targetPath); err != nil {
+		return fmt.Errorf("alias %q: %w", aliasPath, err)
+	}
+	s.serverAdapter.RegisterAlias(aliasPath, targetPath)
+	return nil
+}
+
+// checkPathParams ensures every parameter of target can be filled from from.
+func checkPathParams(from, target string) error {
+	if missing := routing.MissingPathParams(from, target); len(missing) > 0 {
+		return fmt.Errorf("%q uses parameters %v that %q does not define", target, missing, from)
+	}
+	return nil
+}
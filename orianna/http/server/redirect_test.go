@@ -0,0 +1,83 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+)
+
+func TestServer_RedirectAndAlias(t *testing.T) {
+	srv := testutil.NewServer(t)
+	var calls atomic.Int32
+	getUser := func(ctx core.Context) error {
+		calls.Add(1)
+		return ctx.SendString(ctx.Path() + " " + ctx.Params("id") + " " + ctx.Query("fields"))
+	}
+	if err := srv.GET("/v2/users/:id", getUser); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+	if err := srv.Redirect("/v1/users/:id", "/v2/users/:id", http.StatusPermanentRedirect); err != nil {
+		t.Fatalf("Redirect() error = %v", err)
+	}
+	if err := srv.Alias("/users/:id", "/v2/users/:id"); err != nil {
+		t.Fatalf("Alias() error = %v", err)
+	}
+
+	t.Run("redirect keeps params and query string", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			resp, _ := testutil.Send(t, srv, httptest.NewRequest(method, "/v1/users/42?fields=name", nil))
+			if resp.StatusCode != http.StatusPermanentRedirect {
+				t.Errorf("%s: status = %d, want 308", method, resp.StatusCode)
+			}
+			if got := resp.Header.Get("Location"); got != "/v2/users/42?fields=name" {
+				t.Errorf("%s: Location = %q, want /v2/users/42?fields=name", method, got)
+			}
+		}
+	})
+
+	t.Run("redirect does not leave the site", func(t *testing.T) {
+		if err := srv.Redirect("/docs/*", "/*", http.StatusMovedPermanently); err != nil {
+			t.Fatalf("Redirect() error = %v", err)
+		}
+		for path, want := range map[string]string{
+			"/docs//evil.com":      "/evil.com",
+			"/docs/%2F%2Fevil.com": "/%2F%2Fevil.com",
+			"/docs/%5Cevil.com":    "/%5Cevil.com",
+			"/docs/guide/intro":    "/guide/intro",
+		} {
+			resp, _ := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, path, nil))
+			if got := resp.Header.Get("Location"); got != want {
+				t.Errorf("%s: Location = %q, want %q", path, got, want)
+			}
+		}
+	})
+
+	t.Run("alias invokes the target handler", func(t *testing.T) {
+		before := calls.Load()
+		resp, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/users/42?fields=name", nil))
+		if resp.StatusCode != http.StatusOK || body != "/v2/users/42 42 name" {
+			t.Errorf("status = %d, body = %q, want 200 %q", resp.StatusCode, body, "/v2/users/42 42 name")
+		}
+		if got := calls.Load() - before; got != 1 {
+			t.Errorf("handler calls = %d, want 1", got)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if err := srv.Redirect("/old", "/new", http.StatusOK); err == nil {
+			t.Error("Redirect() with status 200: want error")
+		}
+		if err := srv.Redirect("/old", "/new/:id", http.StatusMovedPermanently); err == nil {
+			t.Error("Redirect() to an undefined parameter: want error")
+		}
+		if err := srv.Alias("/a", "/b/:id"); err == nil {
+			t.Error("Alias() to an undefined parameter: want error")
+		}
+	})
+}
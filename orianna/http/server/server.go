@@ -7,6 +7,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/anthanhphan/gosdk/logger"
@@ -50,7 +51,12 @@ type Server struct {
 	rateLimiter       core.Middleware
 	middlewareConfig  *configuration.MiddlewareConfig
 	metricsClient     metrics.Client
+	metricsOptions    []middleware.MetricsOption
 	tracingClient     tracing.Client
+	readinessGate     *readinessGate
+	readinessExempt   []string
+	maintenance       maintenanceMode
+	pprof             *PprofOptions
 }
 
 // NewServer creates a new server instance with the given configuration and options.
@@ -64,12 +70,15 @@ func NewServer(
 	if conf == nil {
 		return nil, fmt.Errorf("invalid config: config cannot be nil")
 	}
-	if err := conf.Validate(); err != nil {
+	// Validate a copy: Validate keeps the parsed TrustedProxies on it, and the
+	// caller's config may be shared
+	validated := *conf
+	if err := validated.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	// Merge with defaults
-	conf = mergeConfig(conf)
+	conf = mergeConfig(&validated)
 
 	// Create server (adapter set after options)
 	server := &Server{
@@ -119,7 +128,7 @@ func NewServer(
 
 	// Setup metrics if enabled
 	if server.metricsClient != nil {
-		server.Use(middleware.MetricsMiddleware(server.metricsClient, server.config.ServiceName))
+		server.Use(middleware.MetricsMiddleware(server.metricsClient, server.config.ServiceName, server.metricsOptions...))
 
 		// Register /metrics endpoint for Prometheus scraping
 		server.serverAdapter.RegisterMetricsHandler(server.metricsClient)
@@ -127,7 +136,8 @@ func NewServer(
 
 	// Setup slow request detection if threshold is configured
 	if server.config.SlowRequestThreshold > 0 {
-		server.Use(middleware.SlowRequestDetector(server.config.SlowRequestThreshold))
+		server.Use(middleware.SlowRequestDetectorWithMetrics(
+			server.config.SlowRequestThreshold, server.metricsClient, server.config.ServiceName, server.logger))
 	}
 
 	// Setup tracing if enabled and not disabled via middleware config
@@ -135,10 +145,11 @@ func NewServer(
 		server.Use(middleware.TracingMiddleware(server.tracingClient))
 	}
 
-	// Setup logging middleware AFTER tracing so trace_id is available in logs
+	// Setup logging middleware AFTER tracing so trace_id is available in logs.
+	// Access entries go to the dedicated access logger when one is configured.
 	server.serverAdapter.SetupLoggingMiddleware(
 		server.middlewareConfig,
-		server.logger,
+		logger.AccessLogger().With(logger.String("package", "transport")),
 	)
 
 	// Setup hooks middleware AFTER logging so hooks see full request context
@@ -149,6 +160,25 @@ func NewServer(
 		server.serverAdapter.Use(hooksMiddleware(server.hooks))
 	}
 
+	// Hold back traffic until gated dependencies are ready
+	if server.readinessGate != nil {
+		exempt := configuration.DefaultReadinessGateExemptPaths
+		if server.readinessExempt != nil {
+			exempt = server.readinessExempt
+		}
+		server.serverAdapter.Use(server.readinessGate.middleware(exempt))
+	}
+
+	// Off until SetMaintenance; registered up front so it can be flipped at runtime
+	server.serverAdapter.Use(server.maintenance.middleware())
+
+	// Mount pprof last so the routes run behind every global middleware
+	if server.pprof != nil {
+		if err := server.registerPprof(*server.pprof); err != nil {
+			return nil, fmt.Errorf("failed to register pprof: %w", err)
+		}
+	}
+
 	// Routes will be registered when user calls RegisterRoutes
 	// No need to register empty routes here
 
@@ -156,6 +186,7 @@ func NewServer(
 }
 
 // hooksMiddleware creates a middleware that fires request lifecycle hooks.
+// Errors caused by the client disconnecting fire OnClientDisconnect instead of OnError.
 // Hooks are executed with panic recovery to prevent a faulty hook from
 // crashing the server.
 func hooksMiddleware(hooks *core.Hooks) core.Middleware {
@@ -169,7 +200,11 @@ func hooksMiddleware(hooks *core.Hooks) core.Middleware {
 		hooks.ExecuteOnResponse(ctx, ctx.ResponseStatusCode(), latency)
 
 		if err != nil {
-			hooks.ExecuteOnError(ctx, err)
+			if core.IsClientDisconnect(err) {
+				hooks.ExecuteOnClientDisconnect(ctx, err)
+			} else {
+				hooks.ExecuteOnError(ctx, err)
+			}
 		}
 		return err
 	}
@@ -250,6 +285,58 @@ func (s *Server) Use(middleware ...core.Middleware) {
 	s.serverAdapter.Use(middleware...)
 }
 
+// Fallback serves requests under prefix that match no registered route with
+// handler, e.g., a JSON 404 for unknown API paths and the SPA index for every
+// other page. Fallbacks only run when no route matches, so the order they are
+// registered in relative to routes does not matter; among fallbacks, the
+// longest matching prefix wins ("/api" before "/"). A request for a path that
+// is registered under another method is not unmatched and skips the fallbacks.
+//
+// Global middleware added with Use after the first Fallback call does not
+// wrap fallback responses, so register fallbacks after Use.
+//
+// Example:
+//
+//	srv.Fallback("/api", func(ctx core.Context) error {
+//	    return ctx.NotFoundMsg("no such endpoint")
+//	})
+//	srv.Fallback("/", func(ctx core.Context) error {
+//	    return ctx.SendFile("./web/dist/index.html")
+//	})
+func (s *Server) Fallback(prefix string, handler core.Handler) error {
+	if handler == nil {
+		return fmt.Errorf("fallback for %q: %w", prefix, core.ErrHandlerNil)
+	}
+	s.serverAdapter.RegisterFallback(prefix, handler)
+	return nil
+}
+
+// defaultTestTimeout bounds a single Test dispatch when the caller doesn't pass one.
+const defaultTestTimeout = 5 * time.Second
+
+// Test dispatches req through the full server pipeline (global middleware,
+// hooks, authentication, route middleware and handler) without binding a port.
+// It is intended for tests using net/http/httptest and is safe for parallel use.
+//
+// Input:
+//   - req: The request to dispatch, typically built with httptest.NewRequest
+//   - timeout: Optional per-request deadline (default 5s; 0 disables it)
+//
+// Output:
+//   - *http.Response: The response exactly as a real client would receive it
+//   - error: Returns an error if the request could not be dispatched
+//
+// Example:
+//
+//	resp, err := srv.Test(httptest.NewRequest(http.MethodGet, "/users", nil))
+func (s *Server) Test(req *http.Request, timeout ...time.Duration) (*http.Response, error) {
+	d := defaultTestTimeout
+	if len(timeout) > 0 {
+		d = timeout[0]
+	}
+	return s.serverAdapter.Test(req, d)
+}
+
 // GetHealthManager returns the health check manager
 func (s *Server) GetHealthManager() HealthCheckManager {
 	return s.healthManager
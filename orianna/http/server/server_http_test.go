@@ -0,0 +1,590 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/middleware"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestServer_Test_ProtectedRoute(t *testing.T) {
+	var requests, responses atomic.Int32
+	hooks := core.NewHooks()
+	hooks.AddOnRequest(func(_ core.Context) { requests.Add(1) })
+	hooks.AddOnResponse(func(_ core.Context, _ int, _ time.Duration) { responses.Add(1) })
+
+	auth := func(ctx core.Context) error {
+		if ctx.Get("Authorization") != "Bearer secret" {
+			return ctx.UnauthorizedMsg("missing or invalid token")
+		}
+		return ctx.Next()
+	}
+
+	srv := testutil.NewServer(t, server.WithAuthentication(auth), server.WithHooks(hooks))
+	if err := srv.Protected().GET("/me", func(ctx core.Context) error {
+		return ctx.OK(map[string]string{"user": "alice"})
+	}); err != nil {
+		t.Fatalf("Protected().GET() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "without token", wantStatus: http.StatusUnauthorized, wantBody: "missing or invalid token"},
+		{name: "with token", token: "Bearer secret", wantStatus: http.StatusOK, wantBody: "alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+
+			resp, body := testutil.Send(t, srv, req)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %q", body, tt.wantBody)
+			}
+		})
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("OnRequest fired %d times, want 2", got)
+	}
+	if got := responses.Load(); got != 2 {
+		t.Errorf("OnResponse fired %d times, want 2", got)
+	}
+}
+
+func TestServer_WithPanicResponder(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		responder   middleware.PanicResponder
+		wantContain []string
+		wantAbsent  []string
+	}{
+		{
+			name: "custom responder",
+			responder: func(ctx core.Context, recovered any, location string) error {
+				return ctx.Status(core.StatusInternalServerError).JSON(core.Map{
+					"support_ref": "REF-42",
+					"panic":       fmt.Sprint(recovered),
+					"location":    location,
+				})
+			},
+			wantContain: []string{"REF-42", "boom", "server_http_test.go"},
+		},
+		{
+			name:        "development includes stack",
+			env:         "local",
+			responder:   middleware.DefaultPanicResponder(true),
+			wantContain: []string{"INTERNAL_ERROR", `"stack"`, `"location"`},
+		},
+		{
+			name:        "production suppresses stack",
+			env:         "production",
+			responder:   middleware.DefaultPanicResponder(true),
+			wantContain: []string{"INTERNAL_ERROR"},
+			wantAbsent:  []string{`"stack"`, "server_http_test.go"},
+		},
+		{
+			name: "panicking responder falls back",
+			responder: func(_ core.Context, _ any, _ string) error {
+				panic("responder failed")
+			},
+			wantContain: []string{"INTERNAL_ERROR"},
+			wantAbsent:  []string{"responder failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("ENV", tt.env)
+			}
+			srv := testutil.NewServer(t, server.WithPanicResponder(tt.responder))
+			if err := srv.GET("/panic", func(_ core.Context) error {
+				panic("boom")
+			}); err != nil {
+				t.Fatalf("GET() error = %v", err)
+			}
+
+			resp, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/panic", nil))
+			if resp.StatusCode != http.StatusInternalServerError {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+			}
+			for _, want := range tt.wantContain {
+				if !strings.Contains(body, want) {
+					t.Errorf("body = %s, want it to contain %q", body, want)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(body, absent) {
+					t.Errorf("body = %s, want it not to contain %q", body, absent)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_PanicWithErrorResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []server.ServerOption
+		panicValue any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "default recovery",
+			panicValue: core.NewErrorResponse("ORDER_NOT_FOUND", http.StatusNotFound, "Order not found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "ORDER_NOT_FOUND",
+		},
+		{
+			name:       "default responder",
+			opts:       []server.ServerOption{server.WithPanicResponder(middleware.DefaultPanicResponder(false))},
+			panicValue: core.NewErrorResponse("ORDER_NOT_FOUND", http.StatusNotFound, "Order not found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "ORDER_NOT_FOUND",
+		},
+		{
+			name:       "wrapped error response",
+			opts:       []server.ServerOption{server.WithPanicResponder(middleware.DefaultPanicResponder(false))},
+			panicValue: fmt.Errorf("load order: %w", core.NewErrorResponse("CONFLICT", http.StatusConflict, "Order locked")),
+			wantStatus: http.StatusConflict,
+			wantCode:   "CONFLICT",
+		},
+		{
+			name:       "plain error keeps 500",
+			opts:       []server.ServerOption{server.WithPanicResponder(middleware.DefaultPanicResponder(false))},
+			panicValue: errors.New("nil map write"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := testutil.NewServer(t, tt.opts...)
+			if err := srv.GET("/orders/:id", func(_ core.Context) error {
+				panic(tt.panicValue)
+			}); err != nil {
+				t.Fatalf("GET() error = %v", err)
+			}
+
+			resp, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(body, tt.wantCode) {
+				t.Errorf("body = %s, want it to contain %q", body, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWithPanicResponder_Nil(t *testing.T) {
+	_, err := server.NewServer(&configuration.Config{ServiceName: "test", Port: 0}, server.WithPanicResponder(nil))
+	if err == nil {
+		t.Error("NewServer() with nil panic responder should fail")
+	}
+}
+
+func TestServer_WithMethodOverride(t *testing.T) {
+	srv := testutil.NewServer(t, server.WithMethodOverride())
+	reply := func(name string) core.Handler {
+		return func(ctx core.Context) error { return ctx.OK(core.Map{"handler": name}) }
+	}
+	if err := srv.POST("/items/:id", reply("post")); err != nil {
+		t.Fatalf("POST() error = %v", err)
+	}
+	if err := srv.DELETE("/items/:id", reply("delete")); err != nil {
+		t.Fatalf("DELETE() error = %v", err)
+	}
+	if err := srv.PATCH("/items/:id", reply("patch")); err != nil {
+		t.Fatalf("PATCH() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		override string
+		want     string
+	}{
+		{name: "POST overridden to DELETE", method: http.MethodPost, override: "DELETE", want: "delete"},
+		{name: "override is case-insensitive", method: http.MethodPost, override: "patch", want: "patch"},
+		{name: "no override header", method: http.MethodPost, want: "post"},
+		{name: "GET is not an allowed target", method: http.MethodPost, override: "GET", want: "post"},
+		{name: "only POST is remapped", method: http.MethodPatch, override: "DELETE", want: "patch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/items/1", nil)
+			if tt.override != "" {
+				req.Header.Set(core.HeaderXHTTPMethodOverride, tt.override)
+			}
+			if _, body := testutil.Send(t, srv, req); !strings.Contains(body, `"handler":"`+tt.want+`"`) {
+				t.Errorf("body = %s, want handler %q", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_ClientDisconnect(t *testing.T) {
+	var disconnects, errorHooks atomic.Int32
+	hooks := core.NewHooks().
+		AddOnClientDisconnect(func(_ core.Context, _ error) { disconnects.Add(1) }).
+		AddOnError(func(_ core.Context, _ error) { errorHooks.Add(1) })
+
+	srv := testutil.NewServer(t, server.WithHooks(hooks))
+	// Simulates a write to a connection the client already closed
+	err := srv.GET("/stream", func(_ core.Context) error {
+		return &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}
+	})
+	if err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	resp, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if resp.StatusCode == http.StatusInternalServerError || strings.Contains(body, "INTERNAL_ERROR") {
+		t.Errorf("got an error response (status %d, body %s), want none", resp.StatusCode, body)
+	}
+	if got := disconnects.Load(); got != 1 {
+		t.Errorf("OnClientDisconnect fired %d times, want 1", got)
+	}
+	if got := errorHooks.Load(); got != 0 {
+		t.Errorf("OnError fired %d times, want 0", got)
+	}
+}
+
+func TestServer_ErrorContentNegotiation(t *testing.T) {
+	newServer := func(opts ...server.ServerOption) *server.Server {
+		t.Helper()
+		srv := testutil.NewServer(t, opts...)
+		if err := srv.GET("/orders/:id", func(ctx core.Context) error {
+			return core.SendError(ctx, core.NewErrorResponse("NOT_FOUND", core.StatusNotFound, "Order not found"))
+		}); err != nil {
+			t.Fatalf("GET() error = %v", err)
+		}
+		return srv
+	}
+	get := func(srv *server.Server, accept string) (*http.Response, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/orders/7", nil)
+		req.Header.Set("Accept", accept)
+		return testutil.Send(t, srv, req)
+	}
+
+	plain := newServer()
+	resp, body := get(plain, "text/plain")
+	if resp.StatusCode != http.StatusNotFound || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("text/plain: status %d, Content-Type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.HasPrefix(body, "NOT_FOUND: Order not found\nrequest_id: ") {
+		t.Errorf("text/plain body = %q", body)
+	}
+
+	browser := "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	if resp, _ := get(plain, browser); strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("browser without template: Content-Type %q, want no HTML", resp.Header.Get("Content-Type"))
+	}
+
+	tmpl := template.Must(template.New("error").Parse(`<h1>{{.HTTPStatus}} {{.Message}}</h1>`))
+	html := newServer(server.WithErrorHTMLTemplate(tmpl))
+	resp, body = get(html, browser)
+	if body != "<h1>404 Order not found</h1>" || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("browser with template: Content-Type %q, body %q", resp.Header.Get("Content-Type"), body)
+	}
+	if _, body := get(html, "application/json"); !strings.Contains(body, `"code":"NOT_FOUND"`) {
+		t.Errorf("application/json body = %q", body)
+	}
+}
+
+func TestServer_Fallback(t *testing.T) {
+	srv := testutil.NewServer(t)
+
+	// Registered before the routes on purpose: fallbacks never shadow routes
+	if err := srv.Fallback("/", func(ctx core.Context) error {
+		ctx.Set(core.HeaderContentType, "text/html")
+		return ctx.SendString("<html>spa</html>")
+	}); err != nil {
+		t.Fatalf("Fallback() error = %v", err)
+	}
+	if err := srv.Fallback("/api", func(ctx core.Context) error {
+		return ctx.NotFoundMsg("no such endpoint")
+	}); err != nil {
+		t.Fatalf("Fallback() error = %v", err)
+	}
+	if err := srv.GET("/api/users", func(ctx core.Context) error {
+		return ctx.SendString("users")
+	}); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"registered route", http.MethodGet, "/api/users", http.StatusOK, "users"},
+		{"unmatched api path", http.MethodGet, "/api/orders", http.StatusNotFound, `"no such endpoint"`},
+		{"api prefix itself", http.MethodGet, "/api", http.StatusNotFound, `"no such endpoint"`},
+		{"unmatched page path", http.MethodGet, "/settings/profile", http.StatusOK, "<html>spa</html>"},
+		{"prefix needs a segment boundary", http.MethodGet, "/apidocs", http.StatusOK, "<html>spa</html>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := testutil.Send(t, srv, httptest.NewRequest(tt.method, tt.path, nil))
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", resp.StatusCode, tt.wantStatus, body)
+			}
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.wantBody)
+			}
+		})
+	}
+
+	// A path registered under another method is not unmatched
+	if _, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodPost, "/api/users", nil)); strings.Contains(body, "no such endpoint") {
+		t.Errorf("POST /api/users should not reach the fallback, got %s", body)
+	}
+
+	if err := srv.Fallback("/x", nil); err == nil {
+		t.Error("Fallback() with nil handler should fail")
+	}
+}
+
+func TestServer_TrailingSlash(t *testing.T) {
+	newServer := func(t *testing.T, opts ...server.ServerOption) *server.Server {
+		t.Helper()
+		srv := testutil.NewServer(t, opts...)
+		for path, body := range map[string]string{"/users": "users", "/orders/": "orders", "/items": "items", "/items/": "items/"} {
+			if err := srv.GET(path, func(ctx core.Context) error { return ctx.SendString(body) }); err != nil {
+				t.Fatalf("GET(%s) error = %v", path, err)
+			}
+		}
+		return srv
+	}
+	get := func(t *testing.T, srv *server.Server, target string) (*http.Response, string) {
+		t.Helper()
+		return testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	t.Run("default merges both forms", func(t *testing.T) {
+		srv := newServer(t)
+		for target, want := range map[string]string{"/users": "users", "/users/": "users", "/orders": "orders", "/orders/": "orders"} {
+			if resp, body := get(t, srv, target); resp.StatusCode != http.StatusOK || body != want {
+				t.Errorf("GET %s: status = %d, body = %q, want 200 %q", target, resp.StatusCode, body, want)
+			}
+		}
+	})
+
+	t.Run("strict keeps forms apart", func(t *testing.T) {
+		srv := newServer(t, server.WithStrictSlash(true))
+		for target, matched := range map[string]bool{"/users": true, "/users/": false, "/orders/": true, "/orders": false} {
+			if resp, _ := get(t, srv, target); (resp.StatusCode == http.StatusOK) != matched {
+				t.Errorf("GET %s: status = %d, want a match = %v", target, resp.StatusCode, matched)
+			}
+		}
+	})
+
+	t.Run("redirect to the registered form", func(t *testing.T) {
+		srv := newServer(t, server.WithRedirectTrailingSlash(true))
+		if err := srv.Fallback("/", func(ctx core.Context) error { return ctx.SendString("fallback") }); err != nil {
+			t.Fatalf("Fallback() error = %v", err)
+		}
+		for target, location := range map[string]string{"/users/?page=2": "/users?page=2", "/orders": "/orders/"} {
+			resp, _ := get(t, srv, target)
+			if resp.StatusCode != http.StatusPermanentRedirect || resp.Header.Get("Location") != location {
+				t.Errorf("GET %s: status = %d, Location = %q, want 308 %q", target, resp.StatusCode, resp.Header.Get("Location"), location)
+			}
+		}
+		for target, want := range map[string]string{"/users": "users", "/orders/": "orders", "/items": "items", "/items/": "items/", "/unknown/": "fallback"} {
+			if resp, body := get(t, srv, target); resp.StatusCode != http.StatusOK || body != want {
+				t.Errorf("GET %s: status = %d, body = %q, want 200 %q", target, resp.StatusCode, body, want)
+			}
+		}
+	})
+
+	t.Run("no redirect off the site", func(t *testing.T) {
+		srv := newServer(t, server.WithRedirectTrailingSlash(true))
+		if err := srv.GET("/:slug", func(ctx core.Context) error { return ctx.SendString("slug") }); err != nil {
+			t.Fatalf("GET() error = %v", err)
+		}
+		for _, target := range []string{"//evil.com/", "/%5Cevil.com/"} {
+			resp, _ := get(t, srv, target)
+			if location := resp.Header.Get("Location"); strings.HasPrefix(location, "//") || strings.HasPrefix(location, "/\\") {
+				t.Errorf("GET %s: Location = %q, want no off-site redirect", target, location)
+			}
+		}
+	})
+}
+
+// recordingContext is a ContextFactory wrapper recording the handlers it is passed to.
+type recordingContext struct {
+	core.BaseContext
+	calls *[]string
+}
+
+func (c recordingContext) record(name string) { *c.calls = append(*c.calls, name) }
+
+func TestServer_WithContextFactory(t *testing.T) {
+	var calls []string
+	var created atomic.Int32
+	srv := testutil.NewServer(t, server.WithContextFactory(func(ctx core.Context) core.Context {
+		created.Add(1)
+		return recordingContext{BaseContext: ctx, calls: &calls}
+	}))
+	srv.Use(func(ctx core.Context) error {
+		rc, ok := ctx.(recordingContext)
+		if !ok {
+			t.Errorf("global middleware got %T, want recordingContext", ctx)
+			return ctx.Next()
+		}
+		rc.record("global")
+		return ctx.Next()
+	})
+	routeMiddleware := func(ctx core.Context) error {
+		ctx.(recordingContext).record("route")
+		return ctx.Next()
+	}
+	handler := func(ctx core.Context) error {
+		rc, ok := ctx.(recordingContext)
+		if !ok {
+			t.Errorf("handler got %T, want recordingContext", ctx)
+			return ctx.SendString("unwrapped")
+		}
+		rc.record("handler")
+		return ctx.SendString("ok")
+	}
+	if err := srv.GET("/orders", handler, routeMiddleware); err != nil {
+		t.Fatalf("GET() error = %v", err)
+	}
+
+	resp, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if resp.StatusCode != http.StatusOK || body != "ok" {
+		t.Errorf("status = %d, body = %q, want 200 ok", resp.StatusCode, body)
+	}
+	if want := []string{"global", "route", "handler"}; fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if got := created.Load(); got != 1 {
+		t.Errorf("factory called %d times for one request, want 1", got)
+	}
+
+	if _, err := server.NewServer(&configuration.Config{ServiceName: "test", Port: 0}, server.WithContextFactory(nil)); err == nil {
+		t.Error("WithContextFactory(nil): want error")
+	}
+}
+
+func TestServer_WithCompression(t *testing.T) {
+	srv := testutil.NewServer(t,
+		server.WithMiddlewareConfig(&configuration.MiddlewareConfig{DisableCache: true, DisableCompression: true}),
+		server.WithCompression(configuration.CompressionConfig{MinSize: 1024, SkipContentTypes: []string{"application/zip"}}),
+	)
+	large := map[string]string{"data": strings.Repeat("orianna ", 512)}
+	_ = srv.GET("/large", func(ctx core.Context) error { return ctx.JSON(large) })
+	_ = srv.GET("/small", func(ctx core.Context) error {
+		return ctx.JSON(map[string]string{"data": strings.Repeat("x", 500)})
+	})
+	_ = srv.GET("/zip", func(ctx core.Context) error {
+		ctx.Set(core.HeaderContentType, "application/zip")
+		return ctx.SendString(strings.Repeat("z", 4096))
+	})
+	_ = srv.GET("/stream", func(ctx core.Context) error {
+		ctx.Set(core.HeaderContentType, core.MIMETextEventStream)
+		return ctx.SendStream(strings.NewReader(strings.Repeat("s", 100)), -1)
+	})
+
+	get := func(path string, header ...string) (*http.Response, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		return testutil.Send(t, srv, req)
+	}
+	gunzip := func(body string) string {
+		t.Helper()
+		zr, err := gzip.NewReader(strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+		plain, _ := io.ReadAll(zr)
+		return string(plain)
+	}
+
+	resp, body := get("/large")
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("/large Content-Encoding = %q, want gzip", got)
+	}
+	if !strings.Contains(resp.Header.Get("Vary"), "Accept-Encoding") {
+		t.Errorf("/large Vary = %q, want Accept-Encoding", resp.Header.Get("Vary"))
+	}
+	var decoded map[string]string
+	if err := jcodec.Unmarshal([]byte(gunzip(body)), &decoded); err != nil || decoded["data"] != large["data"] {
+		t.Errorf("/large body did not decompress to the JSON response (err = %v)", err)
+	}
+	etag := resp.Header.Get("ETag")
+	if !strings.HasPrefix(etag, "W/") {
+		t.Fatalf("/large ETag = %q, want the weak tag of the uncompressed body", etag)
+	}
+	if resp, _ := get("/large", "If-None-Match", etag); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("/large with If-None-Match status = %d, want 304", resp.StatusCode)
+	}
+
+	for _, path := range []string{"/small", "/zip"} {
+		resp, body := get(path)
+		if got := resp.Header.Get("Content-Encoding"); got != "" {
+			t.Errorf("%s Content-Encoding = %q, want none", path, got)
+		}
+		if len(body) < 500 {
+			t.Errorf("%s body length = %d, want the uncompressed body", path, len(body))
+		}
+	}
+
+	// Event streams skip the ETag middleware, so the body is still a stream here
+	resp, body = get("/stream", "Accept", core.MIMETextEventStream)
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("/stream Content-Encoding = %q, want gzip", got)
+	}
+	if got := gunzip(body); got != strings.Repeat("s", 100) {
+		t.Errorf("/stream body = %q, want 100 s", got)
+	}
+
+	conf := &configuration.Config{ServiceName: "test", Port: 0}
+	if _, err := server.NewServer(conf, server.WithCompression(configuration.CompressionConfig{MinSize: -1})); err == nil {
+		t.Error("WithCompression(MinSize: -1): want error")
+	}
+}
@@ -115,6 +115,7 @@ type RouteShortcuts struct {
 	isProtected bool
 	permissions []string
 	middleware  []core.Middleware
+	headers     map[string]string
 }
 
 // Protected returns a RouteShortcuts instance for registering protected routes.
@@ -157,6 +158,17 @@ func (rs *RouteShortcuts) Middleware(middleware ...core.Middleware) *RouteShortc
 	return rs
 }
 
+// WithHeaders sets default response headers for the protected routes.
+// Headers set by the handler take precedence.
+//
+// Example:
+//
+//	server.Protected().WithHeaders(map[string]string{"Cache-Control": "no-store"}).GET("/me", handler)
+func (rs *RouteShortcuts) WithHeaders(headers map[string]string) *RouteShortcuts {
+	rs.headers = headers
+	return rs
+}
+
 // registerProtectedRoute is an internal helper to register protected routes
 func (rs *RouteShortcuts) registerProtectedRoute(method core.Method, path string, handler core.Handler, middleware ...core.Middleware) error {
 	// Create new slice to avoid modifying rs.middleware's backing array
@@ -167,7 +179,8 @@ func (rs *RouteShortcuts) registerProtectedRoute(method core.Method, path string
 	builder := routing.NewRoute(path).
 		Method(method).
 		Handler(handler).
-		Middleware(allMiddleware...)
+		Middleware(allMiddleware...).
+		Headers(rs.headers)
 
 	if rs.isProtected {
 		builder.Protected()
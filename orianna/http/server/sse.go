@@ -0,0 +1,25 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server
+
+import (
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+)
+
+// SSEHub registers a GET route that streams hub events to each client as
+// Server-Sent Events. authFn, when not nil, authorizes the request before it
+// subscribes; see core.SSEHandler.
+//
+// Example:
+//
+//	hub := core.NewHub()
+//	server.SSEHub("/notifications", hub, func(ctx core.Context) error {
+//	    if ctx.Get(core.HeaderAuthorization) == "" {
+//	        return core.NewErrorResponse("UNAUTHORIZED", core.StatusUnauthorized, "Missing credentials")
+//	    }
+//	    return nil
+//	})
+//	hub.Broadcast(core.Event{Event: "notification", Data: `{"id":1}`})
+func (s *Server) SSEHub(path string, hub *core.Hub, authFn func(core.Context) error, middleware ...core.Middleware) error {
+	return s.registerRoute(core.GET, path, core.SSEHandler(hub, authFn), middleware...)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 anthanhphan <an.thanhphan.work@gmail.com>
+
+package server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthanhphan/gosdk/jcodec"
+	"github.com/anthanhphan/gosdk/orianna/http/configuration"
+	"github.com/anthanhphan/gosdk/orianna/http/core"
+	"github.com/anthanhphan/gosdk/orianna/http/internal/testutil"
+	"github.com/anthanhphan/gosdk/orianna/http/server"
+)
+
+func TestServer_SSEHub(t *testing.T) {
+	srv, err := server.NewServer(&configuration.Config{ServiceName: "test", Port: 0})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	hub := core.NewHub()
+	auth := func(ctx core.Context) error {
+		if ctx.Get(core.HeaderAuthorization) == "" {
+			return core.NewErrorResponse("UNAUTHORIZED", core.StatusUnauthorized, "Missing credentials")
+		}
+		return nil
+	}
+	if err := srv.SSEHub("/events", hub, auth); err != nil {
+		t.Fatalf("SSEHub() error = %v", err)
+	}
+
+	type result struct {
+		contentType string
+		etag        string
+		body        string
+		err         error
+	}
+	results := make(chan result, 2)
+	// The default ETag and cache middleware must not buffer the stream, with
+	// or without an Accept header naming it
+	for _, accept := range []string{core.MIMETextEventStream, ""} {
+		go func() {
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			req.Header.Set(core.HeaderAuthorization, "Bearer token")
+			if accept != "" {
+				req.Header.Set(core.HeaderAccept, accept)
+			}
+			resp, err := srv.Test(req, 5*time.Second)
+			if err != nil {
+				results <- result{err: err}
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			results <- result{resp.Header.Get(core.HeaderContentType), resp.Header.Get("ETag"), string(body), err}
+		}()
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.Subscribers() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Subscribers() = %d, want 2", hub.Subscribers())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	hub.Broadcast(core.Event{ID: "1", Event: "notification", Data: `{"msg":"hello"}`})
+	hub.Close()
+
+	for range 2 {
+		r := <-results
+		if r.err != nil {
+			t.Fatalf("stream error = %v", r.err)
+		}
+		if r.contentType != core.MIMETextEventStream {
+			t.Errorf("Content-Type = %q, want %q", r.contentType, core.MIMETextEventStream)
+		}
+		if r.etag != "" {
+			t.Errorf("ETag = %q: the stream was read to hash it", r.etag)
+		}
+		if want := "id: 1\nevent: notification\ndata: {\"msg\":\"hello\"}\n\n"; !strings.Contains(r.body, want) {
+			t.Errorf("body = %q, want it to contain %q", r.body, want)
+		}
+	}
+	for hub.Subscribers() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Subscribers() = %d after Close, want 0", hub.Subscribers())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	_, body := testutil.Send(t, srv, httptest.NewRequest(http.MethodGet, "/events", nil))
+	var out map[string]any
+	if err := jcodec.Unmarshal([]byte(body), &out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if out["code"] != "UNAUTHORIZED" {
+		t.Errorf("unauthenticated response = %v, want UNAUTHORIZED", out)
+	}
+}
@@ -17,4 +17,5 @@ var (
 	UserID        = Key{"user_id"}
 	TenantID      = Key{"tenant_id"}
 	CorrelationID = Key{"correlation_id"}
+	RouteName     = Key{"route_name"}
 )
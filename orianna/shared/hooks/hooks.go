@@ -26,6 +26,10 @@ type (
 	// OnErrorHook is called when an error occurs during request handling.
 	OnErrorHook[C any] func(ctx C, err error)
 
+	// OnClientDisconnectHook is called instead of OnErrorHook when request
+	// handling failed because the client went away (broken pipe, connection reset).
+	OnClientDisconnectHook[C any] func(ctx C, err error)
+
 	// OnPanicHook is called when a panic is recovered during request handling.
 	OnPanicHook[C any] func(ctx C, recovered any, stack []byte)
 
@@ -43,6 +47,7 @@ type Hooks[C any, R any] struct {
 	onRequest     []OnRequestHook[C]
 	onResponse    []OnResponseHook[C, R]
 	onError       []OnErrorHook[C]
+	onDisconnect  []OnClientDisconnectHook[C]
 	onPanic       []OnPanicHook[C]
 	onShutdown    []OnShutdownHook
 	onServerStart []OnServerStartHook
@@ -73,6 +78,12 @@ func (h *Hooks[C, R]) AddOnError(hook OnErrorHook[C]) *Hooks[C, R] {
 	return h
 }
 
+// AddOnClientDisconnect adds a client disconnect hook.
+func (h *Hooks[C, R]) AddOnClientDisconnect(hook OnClientDisconnectHook[C]) *Hooks[C, R] {
+	h.onDisconnect = append(h.onDisconnect, hook)
+	return h
+}
+
 // AddOnPanic adds a panic hook.
 func (h *Hooks[C, R]) AddOnPanic(hook OnPanicHook[C]) *Hooks[C, R] {
 	h.onPanic = append(h.onPanic, hook)
@@ -119,6 +130,14 @@ func (h *Hooks[C, R]) ExecuteOnError(ctx C, err error) {
 	}
 }
 
+// ExecuteOnClientDisconnect executes all client disconnect hooks.
+func (h *Hooks[C, R]) ExecuteOnClientDisconnect(ctx C, err error) {
+	defer recoverHookPanic("OnClientDisconnect")
+	for _, hook := range h.onDisconnect {
+		hook(ctx, err)
+	}
+}
+
 // ExecuteOnPanic executes all panic hooks.
 func (h *Hooks[C, R]) ExecuteOnPanic(ctx C, recovered any, stack []byte) {
 	defer recoverHookPanic("OnPanic")
@@ -25,6 +25,11 @@ func TestHooks_Lifecycle(t *testing.T) {
 		errOk = true
 	})
 
+	disconnectOk := false
+	h.AddOnClientDisconnect(func(ctx context.Context, err error) {
+		disconnectOk = true
+	})
+
 	panicOk := false
 	h.AddOnPanic(func(ctx context.Context, recovered any, stack []byte) {
 		panicOk = true
@@ -46,11 +51,12 @@ func TestHooks_Lifecycle(t *testing.T) {
 	h.ExecuteOnRequest(ctx)
 	h.ExecuteOnResponse(ctx, 200, time.Millisecond)
 	h.ExecuteOnError(ctx, errors.New("test"))
+	h.ExecuteOnClientDisconnect(ctx, errors.New("broken pipe"))
 	h.ExecuteOnPanic(ctx, "panic", []byte{})
 	h.ExecuteOnShutdown()
 	err := h.ExecuteOnServerStart(nil)
 
-	if !reqOk || !resOk || !errOk || !panicOk || !shutOk || !startOk {
+	if !reqOk || !resOk || !errOk || !disconnectOk || !panicOk || !shutOk || !startOk {
 		t.Error("one or more hooks failed to execute properly")
 	}
 	if err != startErr {
@@ -70,6 +76,9 @@ func TestHooks_PanicRecovery(t *testing.T) {
 	h.AddOnError(func(ctx context.Context, err error) { panic("boom") })
 	h.ExecuteOnError(context.Background(), nil) // Should recover
 
+	h.AddOnClientDisconnect(func(ctx context.Context, err error) { panic("boom") })
+	h.ExecuteOnClientDisconnect(context.Background(), nil) // Should recover
+
 	h.AddOnPanic(func(ctx context.Context, r any, s []byte) { panic("boom") })
 	h.ExecuteOnPanic(context.Background(), nil, nil) // Should recover
 
@@ -15,6 +15,15 @@ const (
 	// SuffixInFlightRequests is the suffix for the in-flight gauge.
 	SuffixInFlightRequests = "_in_flight_requests"
 
+	// SuffixRequestSizeBytes is the suffix for the request body size histogram (HTTP only).
+	SuffixRequestSizeBytes = "_request_size_bytes"
+
+	// SuffixResponseSizeBytes is the suffix for the response body size histogram (HTTP only).
+	SuffixResponseSizeBytes = "_response_size_bytes"
+
+	// SuffixSlowRequestsTotal is the suffix for the slow request counter (HTTP only).
+	SuffixSlowRequestsTotal = "_slow_requests_total"
+
 	// SuffixStreamsTotal is the suffix for the stream counter (gRPC only).
 	SuffixStreamsTotal = "_streams_total"
 
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package utils
+
+import "fmt"
+
+// SafeGo runs fn in a new goroutine with panic recovery and reports its outcome
+// on the returned channel. The channel receives exactly one value -- fn's
+// returned error (nil on success) or an error describing a recovered panic with
+// its location -- and is then closed.
+//
+// Input:
+//   - fn: The function to run
+//
+// Output:
+//   - <-chan error: Buffered channel that receives the result and is then closed
+//
+// Example:
+//
+//	errCh := utils.SafeGo(func() error {
+//	    return syncInventory(ctx)
+//	})
+//	if err := <-errCh; err != nil {
+//	    log.Printf("sync failed: %v", err)
+//	}
+func SafeGo(fn func() error) <-chan error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		errCh <- callWithRecover(fn)
+	}()
+
+	return errCh
+}
+
+// callWithRecover invokes fn and converts a panic into an error carrying the panic location.
+func callWithRecover(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			location, locErr := GetPanicLocation()
+			if locErr != nil {
+				location = "unknown"
+			}
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("panic recovered at %s: %w", location, e)
+				return
+			}
+			err = fmt.Errorf("panic recovered at %s: %v", location, r)
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 anthanhphan <an.thanhphan.work@gmail.com>
+
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// receive reads one value from errCh and verifies the channel is then closed.
+func receive(t *testing.T, errCh <-chan error) error {
+	t.Helper()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-time.After(time.Second):
+		t.Fatal("SafeGo() did not deliver a result")
+	}
+
+	select {
+	case _, ok := <-errCh:
+		if ok {
+			t.Error("SafeGo() channel should be closed after the result")
+		}
+	case <-time.After(time.Second):
+		t.Error("SafeGo() channel was not closed")
+	}
+	return err
+}
+
+func TestSafeGo(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name  string
+		fn    func() error
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "success should deliver nil",
+			fn:   func() error { return nil },
+			check: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("SafeGo() error = %v, want nil", err)
+				}
+			},
+		},
+		{
+			name: "returned error should be delivered as is",
+			fn:   func() error { return errBoom },
+			check: func(t *testing.T, err error) {
+				if err != errBoom {
+					t.Errorf("SafeGo() error = %v, want %v", err, errBoom)
+				}
+			},
+		},
+		{
+			name: "string panic should deliver error with location",
+			fn:   func() error { panic("kaboom") },
+			check: func(t *testing.T, err error) {
+				if err == nil {
+					t.Fatal("SafeGo() error = nil, want panic error")
+				}
+				if !strings.Contains(err.Error(), "kaboom") {
+					t.Errorf("SafeGo() error = %v, want to contain panic value", err)
+				}
+				if !strings.Contains(err.Error(), "safego_test.go") {
+					t.Errorf("SafeGo() error = %v, want to contain panic location", err)
+				}
+			},
+		},
+		{
+			name: "error panic should wrap the panic value",
+			fn:   func() error { panic(errBoom) },
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, errBoom) {
+					t.Errorf("SafeGo() error = %v, want to wrap %v", err, errBoom)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, receive(t, SafeGo(tt.fn)))
+		})
+	}
+}
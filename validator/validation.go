@@ -71,9 +71,14 @@ func getCustomRule(name string) (CustomValidationRule, bool) {
 // ============================================================================
 
 // ValidationError represents a validation error with field and message.
+// Rule and Value are filled in for errors produced by a tag rule: Rule is the
+// rule name (e.g., "email") and Value is the offending field value, with
+// pointers dereferenced.
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
+	Rule    string `json:"rule,omitempty"`
+	Value   any    `json:"value,omitempty"`
 }
 
 func (e *ValidationError) Error() string {
@@ -111,6 +116,45 @@ func (e ValidationErrors) ToArray() []map[string]string {
 	return result
 }
 
+// Collapse keeps only the first error of each field, in the order fields were
+// validated. Rules run in tag order, so list the most important rule first
+// (e.g., `validate:"required,email"` reports required rather than email).
+func (e ValidationErrors) Collapse() ValidationErrors {
+	if len(e) == 0 {
+		return e
+	}
+	seen := make(map[string]struct{}, len(e))
+	result := make(ValidationErrors, 0, len(e))
+	for _, err := range e {
+		if _, dup := seen[err.Field]; dup {
+			continue
+		}
+		seen[err.Field] = struct{}{}
+		result = append(result, err)
+	}
+	return result
+}
+
+// ToDetailedArray is like ToArray but also includes the rule name and the
+// offending value of each error, when known.
+func (e ValidationErrors) ToDetailedArray() []map[string]any {
+	if len(e) == 0 {
+		return nil
+	}
+	result := make([]map[string]any, len(e))
+	for i, err := range e {
+		entry := map[string]any{"field": strings.ToLower(err.Field), "message": err.Message}
+		if err.Rule != "" {
+			entry["rule"] = err.Rule
+		}
+		if err.Value != nil {
+			entry["value"] = err.Value
+		}
+		result[i] = entry
+	}
+	return result
+}
+
 // ============================================================================
 // Pre-resolved Rule Cache
 // ============================================================================
@@ -153,15 +197,15 @@ func resolveRuleHandler(raw string) resolvedRule {
 	if customFn, ok := getCustomRule(name); ok {
 		p := param // capture
 		return resolvedRule{
-			handler: func(fieldName string, value reflect.Value, _ string) *ValidationError {
+			handler: withRuleDetails(name, func(fieldName string, value reflect.Value, _ string) *ValidationError {
 				return customFn(fieldName, value, p)
-			},
+			}),
 		}
 	}
 
 	// Lookup built-in factory — creates handler with param pre-parsed
 	if factory, ok := builtinFactories[name]; ok {
-		return resolvedRule{handler: factory(param)}
+		return resolvedRule{handler: withRuleDetails(name, factory(param))}
 	}
 
 	// Unknown rule
@@ -173,6 +217,39 @@ func resolveRuleHandler(raw string) resolvedRule {
 	}
 }
 
+// withRuleDetails fills in Rule and Value on the errors returned by handler,
+// unless the handler already set them.
+func withRuleDetails(name string, handler ruleHandler) ruleHandler {
+	return func(fieldName string, value reflect.Value, param string) *ValidationError {
+		err := handler(fieldName, value, param)
+		if err == nil {
+			return nil
+		}
+		if err.Rule == "" {
+			err.Rule = name
+		}
+		if err.Value == nil {
+			err.Value = offendingValue(value)
+		}
+		return err
+	}
+}
+
+// offendingValue returns the value to report for a failed rule, or nil for
+// nil pointers and values that cannot be exposed.
+func offendingValue(value reflect.Value) any {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() || !value.CanInterface() {
+		return nil
+	}
+	return value.Interface()
+}
+
 // getOrParseFields returns cached field metadata for the given struct type.
 func getOrParseFields(typ reflect.Type) []cachedField {
 	if cached, ok := structFieldCache.Load(typ); ok {
@@ -65,6 +65,40 @@ func TestValidationErrors_ToArray(t *testing.T) {
 	}
 }
 
+func TestValidationErrors_Collapse(t *testing.T) {
+	type signup struct {
+		Email string `validate:"min=5,email"`
+		Age   int    `validate:"min=18"`
+	}
+	err := Validate(signup{Email: "ab", Age: 12})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %v", err)
+	}
+
+	collapsed := errs.Collapse()
+	if len(collapsed) != 2 {
+		t.Fatalf("Collapse() kept %d errors, want 2: %v", len(collapsed), collapsed)
+	}
+	if collapsed[0].Field != "Email" || collapsed[0].Rule != "min" || collapsed[0].Value != "ab" {
+		t.Errorf("first Email error = %+v, want rule min with value ab", collapsed[0])
+	}
+	if collapsed[1].Rule != "min" || collapsed[1].Value != 12 {
+		t.Errorf("Age error = %+v, want rule min with value 12", collapsed[1])
+	}
+	if errs[1].Rule != "email" {
+		t.Errorf("full list should keep the email error, got %+v", errs[1])
+	}
+
+	arr := collapsed.ToDetailedArray()
+	if arr[0]["field"] != "email" || arr[0]["rule"] != "min" || arr[0]["value"] != "ab" {
+		t.Errorf("ToDetailedArray()[0] = %v", arr[0])
+	}
+	if ValidationErrors(nil).ToDetailedArray() != nil {
+		t.Error("nil should return nil")
+	}
+}
+
 // ============================================================================
 // Validate — entry point
 // ============================================================================